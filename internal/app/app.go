@@ -2,25 +2,38 @@ package app
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"log/slog"
+	"net"
 	"net/http"
 	"net/url"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+	"text/tabwriter"
 	"time"
 
 	"github.com/jbenzshawel/playlist-generator/internal/app/commands/playlists"
+	"github.com/jbenzshawel/playlist-generator/internal/app/commands/playlists/applemusic"
 	"github.com/jbenzshawel/playlist-generator/internal/app/commands/playlists/spotify"
+	"github.com/jbenzshawel/playlist-generator/internal/app/commands/playlists/subsonic"
+	"github.com/jbenzshawel/playlist-generator/internal/app/commands/playlists/tidal"
 	"github.com/jbenzshawel/playlist-generator/internal/app/commands/sources"
-	"github.com/jbenzshawel/playlist-generator/internal/app/commands/sources/studioone"
 	"github.com/jbenzshawel/playlist-generator/internal/app/config"
+	"github.com/jbenzshawel/playlist-generator/internal/common/compare"
 	"github.com/jbenzshawel/playlist-generator/internal/common/dateformat"
+	"github.com/jbenzshawel/playlist-generator/internal/domain"
+	"github.com/jbenzshawel/playlist-generator/internal/infrastructure/clients/applemusicclient"
 	"github.com/jbenzshawel/playlist-generator/internal/infrastructure/clients/httpclient/oauth"
 	"github.com/jbenzshawel/playlist-generator/internal/infrastructure/clients/spotifyclient"
-	"github.com/jbenzshawel/playlist-generator/internal/infrastructure/clients/studiooneclient"
+	"github.com/jbenzshawel/playlist-generator/internal/infrastructure/clients/subsonicclient"
+	"github.com/jbenzshawel/playlist-generator/internal/infrastructure/clients/tidalclient"
 	"github.com/jbenzshawel/playlist-generator/internal/infrastructure/storage"
 )
 
-const dsn = "file:db/app.db?_busy_timeout=5000&_pragma=journal_mode(WAL)"
+const defaultDBPath = "db/app.db"
 
 type Action string
 
@@ -29,134 +42,422 @@ const (
 	SyncMonthAction Action = "syncMonth"
 	RecurringAction Action = "recurring"
 	RandomAction    Action = "random"
+	RollingAction   Action = "rolling"
+	ProgramsAction  Action = "programs"
+	RematchAction   Action = "rematch"
+	MatchAction     Action = "match"
+	ReviewAction    Action = "review"
+	AuthAction      Action = "auth"
+	MigrateAction   Action = "migrate"
+	ExportAction    Action = "export"
+
+	ResearchMissingAction Action = "researchMissing"
+)
+
+const (
+	defaultRollingDays   = 30
+	defaultMinConfidence = 80.0
+
+	defaultRedirectHost = "127.0.0.1"
+	defaultRedirectPort = 3000
+
+	spotifyCallbackPath = "/callback"
+	tidalCallbackPath   = "/tidal/callback"
 )
 
 type Application struct {
 	commands
+
+	server *server
+
+	// programPlaylists lists the programs, by source name, that get their own playlist
+	programPlaylists map[string][]string
 }
 
 type commands struct {
-	Sources   sources.Commands
+	Sources   *sources.Registry
 	Playlists playlists.Commands
 }
 
-func NewApplication(ctx context.Context) (Application, func()) {
+// NewApplication loads the config, opens the database, and logs in to the streaming
+// providers. The fields of storageFlags that are set override the storage config.
+func NewApplication(ctx context.Context, storageFlags config.Storage) (Application, func(), error) {
 	cfg, err := config.Load()
 	if err != nil {
 		panic(fmt.Errorf("failed to load config: %w", err))
 	}
 
-	store, err := storage.Initialize(ctx, dsn)
+	store, err := storage.Initialize(ctx, storageDSN(cfg.Storage, storageFlags))
 	if err != nil {
 		panic(fmt.Errorf("failed to initialize database: %w", err))
 	}
 
-	// A callback endpoint is required to complete the OAuth authentication code flow
-	go func() {
-		err := http.ListenAndServe(":3000", nil)
-		if err != nil {
-			panic(fmt.Errorf("failed to start http server: %w", err))
-		}
-	}()
+	err = validateRedirectPorts(cfg.Clients)
+	if err != nil {
+		store.Close()
+		return Application{}, nil, err
+	}
+
+	srv := newServer(serverAddress(cfg))
 
 	closer := func() {
+		srv.Shutdown()
 		store.Close()
 	}
 
-	spotifyClient := setupSpotifyClient(ctx, cfg.SpotifyClient)
-	repository := storage.NewRepository(store)
+	spotifyClient, err := setupSpotifyClient(ctx, cfg.SpotifyClient, storage.NewTokenStore(store, "spotify"), srv)
+	if err != nil {
+		closer()
+		return Application{}, nil, err
+	}
 
-	iprBaseURL, err := url.Parse(cfg.IowaPublicRadio.BaseURL)
+	tidalClient, err := setupTidalClient(ctx, cfg.Tidal, storage.NewTokenStore(store, "tidal"), srv)
 	if err != nil {
-		panic(fmt.Errorf("failed to parse IowaPublicRadio.BaseURL: %w", err))
+		closer()
+		return Application{}, nil, err
 	}
 
-	iprClient := studiooneclient.New(studiooneclient.Config{
-		BaseURL: iprBaseURL,
-	})
+	// the callback endpoints are only needed while logging in, the review action starts
+	// the server again
+	srv.Shutdown()
+
+	repository := storage.NewRepository(store)
 
 	return Application{
 		commands: commands{
-			Sources:   sources.NewCommands(iprClient, repository),
-			Playlists: playlists.NewCommands(spotifyClient, repository),
+			Sources: newSourceRegistry(cfg.Clients, repository),
+			Playlists: playlists.NewCommands(
+				spotifyClient,
+				newAppleMusicClient(cfg.AppleMusic),
+				tidalClient,
+				newSubsonicClient(cfg.Subsonic),
+				repository,
+				mustMatchScoring(cfg.Matching),
+			),
 		},
-	}, closer
+		server:           srv,
+		programPlaylists: cfg.Playlists.Programs,
+	}, closer, nil
+}
+
+func setupSpotifyClient(ctx context.Context, clientConfig config.OAuthClient, tokenStore oauth.TokenStore, srv *server) (*spotifyclient.Client, error) {
+	spotifyClientBaseURL, err := url.Parse(clientConfig.BaseURL)
+	if err != nil {
+		panic(fmt.Errorf("failed to parse SpotifyClient.BaseURL: %w", err))
+	}
+
+	client, err := loginClient(ctx, "spotify", newSpotifyAuthenticator(clientConfig, tokenStore), srv, spotifyCallbackPath)
+	if err != nil || client == nil {
+		return nil, err
+	}
+
+	return spotifyclient.New(spotifyclient.Config{
+		BaseURL: spotifyClientBaseURL,
+		Client:  client,
+	}), nil
+}
+
+// setupTidalClient returns the Tidal client, or nil when Tidal isn't configured.
+func setupTidalClient(ctx context.Context, clientConfig config.Tidal, tokenStore oauth.TokenStore, srv *server) (tidal.Client, error) {
+	if clientConfig.BaseURL == "" {
+		return nil, nil
+	}
+
+	baseURL := mustParseURL("Tidal.BaseURL", clientConfig.BaseURL)
+
+	client, err := loginClient(ctx, "tidal", newTidalAuthenticator(clientConfig, tokenStore), srv, tidalCallbackPath)
+	if err != nil || client == nil {
+		return nil, err
+	}
+
+	return tidalclient.New(tidalclient.Config{
+		BaseURL:     baseURL,
+		Client:      client,
+		CountryCode: clientConfig.CountryCode,
+	}), nil
 }
 
-func setupSpotifyClient(ctx context.Context, clientConfig config.OAuthClient) *spotifyclient.Client {
-	auth := oauth.NewAuthenticator(oauth.AuthenticatorConfig{
+// loginClient returns a client authenticated with the stored token. When there is no
+// stored token or it was rejected the user logs in through the callback endpoint at
+// callbackPath, and nil is returned if the context is done first.
+func loginClient(ctx context.Context, name string, auth oauthAuthenticator, srv *server, callbackPath string) (*http.Client, error) {
+	// Reuse the token from a previous login when it's still valid or can be refreshed
+	storedClient, err := auth.StoredClient(ctx)
+	switch {
+	case err == nil:
+		return storedClient, nil
+	case errors.Is(err, oauth.ErrTokenRejected):
+		slog.Warn("stored token rejected, login required", slog.String("provider", name), slog.Any("error", err))
+	case !errors.Is(err, oauth.ErrNoToken):
+		// logging in doesn't fix an unreachable token endpoint or database, and an
+		// unattended run would wait for a browser that never opens
+		return nil, fmt.Errorf("%s stored token error: %w", name, err)
+	}
+
+	loginURL, err := auth.AuthCodeURL()
+	if err != nil {
+		return nil, fmt.Errorf("%s auth code url failed: %w", name, err)
+	}
+
+	chOAuthClient := make(chan *http.Client)
+
+	// A callback endpoint is required to complete the OAuth authentication code flow
+	err = srv.Handle(ctx, callbackPath, auth.GetAuthCodeCallbackHandler(ctx, chOAuthClient))
+	if err != nil {
+		return nil, fmt.Errorf("%s login callback server failed to start: %w", name, err)
+	}
+
+	fmt.Printf("Click the following URL to complete %s login: %s\n", name, loginURL)
+
+	select {
+	case <-ctx.Done():
+	case oauthClient := <-chOAuthClient:
+		return oauthClient, nil
+	}
+
+	return nil, nil
+}
+
+// newAppleMusicClient returns the Apple Music client, or nil when Apple Music isn't configured.
+func newAppleMusicClient(cfg config.AppleMusic) applemusic.Client {
+	if cfg.BaseURL == "" {
+		return nil
+	}
+
+	pemBytes, err := os.ReadFile(cfg.PrivateKeyPath)
+	if err != nil {
+		panic(fmt.Errorf("failed to read AppleMusic.PrivateKeyPath: %w", err))
+	}
+
+	privateKey, err := applemusicclient.ParsePrivateKey(pemBytes)
+	if err != nil {
+		panic(fmt.Errorf("failed to parse AppleMusic.PrivateKeyPath: %w", err))
+	}
+
+	return applemusicclient.New(applemusicclient.Config{
+		BaseURL:        mustParseURL("AppleMusic.BaseURL", cfg.BaseURL),
+		Storefront:     cfg.Storefront,
+		TeamID:         cfg.TeamID,
+		KeyID:          cfg.KeyID,
+		PrivateKey:     privateKey,
+		MusicUserToken: cfg.MusicUserToken,
+	})
+}
+
+// newSubsonicClient returns the Subsonic client, or nil when a Subsonic server isn't configured.
+func newSubsonicClient(cfg config.Subsonic) subsonic.Client {
+	if cfg.BaseURL == "" {
+		return nil
+	}
+
+	return subsonicclient.New(subsonicclient.Config{
+		BaseURL:  mustParseURL("Subsonic.BaseURL", cfg.BaseURL),
+		Username: cfg.Username,
+		Password: cfg.Password,
+	})
+}
+
+func newSpotifyAuthenticator(clientConfig config.OAuthClient, tokenStore oauth.TokenStore) oauthAuthenticator {
+	return oauth.NewAuthenticator(oauth.AuthenticatorConfig{
 		ClientID:     clientConfig.ClientID,
 		ClientSecret: clientConfig.ClientSecret,
 		AuthURL:      clientConfig.AuthURL,
 		TokenURL:     clientConfig.TokenURL,
-		RedirectURL:  "http://127.0.0.1:3000/callback",
+		RedirectURL:  redirectURL(clientConfig),
 		Scopes: []string{
 			"playlist-read-private",
 			"playlist-modify-private",
 			"playlist-modify-public",
 		},
+		TokenStore: tokenStore,
 	})
+}
 
-	loginURL, err := auth.AuthCodeURL()
-	if err != nil {
-		panic(fmt.Errorf("auth code url failed: %w", err))
-	}
+func newTidalAuthenticator(clientConfig config.Tidal, tokenStore oauth.TokenStore) oauthAuthenticator {
+	return oauth.NewAuthenticator(oauth.AuthenticatorConfig{
+		ClientID:     clientConfig.ClientID,
+		ClientSecret: clientConfig.ClientSecret,
+		AuthURL:      clientConfig.AuthURL,
+		TokenURL:     clientConfig.TokenURL,
+		RedirectURL:  callbackURL(clientConfig.OAuthClient, tidalCallbackPath),
+		Scopes: []string{
+			"playlists.read",
+			"playlists.write",
+			"search.read",
+		},
+		PKCE:       true,
+		TokenStore: tokenStore,
+	})
+}
 
-	chOAuthClient := make(chan *http.Client)
-	completeAuthHandler := auth.GetAuthCodeCallbackHandler(ctx, chOAuthClient)
+// redirectURL is the Spotify login redirect URL, served by the callback endpoint.
+func redirectURL(clientConfig config.OAuthClient) string {
+	return callbackURL(clientConfig, spotifyCallbackPath)
+}
 
-	http.HandleFunc("/callback", completeAuthHandler)
+// callbackURL is the URL of a login callback endpoint on the redirect host and port.
+func callbackURL(clientConfig config.OAuthClient, path string) string {
+	host := clientConfig.RedirectHost
+	if host == "" {
+		host = defaultRedirectHost
+	}
 
-	fmt.Printf("Click the following URL to complete spotify login: %s\n", loginURL)
+	return fmt.Sprintf("http://%s%s", net.JoinHostPort(host, strconv.Itoa(redirectPort(clientConfig))), path)
+}
 
-	select {
-	case <-ctx.Done():
-	case spotifyOAuthClient := <-chOAuthClient:
-		spotifyClientBaseURL, err := url.Parse(clientConfig.BaseURL)
-		if err != nil {
-			panic(fmt.Errorf("failed to parse SpotifyClient.BaseURL: %w", err))
-		}
+func redirectPort(clientConfig config.OAuthClient) int {
+	if clientConfig.RedirectPort == 0 {
+		return defaultRedirectPort
+	}
+	return clientConfig.RedirectPort
+}
 
-		return spotifyclient.New(spotifyclient.Config{
-			BaseURL: spotifyClientBaseURL,
-			Client:  spotifyOAuthClient,
-		})
+// validateRedirectPorts checks the Tidal login redirects to the port of the Spotify
+// login, since one server on the Spotify redirect port serves both login callbacks.
+func validateRedirectPorts(cfg config.Clients) error {
+	if cfg.Tidal.BaseURL == "" {
+		return nil
+	}
+
+	spotifyPort, tidalPort := redirectPort(cfg.SpotifyClient), redirectPort(cfg.Tidal.OAuthClient)
+	if tidalPort != spotifyPort {
+		return fmt.Errorf("tidal redirect port %d doesn't match the spotify redirect port %d - both login callbacks are served on one port", tidalPort, spotifyPort)
 	}
 
 	return nil
 }
 
+// storageDSN is the DSN of the database in the storage config, with the fields of flags
+// that are set taking precedence. A DSN is used over a path from the same source.
+func storageDSN(cfg, flags config.Storage) string {
+	switch {
+	case flags.DSN != "":
+		return flags.DSN
+	case flags.Path != "":
+		return storage.SQLiteDSN(flags.Path)
+	case cfg.DSN != "":
+		return cfg.DSN
+	case cfg.Path != "":
+		return storage.SQLiteDSN(cfg.Path)
+	default:
+		return storage.SQLiteDSN(defaultDBPath)
+	}
+}
+
+// serverAddress is the address the HTTP server listens on, which defaults to the redirect
+// port on the loopback address so the server receives the login callback and the review
+// page isn't reachable from other machines.
+func serverAddress(cfg config.Config) string {
+	if cfg.Server.Address != "" {
+		return cfg.Server.Address
+	}
+	return fmt.Sprintf("127.0.0.1:%d", redirectPort(cfg.SpotifyClient))
+}
+
+// mustMatchScoring builds how songs are scored against search results from the matching config.
+func mustMatchScoring(cfg config.Matching) domain.MatchScoring {
+	scoring, err := domain.NewMatchScoring(
+		compare.Strategy(cfg.Strategy),
+		cfg.Weights.Artist, cfg.Weights.Track, cfg.Weights.Album,
+		cfg.ReviewBelow,
+	)
+	if err != nil {
+		panic(fmt.Errorf("failed to parse Matching: %w", err))
+	}
+	return scoring
+}
+
 type RunConfig struct {
-	Action    Action
-	Date      string
-	Month     string
+	Action Action
+	Date   string
+	Month  string
+	Scope  string
+	// To is the inclusive end date of the programs action's date range
+	To        string
 	Interval  time.Duration
 	NumTracks int
+	// RollingDays is the window size of rolling playlists. The recurring action only
+	// updates rolling playlists when it is set.
+	RollingDays int
+	// MinConfidence is the match confidence, from 0 to 100, below which the rematch
+	// action searches for a song again
+	MinConfidence float64
+	// Match selects the songs and track for the match action
+	Match spotify.MatchSongCommand
 }
 
 func (a Application) Run(ctx context.Context, cfg RunConfig) {
+	scope, err := domain.ParsePlaylistDateScope(cfg.Scope)
+	if err != nil {
+		panic(fmt.Errorf("invalid scope - day, month, or year expected: %w", err))
+	}
+
 	switch cfg.Action {
 	case SyncDayAction:
-		err := a.genStudioOneSpotifyPlaylistsForDay(ctx, cfg.Date)
+		err := a.genPlaylistsForDay(ctx, cfg.Date, scope)
 		if err != nil {
-			slog.Error("gen studio one playlist error", slog.Any("error", err), slog.String("date", cfg.Date))
+			slog.Error("gen playlists error", slog.Any("error", err), slog.String("date", cfg.Date))
 		}
 	case SyncMonthAction:
-		a.genStudioOneSpotifyPlaylistForMonth(ctx, cfg.Month)
+		a.genSpotifyPlaylistsForMonth(ctx, cfg.Month, scope)
 	case RecurringAction:
-		a.startRecurringJob(ctx, cfg.Interval)
+		a.startRecurringJob(ctx, cfg.Interval, scope, cfg.RollingDays)
 	case RandomAction:
 		err := a.randomPlaylist(ctx, cfg.NumTracks)
 		if err != nil {
 			slog.Error("update random playlist error", slog.Any("error", err))
 		}
+	case RollingAction:
+		days := cfg.RollingDays
+		if days == 0 {
+			days = defaultRollingDays
+		}
+		err := a.genSpotifyRollingPlaylists(ctx, days)
+		if err != nil {
+			slog.Error("gen spotify rolling playlists error", slog.Any("error", err), slog.Int("days", days))
+		}
+	case ProgramsAction:
+		to := cfg.To
+		if to == "" {
+			to = cfg.Date
+		}
+		err := a.listPrograms(ctx, cfg.Date, to)
+		if err != nil {
+			slog.Error("list programs error", slog.Any("error", err), slog.String("date", cfg.Date), slog.String("to", to))
+		}
+	case RematchAction:
+		minConfidence := cfg.MinConfidence
+		if minConfidence == 0 {
+			minConfidence = defaultMinConfidence
+		}
+		err := a.rematch(ctx, minConfidence)
+		if err != nil {
+			slog.Error("rematch spotify tracks error", slog.Any("error", err), slog.Float64("minConfidence", minConfidence))
+		}
+	case ResearchMissingAction:
+		err := a.researchMissing(ctx)
+		if err != nil {
+			slog.Error("research missing songs error", slog.Any("error", err))
+		}
+	case MatchAction:
+		res, err := a.Playlists.Spotify.MatchSong.Execute(ctx, cfg.Match)
+		if err != nil {
+			slog.Error("match song error", slog.Any("error", err))
+			return
+		}
+		slog.Info("song matches overridden", slog.Int("numSongs", len(res.Songs)))
+	case ReviewAction:
+		err := a.reviewMatches(ctx)
+		if err != nil {
+			slog.Error("review matches error", slog.Any("error", err))
+		}
 	default:
 		panic(fmt.Errorf("unknown action %q", cfg.Action))
 	}
 
 }
 
-func (a Application) startRecurringJob(ctx context.Context, interval time.Duration) {
+func (a Application) startRecurringJob(ctx context.Context, interval time.Duration, scope domain.PlaylistDateScope, rollingDays int) {
 	slog.Info("starting recurring job", slog.String("interval", fmt.Sprintf("%v minutes", interval.Minutes())))
 
 	ticker := time.NewTicker(interval)
@@ -169,9 +470,16 @@ func (a Application) startRecurringJob(ctx context.Context, interval time.Durati
 			select {
 			case <-ticker.C:
 				date := time.Now().Format(time.DateOnly)
-				err := a.genStudioOneSpotifyPlaylistsForDay(ctx, date)
+				err := a.genPlaylistsForDay(ctx, date, scope)
 				if err != nil {
-					slog.Error("gen studio one playlist error", slog.Any("error", err), slog.String("date", date))
+					slog.Error("gen playlists error", slog.Any("error", err), slog.String("date", date))
+				}
+
+				if rollingDays > 0 {
+					err = a.syncSpotifyRollingPlaylists(ctx, rollingDays)
+					if err != nil {
+						slog.Error("sync spotify rolling playlists error", slog.Any("error", err), slog.Int("days", rollingDays))
+					}
 				}
 			case <-ctx.Done():
 				slog.Info("stopping recurring job")
@@ -184,7 +492,7 @@ func (a Application) startRecurringJob(ctx context.Context, interval time.Durati
 	<-done
 }
 
-func (a Application) genStudioOneSpotifyPlaylistForMonth(ctx context.Context, month string) {
+func (a Application) genSpotifyPlaylistsForMonth(ctx context.Context, month string, scope domain.PlaylistDateScope) {
 	date, err := time.Parse(dateformat.YearMonth, month)
 	if err != nil {
 		panic(fmt.Errorf("invalid single mode month - YYYY-MM format expected: %w", err))
@@ -196,30 +504,302 @@ func (a Application) genStudioOneSpotifyPlaylistForMonth(ctx context.Context, mo
 		case <-ctx.Done():
 		default:
 			day := date.Format(time.DateOnly)
-			err = a.genStudioOneSpotifyPlaylistsForDay(ctx, day)
+			err = a.genPlaylistsForDay(ctx, day, scope)
 			if err != nil {
-				slog.Error("gen studio one playlist error", slog.Any("error", err), slog.String("date", day))
+				slog.Error("gen playlists error", slog.Any("error", err), slog.String("date", day))
 			}
 			date = date.AddDate(0, 0, 1)
 		}
 	}
 }
 
-func (a Application) genStudioOneSpotifyPlaylistsForDay(ctx context.Context, date string) error {
-	slog.Info("adding songs from Studio One to Spotify playlist", slog.String("date", date))
+func (a Application) genPlaylistsForDay(ctx context.Context, date string, scope domain.PlaylistDateScope) error {
+	slog.Info("adding songs from sources to playlists", slog.String("date", date), slog.String("scope", scope.String()))
+
+	errs := []error{a.downloadSongsForDay(ctx, date)}
+
+	for _, source := range a.Sources.All() {
+		err := a.syncPlaylists(ctx, source, "", date, scope)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", source.Name(), err))
+		}
+
+		for _, program := range a.programPlaylists[source.Name()] {
+			err = a.syncPlaylists(ctx, source, program, date, scope)
+			if err != nil {
+				errs = append(errs, fmt.Errorf("%s %s: %w", source.Name(), program, err))
+			}
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// syncPlaylists creates or updates the playlists for a source on every configured
+// streaming provider.
+func (a Application) syncPlaylists(ctx context.Context, source sources.Source, program, date string, scope domain.PlaylistDateScope) error {
+	errs := []error{a.syncSpotifyPlaylist(ctx, source, program, date, scope)}
+
+	if a.Playlists.AppleMusic != nil {
+		errs = append(errs, a.syncAppleMusicPlaylist(ctx, source, program, date, scope))
+	}
+
+	if a.Playlists.Tidal != nil {
+		errs = append(errs, a.syncTidalPlaylist(ctx, source, program, date, scope))
+	}
+
+	if a.Playlists.Subsonic != nil {
+		errs = append(errs, a.syncSubsonicPlaylist(ctx, source, program, date, scope))
+	}
+
+	return errors.Join(errs...)
+}
+
+func (a Application) genSpotifyRollingPlaylists(ctx context.Context, days int) error {
+	date := time.Now().Format(time.DateOnly)
+	slog.Info("updating rolling Spotify playlists", slog.String("date", date), slog.Int("days", days))
+
+	return errors.Join(
+		a.downloadSongsForDay(ctx, date),
+		a.syncSpotifyRollingPlaylists(ctx, days),
+	)
+}
+
+// downloadSongsForDay downloads the songs played at every source on a date and
+// searches Spotify, and Apple Music, Tidal, and Subsonic when configured, for any new songs.
+func (a Application) downloadSongsForDay(ctx context.Context, date string) error {
+	var errs []error
+	for _, source := range a.Sources.All() {
+		err := source.FetchDay(ctx, date)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("%s download song list error: %w", source.Name(), err))
+		}
+	}
+
+	_, err := a.Playlists.Spotify.SearchTracks.Execute(ctx, spotify.SearchTracksCommand{})
+	if err != nil {
+		errs = append(errs, fmt.Errorf("spotify track update error: %w", err))
+	}
+
+	if a.Playlists.AppleMusic != nil {
+		_, err = a.Playlists.AppleMusic.SearchTracks.Execute(ctx, applemusic.SearchTracksCommand{})
+		if err != nil {
+			errs = append(errs, fmt.Errorf("apple music track update error: %w", err))
+		}
+	}
+
+	// Tidal and Subsonic are searched last so they can use the ISRCs of the other providers' matches
+	if a.Playlists.Tidal != nil {
+		_, err = a.Playlists.Tidal.SearchTracks.Execute(ctx, tidal.SearchTracksCommand{})
+		if err != nil {
+			errs = append(errs, fmt.Errorf("tidal track update error: %w", err))
+		}
+	}
+
+	if a.Playlists.Subsonic != nil {
+		_, err = a.Playlists.Subsonic.SearchTracks.Execute(ctx, subsonic.SearchTracksCommand{})
+		if err != nil {
+			errs = append(errs, fmt.Errorf("subsonic track update error: %w", err))
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// rematch searches Spotify again for songs matched with a confidence below minConfidence,
+// and swaps the new matches into the existing playlists that hold the old ones.
+func (a Application) rematch(ctx context.Context, minConfidence float64) error {
+	searchRes, err := a.Playlists.Spotify.SearchTracks.Execute(ctx, spotify.SearchTracksCommand{MinConfidence: minConfidence})
+	if err != nil {
+		return fmt.Errorf("spotify track search error: %w", err)
+	}
+
+	slog.Info("low confidence songs rematched", slog.Int("numSongs", len(searchRes.Matched)))
+
+	if len(searchRes.Matched) == 0 {
+		return nil
+	}
+
+	return a.syncSongPlaylists(ctx, searchRes.Matched)
+}
+
+// researchMissing searches Spotify, and Apple Music, Tidal, and the Subsonic library when
+// configured, again for songs that weren't found, and adds the songs that are found to
+// the existing playlists that should have held them.
+func (a Application) researchMissing(ctx context.Context) error {
+	searchRes, err := a.Playlists.Spotify.SearchTracks.Execute(ctx, spotify.SearchTracksCommand{RetryNotFound: true})
+	if err != nil {
+		return fmt.Errorf("spotify track search error: %w", err)
+	}
+
+	slog.Info("missing songs found", slog.Int("numSongs", len(searchRes.Matched)))
+
+	var errs []error
+	if len(searchRes.Matched) > 0 {
+		errs = append(errs, a.syncSongPlaylists(ctx, searchRes.Matched))
+	}
+
+	if a.Playlists.AppleMusic != nil {
+		errs = append(errs, a.researchMissingAppleMusic(ctx))
+	}
+
+	// Tidal and Subsonic are searched last so they can use the ISRCs of the other providers' matches
+	if a.Playlists.Tidal != nil {
+		errs = append(errs, a.researchMissingTidal(ctx))
+	}
+
+	if a.Playlists.Subsonic != nil {
+		errs = append(errs, a.researchMissingSubsonic(ctx))
+	}
+
+	return errors.Join(errs...)
+}
 
-	_, err := a.Sources.StudioOne.ListSongs.Execute(ctx, studioone.SongListCommand{Date: date})
+// researchMissingAppleMusic searches Apple Music again for songs that weren't found.
+func (a Application) researchMissingAppleMusic(ctx context.Context) error {
+	searchRes, err := a.Playlists.AppleMusic.SearchTracks.Execute(ctx, applemusic.SearchTracksCommand{RetryNotFound: true})
 	if err != nil {
-		return fmt.Errorf("studio one download song list error: %w", err)
+		return fmt.Errorf("apple music track search error: %w", err)
 	}
 
-	_, err = a.Playlists.Spotify.SearchTracks.Execute(ctx, spotify.SearchTracksCommand{})
+	slog.Info("missing apple music songs found", slog.Int("numSongs", len(searchRes.Matched)))
+
+	if len(searchRes.Matched) == 0 {
+		return nil
+	}
+
+	playlistsRes, err := a.Playlists.AppleMusic.SongPlaylists.Execute(ctx, applemusic.SongPlaylistsCommand{Songs: searchRes.Matched})
 	if err != nil {
-		return fmt.Errorf("spotify track update error: %w", err)
+		return fmt.Errorf("find apple music song playlists error: %w", err)
 	}
 
+	var errs []error
+	for _, p := range playlistsRes.Playlists {
+		// an empty date syncs every song played in the playlist's date range
+		_, err = a.Playlists.AppleMusic.SyncPlaylist.Execute(ctx, applemusic.SyncPlaylistCommand{Playlist: p})
+		if err != nil {
+			errs = append(errs, fmt.Errorf("%s: sync apple music playlist error: %w", p.Name(), err))
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// researchMissingTidal searches Tidal again for songs that weren't found.
+func (a Application) researchMissingTidal(ctx context.Context) error {
+	searchRes, err := a.Playlists.Tidal.SearchTracks.Execute(ctx, tidal.SearchTracksCommand{RetryNotFound: true})
+	if err != nil {
+		return fmt.Errorf("tidal track search error: %w", err)
+	}
+
+	slog.Info("missing tidal songs found", slog.Int("numSongs", len(searchRes.Matched)))
+
+	if len(searchRes.Matched) == 0 {
+		return nil
+	}
+
+	playlistsRes, err := a.Playlists.Tidal.SongPlaylists.Execute(ctx, tidal.SongPlaylistsCommand{Songs: searchRes.Matched})
+	if err != nil {
+		return fmt.Errorf("find tidal song playlists error: %w", err)
+	}
+
+	var errs []error
+	for _, p := range playlistsRes.Playlists {
+		// an empty date syncs every song played in the playlist's date range
+		_, err = a.Playlists.Tidal.SyncPlaylist.Execute(ctx, tidal.SyncPlaylistCommand{Playlist: p})
+		if err != nil {
+			errs = append(errs, fmt.Errorf("%s: sync tidal playlist error: %w", p.Name(), err))
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// researchMissingSubsonic searches the Subsonic library again for songs that weren't
+// found, since songs can be added to the library after they air.
+func (a Application) researchMissingSubsonic(ctx context.Context) error {
+	searchRes, err := a.Playlists.Subsonic.SearchTracks.Execute(ctx, subsonic.SearchTracksCommand{RetryNotFound: true})
+	if err != nil {
+		return fmt.Errorf("subsonic track search error: %w", err)
+	}
+
+	slog.Info("missing subsonic songs found", slog.Int("numSongs", len(searchRes.Matched)))
+
+	if len(searchRes.Matched) == 0 {
+		return nil
+	}
+
+	playlistsRes, err := a.Playlists.Subsonic.SongPlaylists.Execute(ctx, subsonic.SongPlaylistsCommand{Songs: searchRes.Matched})
+	if err != nil {
+		return fmt.Errorf("find subsonic song playlists error: %w", err)
+	}
+
+	var errs []error
+	for _, p := range playlistsRes.Playlists {
+		// an empty date syncs every song played in the playlist's date range
+		_, err = a.Playlists.Subsonic.SyncPlaylist.Execute(ctx, subsonic.SyncPlaylistCommand{Playlist: p})
+		if err != nil {
+			errs = append(errs, fmt.Errorf("%s: sync subsonic playlist error: %w", p.Name(), err))
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// syncSongPlaylists adds newly matched songs to the existing playlists that should
+// have held them.
+func (a Application) syncSongPlaylists(ctx context.Context, songs []domain.Song) error {
+	playlistsRes, err := a.Playlists.Spotify.SongPlaylists.Execute(ctx, spotify.SongPlaylistsCommand{Songs: songs})
+	if err != nil {
+		return fmt.Errorf("find song playlists error: %w", err)
+	}
+
+	var errs []error
+	for _, p := range playlistsRes.Playlists {
+		// an empty date syncs every song played in the playlist's date range
+		_, err = a.Playlists.Spotify.SyncPlaylist.Execute(ctx, spotify.SyncPlaylistCommand{Playlist: p})
+		if err != nil {
+			errs = append(errs, fmt.Errorf("%s: sync spotify playlist error: %w", p.Name(), err))
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+func (a Application) syncSpotifyRollingPlaylists(ctx context.Context, days int) error {
+	var errs []error
+	for _, source := range a.Sources.All() {
+		createRes, err := a.Playlists.Spotify.CreatePlaylist.Execute(ctx, spotify.CreatePlaylistCommand{
+			DateScope:   domain.RollingPlaylistDateScope,
+			RollingDays: days,
+			SourceName:  source.Name(),
+			SourceType:  source.SourceType(),
+		})
+		if err != nil {
+			errs = append(errs, fmt.Errorf("%s: create spotify rolling playlist error: %w", source.Name(), err))
+			continue
+		}
+
+		_, err = a.Playlists.Spotify.SyncRollingPlaylist.Execute(ctx, spotify.SyncRollingPlaylistCommand{
+			Playlist: createRes.Playlist,
+		})
+		if err != nil {
+			errs = append(errs, fmt.Errorf("%s: sync spotify rolling playlist error: %w", source.Name(), err))
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// syncSpotifyPlaylist creates or updates the playlist for a source. When program is
+// set the playlist only includes songs played during that program.
+func (a Application) syncSpotifyPlaylist(ctx context.Context, source sources.Source, program, date string, scope domain.PlaylistDateScope) error {
 	createRes, err := a.Playlists.Spotify.CreatePlaylist.Execute(ctx, spotify.CreatePlaylistCommand{
-		Date: date,
+		Date:        date,
+		DateScope:   scope,
+		SourceName:  source.Name(),
+		SourceType:  source.SourceType(),
+		ProgramName: program,
 	})
 	if err != nil {
 		return fmt.Errorf("create spotify playlist error: %w", err)
@@ -233,7 +813,82 @@ func (a Application) genStudioOneSpotifyPlaylistsForDay(ctx context.Context, dat
 		return fmt.Errorf("sync spotify playlist error: %w", err)
 	}
 
-	return err
+	return nil
+}
+
+// syncAppleMusicPlaylist creates or updates the Apple Music playlist for a source. When
+// program is set the playlist only includes songs played during that program.
+func (a Application) syncAppleMusicPlaylist(ctx context.Context, source sources.Source, program, date string, scope domain.PlaylistDateScope) error {
+	createRes, err := a.Playlists.AppleMusic.CreatePlaylist.Execute(ctx, applemusic.CreatePlaylistCommand{
+		Date:        date,
+		DateScope:   scope,
+		SourceName:  source.Name(),
+		SourceType:  source.SourceType(),
+		ProgramName: program,
+	})
+	if err != nil {
+		return fmt.Errorf("create apple music playlist error: %w", err)
+	}
+
+	_, err = a.Playlists.AppleMusic.SyncPlaylist.Execute(ctx, applemusic.SyncPlaylistCommand{
+		Playlist: createRes.Playlist,
+		Date:     date,
+	})
+	if err != nil {
+		return fmt.Errorf("sync apple music playlist error: %w", err)
+	}
+
+	return nil
+}
+
+// syncTidalPlaylist creates or updates the Tidal playlist for a source. When program is
+// set the playlist only includes songs played during that program.
+func (a Application) syncTidalPlaylist(ctx context.Context, source sources.Source, program, date string, scope domain.PlaylistDateScope) error {
+	createRes, err := a.Playlists.Tidal.CreatePlaylist.Execute(ctx, tidal.CreatePlaylistCommand{
+		Date:        date,
+		DateScope:   scope,
+		SourceName:  source.Name(),
+		SourceType:  source.SourceType(),
+		ProgramName: program,
+	})
+	if err != nil {
+		return fmt.Errorf("create tidal playlist error: %w", err)
+	}
+
+	_, err = a.Playlists.Tidal.SyncPlaylist.Execute(ctx, tidal.SyncPlaylistCommand{
+		Playlist: createRes.Playlist,
+		Date:     date,
+	})
+	if err != nil {
+		return fmt.Errorf("sync tidal playlist error: %w", err)
+	}
+
+	return nil
+}
+
+// syncSubsonicPlaylist creates or updates the Subsonic playlist for a source. When program
+// is set the playlist only includes songs played during that program.
+func (a Application) syncSubsonicPlaylist(ctx context.Context, source sources.Source, program, date string, scope domain.PlaylistDateScope) error {
+	createRes, err := a.Playlists.Subsonic.CreatePlaylist.Execute(ctx, subsonic.CreatePlaylistCommand{
+		Date:        date,
+		DateScope:   scope,
+		SourceName:  source.Name(),
+		SourceType:  source.SourceType(),
+		ProgramName: program,
+	})
+	if err != nil {
+		return fmt.Errorf("create subsonic playlist error: %w", err)
+	}
+
+	_, err = a.Playlists.Subsonic.SyncPlaylist.Execute(ctx, subsonic.SyncPlaylistCommand{
+		Playlist: createRes.Playlist,
+		Date:     date,
+	})
+	if err != nil {
+		return fmt.Errorf("sync subsonic playlist error: %w", err)
+	}
+
+	return nil
 }
 
 func (a Application) randomPlaylist(ctx context.Context, numTracks int) error {
@@ -248,3 +903,52 @@ func (a Application) randomPlaylist(ctx context.Context, numTracks int) error {
 
 	return nil
 }
+
+// listPrograms prints every program seen in the sources' raw feeds between two inclusive
+// dates and whether songs from the program are kept.
+func (a Application) listPrograms(ctx context.Context, from, to string) error {
+	date, err := time.Parse(time.DateOnly, from)
+	if err != nil {
+		return fmt.Errorf("invalid date - YYYY-MM-DD format expected: %w", err)
+	}
+
+	end, err := time.Parse(time.DateOnly, to)
+	if err != nil {
+		return fmt.Errorf("invalid to date - YYYY-MM-DD format expected: %w", err)
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	_, _ = fmt.Fprintln(w, "SOURCE\tPROGRAM\tSONGS\tKEPT")
+
+	for _, source := range a.Sources.All() {
+		var programs []sources.Program
+		lookup := make(map[string]int)
+
+		for day := date; !day.After(end); day = day.AddDate(0, 0, 1) {
+			dayPrograms, err := source.ListPrograms(ctx, day.Format(time.DateOnly))
+			if err != nil {
+				return fmt.Errorf("%s list programs error: %w", source.Name(), err)
+			}
+
+			for _, p := range dayPrograms {
+				idx, ok := lookup[p.Name]
+				if !ok {
+					idx = len(programs)
+					lookup[p.Name] = idx
+					programs = append(programs, sources.Program{Name: p.Name, Kept: p.Kept})
+				}
+				programs[idx].NumSongs += p.NumSongs
+			}
+		}
+
+		slices.SortFunc(programs, func(a, b sources.Program) int {
+			return strings.Compare(a.Name, b.Name)
+		})
+
+		for _, p := range programs {
+			_, _ = fmt.Fprintf(w, "%s\t%s\t%d\t%t\n", source.Name(), p.Name, p.NumSongs, p.Kept)
+		}
+	}
+
+	return w.Flush()
+}
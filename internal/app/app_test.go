@@ -0,0 +1,98 @@
+package app
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/jbenzshawel/playlist-generator/internal/app/config"
+)
+
+func TestStorageDSN(t *testing.T) {
+	const postgresDSN = "postgres://localhost:5432/playlists"
+
+	testCases := []struct {
+		name     string
+		cfg      config.Storage
+		flags    config.Storage
+		expected string
+	}{
+		{
+			name:     "default path",
+			expected: "file:db/app.db?_busy_timeout=5000&_pragma=journal_mode(WAL)",
+		},
+		{
+			name:     "config path",
+			cfg:      config.Storage{Path: "/var/lib/playlists/app.db"},
+			expected: "file:/var/lib/playlists/app.db?_busy_timeout=5000&_pragma=journal_mode(WAL)",
+		},
+		{
+			name:     "config dsn",
+			cfg:      config.Storage{Path: "/var/lib/playlists/app.db", DSN: postgresDSN},
+			expected: postgresDSN,
+		},
+		{
+			name:     "path flag",
+			cfg:      config.Storage{DSN: postgresDSN},
+			flags:    config.Storage{Path: ":memory:"},
+			expected: "file::memory:?mode=memory&cache=shared",
+		},
+		{
+			name:     "dsn flag",
+			cfg:      config.Storage{Path: "/var/lib/playlists/app.db"},
+			flags:    config.Storage{Path: ":memory:", DSN: postgresDSN},
+			expected: postgresDSN,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, storageDSN(tc.cfg, tc.flags))
+		})
+	}
+}
+
+func TestServerAddress(t *testing.T) {
+	assert.Equal(t, "127.0.0.1:3000", serverAddress(config.Config{}))
+	assert.Equal(t, "127.0.0.1:8080", serverAddress(config.Config{Clients: config.Clients{SpotifyClient: config.OAuthClient{RedirectPort: 8080}}}))
+	assert.Equal(t, "0.0.0.0:3000", serverAddress(config.Config{Server: config.Server{Address: "0.0.0.0:3000"}}))
+}
+
+func TestValidateRedirectPorts(t *testing.T) {
+	tidal := func(port int) config.Tidal {
+		return config.Tidal{OAuthClient: config.OAuthClient{Client: config.Client{BaseURL: "https://openapi.tidal.com"}, RedirectPort: port}}
+	}
+
+	testCases := []struct {
+		name        string
+		cfg         config.Clients
+		expectedErr string
+	}{
+		{
+			name: "tidal not configured",
+			cfg:  config.Clients{SpotifyClient: config.OAuthClient{RedirectPort: 8080}},
+		},
+		{
+			name: "default ports",
+			cfg:  config.Clients{Tidal: tidal(0)},
+		},
+		{
+			name: "matching ports",
+			cfg:  config.Clients{SpotifyClient: config.OAuthClient{RedirectPort: 8080}, Tidal: tidal(8080)},
+		},
+		{
+			name:        "different ports",
+			cfg:         config.Clients{Tidal: tidal(8080)},
+			expectedErr: "tidal redirect port 8080 doesn't match the spotify redirect port 3000 - both login callbacks are served on one port",
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := validateRedirectPorts(tc.cfg)
+			if tc.expectedErr == "" {
+				assert.NoError(t, err)
+				return
+			}
+			assert.EqualError(t, err, tc.expectedErr)
+		})
+	}
+}
@@ -0,0 +1,78 @@
+package app
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/jbenzshawel/playlist-generator/internal/app/config"
+	"github.com/jbenzshawel/playlist-generator/internal/infrastructure/storage"
+)
+
+// oauthAuthenticator runs the OAuth authentication code flow, either through the
+// callback endpoint or by exchanging a pasted redirect URL.
+type oauthAuthenticator interface {
+	AuthCodeURL() (string, error)
+	GetAuthCodeCallbackHandler(ctx context.Context, chOAuthClient chan *http.Client) http.HandlerFunc
+	StoredClient(ctx context.Context) (*http.Client, error)
+	Exchange(ctx context.Context, redirect string) (*http.Client, error)
+}
+
+// Login logs in to a streaming provider without the callback endpoint, for hosts without
+// a browser. The provider is spotify, the default when empty, or tidal. The login URL is
+// written to out and the URL the browser was redirected to, or the code from it, is read
+// from in. The token is stored for later runs in the database selected by the storage
+// config and storageFlags.
+func Login(ctx context.Context, storageFlags config.Storage, provider string, in io.Reader, out io.Writer) error {
+	cfg, err := config.Load()
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+
+	store, err := storage.Initialize(ctx, storageDSN(cfg.Storage, storageFlags))
+	if err != nil {
+		return fmt.Errorf("failed to initialize database: %w", err)
+	}
+	defer store.Close()
+
+	switch provider {
+	case "", "spotify":
+		auth := newSpotifyAuthenticator(cfg.SpotifyClient, storage.NewTokenStore(store, "spotify"))
+		return headlessLogin(ctx, "Spotify", auth, in, out)
+	case "tidal":
+		auth := newTidalAuthenticator(cfg.Tidal, storage.NewTokenStore(store, "tidal"))
+		return headlessLogin(ctx, "Tidal", auth, in, out)
+	default:
+		return fmt.Errorf("unknown provider %q - spotify or tidal expected", provider)
+	}
+}
+
+func headlessLogin(ctx context.Context, name string, auth oauthAuthenticator, in io.Reader, out io.Writer) error {
+	loginURL, err := auth.AuthCodeURL()
+	if err != nil {
+		return fmt.Errorf("auth code url failed: %w", err)
+	}
+
+	fmt.Fprintf(out, "Open the following URL in a browser to complete %s login: %s\n", name, loginURL)
+	fmt.Fprintln(out, "Paste the URL you were redirected to, or the code from it:")
+
+	scanner := bufio.NewScanner(in)
+	if !scanner.Scan() {
+		if err := scanner.Err(); err != nil {
+			return fmt.Errorf("failed to read redirect URL: %w", err)
+		}
+		return errors.New("no redirect URL entered")
+	}
+
+	_, err = auth.Exchange(ctx, scanner.Text())
+	if err != nil {
+		return fmt.Errorf("%s login failed: %w", name, err)
+	}
+
+	fmt.Fprintf(out, "%s login complete\n", name)
+
+	return nil
+}
@@ -0,0 +1,184 @@
+package app
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/oauth2"
+
+	"github.com/jbenzshawel/playlist-generator/internal/app/config"
+)
+
+type memoryTokenStore struct {
+	token *oauth2.Token
+}
+
+func (m *memoryTokenStore) Token(context.Context) (*oauth2.Token, error) {
+	return m.token, nil
+}
+
+func (m *memoryTokenStore) SaveToken(_ context.Context, token *oauth2.Token) error {
+	m.token = token
+	return nil
+}
+
+func TestHeadlessLogin(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.NoError(t, r.ParseForm())
+
+		w.Header().Set("Content-Type", "application/json")
+		if r.PostForm.Get("grant_type") != "authorization_code" || r.PostForm.Get("code") != "code123" {
+			w.WriteHeader(http.StatusBadRequest)
+			_ = json.NewEncoder(w).Encode(map[string]string{"error": "invalid_grant"})
+			return
+		}
+		assert.Equal(t, "http://box.local:8080/callback", r.PostForm.Get("redirect_uri"))
+
+		_ = json.NewEncoder(w).Encode(map[string]any{
+			"access_token":  "access",
+			"token_type":    "Bearer",
+			"refresh_token": "refresh",
+			"expires_in":    3600,
+		})
+	}))
+	t.Cleanup(ts.Close)
+
+	clientConfig := config.OAuthClient{
+		ClientID:     "client",
+		ClientSecret: "secret",
+		AuthURL:      ts.URL + "/authorize",
+		TokenURL:     ts.URL,
+		RedirectHost: "box.local",
+		RedirectPort: 8080,
+	}
+
+	t.Run("pasted redirect URL", func(t *testing.T) {
+		store := &memoryTokenStore{}
+		auth := newSpotifyAuthenticator(clientConfig, store)
+
+		// the state is only known after the login URL is printed, so the redirect URL
+		// is built from the output
+		in := &stateRedirectReader{out: &bytes.Buffer{}}
+		err := headlessLogin(t.Context(), "Spotify", auth, in, in.out)
+		require.NoError(t, err)
+
+		assert.Contains(t, in.out.String(), ts.URL+"/authorize?")
+		assert.Contains(t, in.out.String(), "Spotify login complete")
+		require.NotNil(t, store.token)
+		assert.Equal(t, "access", store.token.AccessToken)
+		assert.Equal(t, "refresh", store.token.RefreshToken)
+	})
+
+	t.Run("pasted code", func(t *testing.T) {
+		store := &memoryTokenStore{}
+		auth := newSpotifyAuthenticator(clientConfig, store)
+
+		err := headlessLogin(t.Context(), "Spotify", auth, strings.NewReader("code123\n"), &bytes.Buffer{})
+		require.NoError(t, err)
+		require.NotNil(t, store.token)
+		assert.Equal(t, "access", store.token.AccessToken)
+	})
+
+	t.Run("rejected code", func(t *testing.T) {
+		store := &memoryTokenStore{}
+		auth := newSpotifyAuthenticator(clientConfig, store)
+
+		err := headlessLogin(t.Context(), "Spotify", auth, strings.NewReader("expired\n"), &bytes.Buffer{})
+		assert.Error(t, err)
+		assert.Nil(t, store.token)
+	})
+
+	t.Run("nothing entered", func(t *testing.T) {
+		store := &memoryTokenStore{}
+		auth := newSpotifyAuthenticator(clientConfig, store)
+
+		err := headlessLogin(t.Context(), "Spotify", auth, strings.NewReader(""), &bytes.Buffer{})
+		assert.Error(t, err)
+		assert.Nil(t, store.token)
+	})
+}
+
+func TestLoginClient_StoredTokenError(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	t.Cleanup(ts.Close)
+
+	store := &memoryTokenStore{token: &oauth2.Token{
+		AccessToken:  "access",
+		TokenType:    "Bearer",
+		RefreshToken: "refresh",
+		Expiry:       time.Now().Add(-time.Hour),
+	}}
+	auth := newSpotifyAuthenticator(config.OAuthClient{
+		ClientID: "client",
+		AuthURL:  ts.URL + "/authorize",
+		TokenURL: ts.URL,
+	}, store)
+
+	srv := newServer("127.0.0.1:0")
+	t.Cleanup(srv.Shutdown)
+
+	// an unreachable token endpoint is returned instead of waiting for a login
+	client, err := loginClient(t.Context(), "spotify", auth, srv, spotifyCallbackPath)
+	assert.Error(t, err)
+	assert.Nil(t, client)
+	assert.Nil(t, srv.httpSrv)
+}
+
+func TestLoginClient_PortInUse(t *testing.T) {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		_ = ln.Close()
+	})
+
+	auth := newSpotifyAuthenticator(config.OAuthClient{ClientID: "client"}, &memoryTokenStore{})
+
+	srv := newServer(ln.Addr().String())
+	t.Cleanup(srv.Shutdown)
+
+	client, err := loginClient(t.Context(), "spotify", auth, srv, spotifyCallbackPath)
+	assert.ErrorContains(t, err, "spotify login callback server failed to start")
+	assert.Nil(t, client)
+}
+
+// stateRedirectReader reads the redirect URL for the login URL written to out.
+type stateRedirectReader struct {
+	out  *bytes.Buffer
+	read bool
+}
+
+func (r *stateRedirectReader) Read(p []byte) (int, error) {
+	if r.read {
+		return 0, io.EOF
+	}
+	r.read = true
+
+	loginURL := strings.Fields(strings.SplitN(r.out.String(), "login: ", 2)[1])[0]
+	parsed, err := url.Parse(loginURL)
+	if err != nil {
+		return 0, err
+	}
+
+	redirect := "http://box.local:8080/callback?code=code123&state=" + parsed.Query().Get("state") + "\n"
+	return copy(p, redirect), nil
+}
+
+func TestRedirectURL(t *testing.T) {
+	assert.Equal(t, "http://127.0.0.1:3000/callback", redirectURL(config.OAuthClient{}))
+	assert.Equal(t, "http://box.local:8080/callback", redirectURL(config.OAuthClient{RedirectHost: "box.local", RedirectPort: 8080}))
+	assert.Equal(t, "http://[::1]:3000/callback", redirectURL(config.OAuthClient{RedirectHost: "::1"}))
+	assert.Equal(t, "http://box.local:8080/tidal/callback", callbackURL(config.OAuthClient{RedirectHost: "box.local", RedirectPort: 8080}, tidalCallbackPath))
+}
@@ -0,0 +1,15 @@
+package exports
+
+import (
+	"github.com/jbenzshawel/playlist-generator/internal/domain"
+)
+
+type Commands struct {
+	ExportSongs ExportSongsCommandHandler
+}
+
+func NewCommands(repository domain.Repository) Commands {
+	return Commands{
+		ExportSongs: NewExportSongsCommand(repository),
+	}
+}
@@ -0,0 +1,90 @@
+package exports
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"time"
+
+	"github.com/jbenzshawel/playlist-generator/internal/common/decorator"
+	"github.com/jbenzshawel/playlist-generator/internal/domain"
+)
+
+type ExportSongsCommand struct {
+	// Date is any date in YYYY-MM-DD within the scope period to export
+	Date       string
+	DateScope  domain.PlaylistDateScope
+	SourceName string
+	SourceType domain.SourceType
+	Format     Format
+	// Writer receives the exported playlist
+	Writer io.Writer
+}
+
+type ExportSongsCommandResult struct {
+	// Title is the name of the exported playlist, which matches the name of the source's
+	// playlists on the streaming providers
+	Title    string
+	NumSongs int
+}
+
+type ExportSongsCommandHandler decorator.CommandWithResultHandler[ExportSongsCommand, ExportSongsCommandResult]
+
+func NewExportSongsCommand(repository domain.Repository) ExportSongsCommandHandler {
+	return decorator.ApplyDBTransactionDecorator(
+		&exportSongsCommand{
+			songSourceRepository: repository.SongSource(),
+		},
+		repository,
+	)
+}
+
+type exportSongsCommand struct {
+	songSourceRepository domain.SongSourceRepository
+}
+
+// Execute writes every song played at a source during the scope period containing the
+// command's date, in the order the songs were played.
+func (c *exportSongsCommand) Execute(ctx context.Context, cmd ExportSongsCommand) (ExportSongsCommandResult, error) {
+	if cmd.DateScope == domain.RollingPlaylistDateScope {
+		return ExportSongsCommandResult{}, fmt.Errorf("%s exports aren't supported", cmd.DateScope)
+	}
+
+	write, ok := playlistWriters[cmd.Format]
+	if !ok {
+		return ExportSongsCommandResult{}, fmt.Errorf("unsupported export format %s", cmd.Format)
+	}
+
+	if cmd.Writer == nil {
+		return ExportSongsCommandResult{}, errors.New("export writer is required")
+	}
+
+	date, err := time.Parse(time.DateOnly, cmd.Date)
+	if err != nil {
+		return ExportSongsCommandResult{}, fmt.Errorf("invalid export date: %w", err)
+	}
+
+	start, end := cmd.DateScope.Period(date)
+
+	plays, err := c.songSourceRepository.GetSongsPlayedInRange(ctx, cmd.SourceType, start.Format(time.DateOnly), end.Format(time.DateOnly))
+	if err != nil {
+		return ExportSongsCommandResult{}, fmt.Errorf("get songs played error: %w", err)
+	}
+
+	title := fmt.Sprintf("%s %s", cmd.SourceName, cmd.DateScope.Format(date))
+
+	err = write(cmd.Writer, title, plays)
+	if err != nil {
+		return ExportSongsCommandResult{}, fmt.Errorf("write %s error: %w", cmd.Format, err)
+	}
+
+	slog.Info("songs exported",
+		slog.String("title", title),
+		slog.String("format", cmd.Format.String()),
+		slog.Int("numSongs", len(plays)),
+	)
+
+	return ExportSongsCommandResult{Title: title, NumSongs: len(plays)}, nil
+}
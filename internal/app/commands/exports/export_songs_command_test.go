@@ -0,0 +1,50 @@
+package exports
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/jbenzshawel/playlist-generator/internal/domain"
+	"github.com/jbenzshawel/playlist-generator/internal/infrastructure/storage"
+)
+
+func TestExportSongsCommand(t *testing.T) {
+	repository := storage.NewRepository(storage.InitTestStorage(t))
+
+	song1, err := domain.NewSong("CAKE", "Never There", "Prolonging The Magic", "")
+	require.NoError(t, err)
+	song2, err := domain.NewSong("Wilco", "Jesus, Etc.", "Yankee Hotel Foxtrot", "")
+	require.NoError(t, err)
+
+	played1 := time.Date(2025, 10, 1, 14, 3, 27, 0, time.UTC)
+	played2 := time.Date(2025, 10, 1, 9, 30, 5, 0, time.UTC)
+
+	require.NoError(t, repository.Begin(t.Context()))
+	require.NoError(t, repository.Song().BulkInsert(t.Context(), []domain.Song{song1, song2}))
+	require.NoError(t, repository.SongSource().BulkInsert(t.Context(), []domain.SongSource{
+		domain.NewSongSource("sourceID1", song1.SongHash(), domain.StudioOneSourceType, "Studio One Tracks", "2025-10-01", played1),
+		domain.NewSongSource("sourceID2", song2.SongHash(), domain.StudioOneSourceType, "Studio One Tracks", "2025-10-01", played2),
+	}))
+	require.NoError(t, repository.Commit())
+
+	var out bytes.Buffer
+	result, err := NewExportSongsCommand(repository).Execute(t.Context(), ExportSongsCommand{
+		Date:       "2025-10-01",
+		DateScope:  domain.DayPlaylistDateScope,
+		SourceName: "Studio One",
+		SourceType: domain.StudioOneSourceType,
+		Format:     CSVFormat,
+		Writer:     &out,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, 2, result.NumSongs)
+
+	// songs are exported in the order they were played, at the time they were played
+	assert.Equal(t, "artist,track,album,program,played\n"+
+		"Wilco,\"Jesus, Etc.\",Yankee Hotel Foxtrot,Studio One Tracks,"+played2.Local().Format(time.RFC3339)+"\n"+
+		"CAKE,Never There,Prolonging The Magic,Studio One Tracks,"+played1.Local().Format(time.RFC3339)+"\n", out.String())
+}
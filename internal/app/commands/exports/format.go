@@ -0,0 +1,65 @@
+package exports
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Format is the file format songs are exported in.
+type Format int
+
+const (
+	UnknownFormat Format = 0
+	// M3U8Format is an extended M3U playlist in UTF-8
+	M3U8Format Format = 1
+	// XSPFFormat is an XML Shareable Playlist Format playlist
+	XSPFFormat Format = 2
+	CSVFormat  Format = 3
+	JSONFormat Format = 4
+)
+
+var Formats = map[Format]string{
+	UnknownFormat: "Unknown",
+	M3U8Format:    "M3U8",
+	XSPFFormat:    "XSPF",
+	CSVFormat:     "CSV",
+	JSONFormat:    "JSON",
+}
+
+func (f Format) String() string {
+	s, ok := Formats[f]
+	if !ok {
+		return "Unknown"
+	}
+	return s
+}
+
+func (f Format) IsValid() bool {
+	_, ok := Formats[f]
+	return ok
+}
+
+// Extension returns the file name extension, without a leading dot, for the format.
+func (f Format) Extension() string {
+	return strings.ToLower(f.String())
+}
+
+func AllFormats() []Format {
+	return []Format{
+		M3U8Format,
+		XSPFFormat,
+		CSVFormat,
+		JSONFormat,
+	}
+}
+
+// ParseFormat returns the format matching a case-insensitive format name such as
+// "m3u8" or "json".
+func ParseFormat(s string) (Format, error) {
+	for _, format := range AllFormats() {
+		if strings.EqualFold(format.String(), s) {
+			return format, nil
+		}
+	}
+	return UnknownFormat, fmt.Errorf("unknown export format %q", s)
+}
@@ -0,0 +1,166 @@
+package exports
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/jbenzshawel/playlist-generator/internal/domain"
+)
+
+// playlistWriter writes a playlist with a title holding songs in the order they were played.
+type playlistWriter func(w io.Writer, title string, plays []domain.SongPlay) error
+
+var playlistWriters = map[Format]playlistWriter{
+	M3U8Format: writeM3U8,
+	XSPFFormat: writeXSPF,
+	CSVFormat:  writeCSV,
+	JSONFormat: writeJSON,
+}
+
+// writeM3U8 writes an extended M3U playlist. Songs aren't local files, so each entry's
+// location is the artist and track name, which tools that import playlists by name match
+// on. The album, program, and play time are written as directives and comments.
+func writeM3U8(w io.Writer, title string, plays []domain.SongPlay) error {
+	var b strings.Builder
+	b.WriteString("#EXTM3U\n")
+	fmt.Fprintf(&b, "#PLAYLIST:%s\n", m3uText(title))
+
+	for _, p := range plays {
+		song := p.Song()
+		name := m3uText(song.Artist() + " - " + song.Track())
+
+		fmt.Fprintf(&b, "#EXTINF:-1,%s\n", name)
+		if song.Album() != "" {
+			fmt.Fprintf(&b, "#EXTALB:%s\n", m3uText(song.Album()))
+		}
+		if p.Source().ProgramName() != "" {
+			fmt.Fprintf(&b, "# Program: %s\n", m3uText(p.Source().ProgramName()))
+		}
+		fmt.Fprintf(&b, "# Played: %s\n", played(p))
+		fmt.Fprintf(&b, "%s\n", name)
+	}
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// m3uText replaces line breaks, which end an M3U line, with spaces.
+func m3uText(s string) string {
+	return strings.Join(strings.Fields(s), " ")
+}
+
+type xspfPlaylist struct {
+	XMLName   xml.Name    `xml:"http://xspf.org/ns/0/ playlist"`
+	Version   string      `xml:"version,attr"`
+	Title     string      `xml:"title"`
+	TrackList []xspfTrack `xml:"trackList>track"`
+}
+
+type xspfTrack struct {
+	Creator    string `xml:"creator"`
+	Title      string `xml:"title"`
+	Album      string `xml:"album,omitempty"`
+	Annotation string `xml:"annotation"`
+}
+
+// writeXSPF writes an XSPF playlist. The program and play time of each song are written
+// to the track's annotation.
+func writeXSPF(w io.Writer, title string, plays []domain.SongPlay) error {
+	playlist := xspfPlaylist{
+		Version: "1",
+		Title:   title,
+	}
+
+	for _, p := range plays {
+		annotation := "Played " + played(p)
+		if p.Source().ProgramName() != "" {
+			annotation += " during " + p.Source().ProgramName()
+		}
+
+		playlist.TrackList = append(playlist.TrackList, xspfTrack{
+			Creator:    p.Song().Artist(),
+			Title:      p.Song().Track(),
+			Album:      p.Song().Album(),
+			Annotation: annotation,
+		})
+	}
+
+	_, err := io.WriteString(w, xml.Header)
+	if err != nil {
+		return err
+	}
+
+	enc := xml.NewEncoder(w)
+	enc.Indent("", "  ")
+	err = enc.Encode(playlist)
+	if err != nil {
+		return err
+	}
+
+	_, err = io.WriteString(w, "\n")
+	return err
+}
+
+// writeCSV writes a header row followed by a row for every song. The title isn't written.
+func writeCSV(w io.Writer, _ string, plays []domain.SongPlay) error {
+	cw := csv.NewWriter(w)
+
+	err := cw.Write([]string{"artist", "track", "album", "program", "played"})
+	if err != nil {
+		return err
+	}
+
+	for _, p := range plays {
+		err = cw.Write([]string{p.Song().Artist(), p.Song().Track(), p.Song().Album(), p.Source().ProgramName(), played(p)})
+		if err != nil {
+			return err
+		}
+	}
+
+	cw.Flush()
+	return cw.Error()
+}
+
+type jsonPlaylist struct {
+	Title string     `json:"title"`
+	Songs []jsonSong `json:"songs"`
+}
+
+type jsonSong struct {
+	Artist  string `json:"artist"`
+	Track   string `json:"track"`
+	Album   string `json:"album"`
+	Program string `json:"program"`
+	Played  string `json:"played"`
+}
+
+func writeJSON(w io.Writer, title string, plays []domain.SongPlay) error {
+	playlist := jsonPlaylist{
+		Title: title,
+		Songs: make([]jsonSong, 0, len(plays)),
+	}
+
+	for _, p := range plays {
+		playlist.Songs = append(playlist.Songs, jsonSong{
+			Artist:  p.Song().Artist(),
+			Track:   p.Song().Track(),
+			Album:   p.Song().Album(),
+			Program: p.Source().ProgramName(),
+			Played:  played(p),
+		})
+	}
+
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(playlist)
+}
+
+// played returns when the song was played in RFC 3339.
+func played(p domain.SongPlay) string {
+	return p.Source().EndTime().Format(time.RFC3339)
+}
@@ -0,0 +1,115 @@
+package exports
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/jbenzshawel/playlist-generator/internal/domain"
+)
+
+const testTitle = "Studio One 2025-10"
+
+func TestWriteM3U8(t *testing.T) {
+	var out bytes.Buffer
+	require.NoError(t, writeM3U8(&out, testTitle, testPlays()))
+
+	assert.Equal(t, "#EXTM3U\n"+
+		"#PLAYLIST:Studio One 2025-10\n"+
+		"#EXTINF:-1,CAKE - Never There\n"+
+		"#EXTALB:Prolonging The Magic\n"+
+		"# Program: Studio One Tracks\n"+
+		"# Played: 2025-10-01T14:03:00Z\n"+
+		"CAKE - Never There\n"+
+		"#EXTINF:-1,Wilco - Jesus, Etc.\n"+
+		"# Played: 2025-10-02T09:30:00Z\n"+
+		"Wilco - Jesus, Etc.\n", out.String())
+}
+
+func TestWriteM3U8_LineBreaks(t *testing.T) {
+	played := time.Date(2025, 10, 1, 14, 3, 0, 0, time.UTC)
+	plays := []domain.SongPlay{
+		domain.NewSongPlay(
+			domain.NewSongFromDB(uuid.New(), "CAKE", "Never\nThere", "", "", "songHash1", played),
+			domain.NewSongSourceFromDB(uuid.New(), "sourceID1", "songHash1", domain.StudioOneSourceType, "", "2025-10-01", played, played),
+		),
+	}
+
+	var out bytes.Buffer
+	require.NoError(t, writeM3U8(&out, testTitle, plays))
+
+	assert.Contains(t, out.String(), "#EXTINF:-1,CAKE - Never There\n")
+}
+
+func TestWriteXSPF(t *testing.T) {
+	var out bytes.Buffer
+	require.NoError(t, writeXSPF(&out, testTitle, testPlays()))
+
+	assert.Equal(t, `<?xml version="1.0" encoding="UTF-8"?>
+<playlist xmlns="http://xspf.org/ns/0/" version="1">
+  <title>Studio One 2025-10</title>
+  <trackList>
+    <track>
+      <creator>CAKE</creator>
+      <title>Never There</title>
+      <album>Prolonging The Magic</album>
+      <annotation>Played 2025-10-01T14:03:00Z during Studio One Tracks</annotation>
+    </track>
+    <track>
+      <creator>Wilco</creator>
+      <title>Jesus, Etc.</title>
+      <annotation>Played 2025-10-02T09:30:00Z</annotation>
+    </track>
+  </trackList>
+</playlist>
+`, out.String())
+}
+
+func TestWriteCSV(t *testing.T) {
+	var out bytes.Buffer
+	require.NoError(t, writeCSV(&out, testTitle, testPlays()))
+
+	assert.Equal(t, "artist,track,album,program,played\n"+
+		"CAKE,Never There,Prolonging The Magic,Studio One Tracks,2025-10-01T14:03:00Z\n"+
+		"Wilco,\"Jesus, Etc.\",,,2025-10-02T09:30:00Z\n", out.String())
+}
+
+func TestWriteJSON(t *testing.T) {
+	var out bytes.Buffer
+	require.NoError(t, writeJSON(&out, testTitle, testPlays()))
+
+	assert.JSONEq(t, `{
+		"title": "Studio One 2025-10",
+		"songs": [
+			{"artist": "CAKE", "track": "Never There", "album": "Prolonging The Magic", "program": "Studio One Tracks", "played": "2025-10-01T14:03:00Z"},
+			{"artist": "Wilco", "track": "Jesus, Etc.", "album": "", "program": "", "played": "2025-10-02T09:30:00Z"}
+		]
+	}`, out.String())
+}
+
+func TestWriteJSON_NoSongs(t *testing.T) {
+	var out bytes.Buffer
+	require.NoError(t, writeJSON(&out, testTitle, nil))
+
+	assert.JSONEq(t, `{"title": "Studio One 2025-10", "songs": []}`, out.String())
+}
+
+func testPlays() []domain.SongPlay {
+	played1 := time.Date(2025, 10, 1, 14, 3, 0, 0, time.UTC)
+	played2 := time.Date(2025, 10, 2, 9, 30, 0, 0, time.UTC)
+
+	return []domain.SongPlay{
+		domain.NewSongPlay(
+			domain.NewSongFromDB(uuid.New(), "CAKE", "Never There", "Prolonging The Magic", "", "songHash1", played1),
+			domain.NewSongSourceFromDB(uuid.New(), "sourceID1", "songHash1", domain.StudioOneSourceType, "Studio One Tracks", "2025-10-01", played1, played1),
+		),
+		domain.NewSongPlay(
+			domain.NewSongFromDB(uuid.New(), "Wilco", "Jesus, Etc.", "", "", "songHash2", played2),
+			domain.NewSongSourceFromDB(uuid.New(), "sourceID2", "songHash2", domain.StudioOneSourceType, "", "2025-10-02", played2, played2),
+		),
+	}
+}
@@ -0,0 +1,52 @@
+package mutators
+
+import (
+	"context"
+
+	"github.com/jbenzshawel/playlist-generator/internal/app/commands/playlists/applemusic/models"
+	"github.com/jbenzshawel/playlist-generator/internal/domain"
+)
+
+type PlaylistCreator interface {
+	CreateLibraryPlaylist(ctx context.Context, request models.CreateLibraryPlaylistRequest) (models.LibraryPlaylist, error)
+}
+
+type CreatePlaylistMutator interface {
+	// CreatePlaylist creates a playlist in the user's library for a source and date. The
+	// date must be formatted for the date scope, see domain.PlaylistDateScope.Format.
+	CreatePlaylist(ctx context.Context, name, date string, dateScope domain.PlaylistDateScope, sourceType domain.SourceType, programName string) (domain.Playlist, error)
+}
+
+func NewCreatePlaylistMutator(creator PlaylistCreator) CreatePlaylistMutator {
+	return &createPlaylistMutator{
+		creator: creator,
+	}
+}
+
+type createPlaylistMutator struct {
+	creator PlaylistCreator
+}
+
+func (c *createPlaylistMutator) CreatePlaylist(ctx context.Context, name, date string, dateScope domain.PlaylistDateScope, sourceType domain.SourceType, programName string) (domain.Playlist, error) {
+	libraryPlaylist, err := c.creator.CreateLibraryPlaylist(ctx, models.CreateLibraryPlaylistRequest{
+		Attributes: models.CreateLibraryPlaylistAttributes{
+			Name: name,
+		},
+	})
+	if err != nil {
+		return domain.Playlist{}, err
+	}
+
+	p := domain.NewPlaylist(
+		libraryPlaylist.ID,
+		libraryPlaylist.Endpoint,
+		libraryPlaylist.Attributes.Name,
+		date,
+		dateScope,
+		domain.AppleMusicPlaylistType,
+		sourceType,
+		programName,
+	)
+
+	return p, nil
+}
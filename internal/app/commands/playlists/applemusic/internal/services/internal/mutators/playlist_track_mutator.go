@@ -0,0 +1,53 @@
+package mutators
+
+import (
+	"context"
+
+	"github.com/jbenzshawel/playlist-generator/internal/app/commands/playlists/applemusic/models"
+)
+
+// batchSize is the number of songs added to a library playlist in one request
+const batchSize = 100
+
+type TrackAdder interface {
+	AddTracksToLibraryPlaylist(ctx context.Context, playlistID string, request models.AddTracksToLibraryPlaylistRequest) error
+}
+
+type PlaylistTrackMutator interface {
+	// AddTracks adds catalog songs to a library playlist. The Apple Music API doesn't
+	// support removing songs from a playlist.
+	AddTracks(ctx context.Context, playlistID string, trackIDs []string) error
+}
+
+type playlistTrackMutator struct {
+	adder TrackAdder
+}
+
+func NewPlaylistTrackMutator(adder TrackAdder) PlaylistTrackMutator {
+	return &playlistTrackMutator{
+		adder: adder,
+	}
+}
+
+func (p *playlistTrackMutator) AddTracks(ctx context.Context, playlistID string, trackIDs []string) error {
+	for offset := 0; offset < len(trackIDs); offset += batchSize {
+		batch := trackIDs[offset:min(offset+batchSize, len(trackIDs))]
+
+		tracks := make([]models.TrackReference, len(batch))
+		for idx, trackID := range batch {
+			tracks[idx] = models.TrackReference{
+				ID:   trackID,
+				Type: models.SongResourceType,
+			}
+		}
+
+		err := p.adder.AddTracksToLibraryPlaylist(ctx, playlistID, models.AddTracksToLibraryPlaylistRequest{
+			Data: tracks,
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
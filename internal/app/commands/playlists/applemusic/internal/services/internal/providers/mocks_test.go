@@ -0,0 +1,277 @@
+// Code generated by mockery; DO NOT EDIT.
+// github.com/vektra/mockery
+// template: testify
+
+package providers
+
+import (
+	"context"
+
+	mock "github.com/stretchr/testify/mock"
+
+	"github.com/jbenzshawel/playlist-generator/internal/app/commands/playlists/applemusic/models"
+)
+
+// NewMockTrackGetter creates a new instance of MockTrackGetter. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockTrackGetter(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockTrackGetter {
+	mock := &MockTrackGetter{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
+
+// MockTrackGetter is an autogenerated mock type for the TrackGetter type
+type MockTrackGetter struct {
+	mock.Mock
+}
+
+type MockTrackGetter_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockTrackGetter) EXPECT() *MockTrackGetter_Expecter {
+	return &MockTrackGetter_Expecter{mock: &_m.Mock}
+}
+
+// GetLibraryPlaylistTracks provides a mock function for the type MockTrackGetter
+func (_mock *MockTrackGetter) GetLibraryPlaylistTracks(ctx context.Context, playlistID string, limit int, offset int) (models.LibrarySongPage, error) {
+	ret := _mock.Called(ctx, playlistID, limit, offset)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetLibraryPlaylistTracks")
+	}
+
+	var r0 models.LibrarySongPage
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, int, int) (models.LibrarySongPage, error)); ok {
+		return returnFunc(ctx, playlistID, limit, offset)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, int, int) models.LibrarySongPage); ok {
+		r0 = returnFunc(ctx, playlistID, limit, offset)
+	} else {
+		r0 = ret.Get(0).(models.LibrarySongPage)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, int, int) error); ok {
+		r1 = returnFunc(ctx, playlistID, limit, offset)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockTrackGetter_GetLibraryPlaylistTracks_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetLibraryPlaylistTracks'
+type MockTrackGetter_GetLibraryPlaylistTracks_Call struct {
+	*mock.Call
+}
+
+// GetLibraryPlaylistTracks is a helper method to define mock.On call
+//   - ctx context.Context
+//   - playlistID string
+//   - limit int
+//   - offset int
+func (_e *MockTrackGetter_Expecter) GetLibraryPlaylistTracks(ctx interface{}, playlistID interface{}, limit interface{}, offset interface{}) *MockTrackGetter_GetLibraryPlaylistTracks_Call {
+	return &MockTrackGetter_GetLibraryPlaylistTracks_Call{Call: _e.mock.On("GetLibraryPlaylistTracks", ctx, playlistID, limit, offset)}
+}
+
+func (_c *MockTrackGetter_GetLibraryPlaylistTracks_Call) Run(run func(ctx context.Context, playlistID string, limit int, offset int)) *MockTrackGetter_GetLibraryPlaylistTracks_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 int
+		if args[2] != nil {
+			arg2 = args[2].(int)
+		}
+		var arg3 int
+		if args[3] != nil {
+			arg3 = args[3].(int)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+		)
+	})
+	return _c
+}
+
+func (_c *MockTrackGetter_GetLibraryPlaylistTracks_Call) Return(librarySongPage models.LibrarySongPage, err error) *MockTrackGetter_GetLibraryPlaylistTracks_Call {
+	_c.Call.Return(librarySongPage, err)
+	return _c
+}
+
+func (_c *MockTrackGetter_GetLibraryPlaylistTracks_Call) RunAndReturn(run func(ctx context.Context, playlistID string, limit int, offset int) (models.LibrarySongPage, error)) *MockTrackGetter_GetLibraryPlaylistTracks_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockTrackSearcher creates a new instance of MockTrackSearcher. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockTrackSearcher(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockTrackSearcher {
+	mock := &MockTrackSearcher{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
+
+// MockTrackSearcher is an autogenerated mock type for the TrackSearcher type
+type MockTrackSearcher struct {
+	mock.Mock
+}
+
+type MockTrackSearcher_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockTrackSearcher) EXPECT() *MockTrackSearcher_Expecter {
+	return &MockTrackSearcher_Expecter{mock: &_m.Mock}
+}
+
+// GetAlbumsByUPC provides a mock function for the type MockTrackSearcher
+func (_mock *MockTrackSearcher) GetAlbumsByUPC(ctx context.Context, upc string) (models.AlbumResponse, error) {
+	ret := _mock.Called(ctx, upc)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetAlbumsByUPC")
+	}
+
+	var r0 models.AlbumResponse
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) (models.AlbumResponse, error)); ok {
+		return returnFunc(ctx, upc)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) models.AlbumResponse); ok {
+		r0 = returnFunc(ctx, upc)
+	} else {
+		r0 = ret.Get(0).(models.AlbumResponse)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = returnFunc(ctx, upc)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockTrackSearcher_GetAlbumsByUPC_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetAlbumsByUPC'
+type MockTrackSearcher_GetAlbumsByUPC_Call struct {
+	*mock.Call
+}
+
+// GetAlbumsByUPC is a helper method to define mock.On call
+//   - ctx context.Context
+//   - upc string
+func (_e *MockTrackSearcher_Expecter) GetAlbumsByUPC(ctx interface{}, upc interface{}) *MockTrackSearcher_GetAlbumsByUPC_Call {
+	return &MockTrackSearcher_GetAlbumsByUPC_Call{Call: _e.mock.On("GetAlbumsByUPC", ctx, upc)}
+}
+
+func (_c *MockTrackSearcher_GetAlbumsByUPC_Call) Run(run func(ctx context.Context, upc string)) *MockTrackSearcher_GetAlbumsByUPC_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockTrackSearcher_GetAlbumsByUPC_Call) Return(albumResponse models.AlbumResponse, err error) *MockTrackSearcher_GetAlbumsByUPC_Call {
+	_c.Call.Return(albumResponse, err)
+	return _c
+}
+
+func (_c *MockTrackSearcher_GetAlbumsByUPC_Call) RunAndReturn(run func(ctx context.Context, upc string) (models.AlbumResponse, error)) *MockTrackSearcher_GetAlbumsByUPC_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SearchSongs provides a mock function for the type MockTrackSearcher
+func (_mock *MockTrackSearcher) SearchSongs(ctx context.Context, term string) (models.SearchResponse, error) {
+	ret := _mock.Called(ctx, term)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SearchSongs")
+	}
+
+	var r0 models.SearchResponse
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) (models.SearchResponse, error)); ok {
+		return returnFunc(ctx, term)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) models.SearchResponse); ok {
+		r0 = returnFunc(ctx, term)
+	} else {
+		r0 = ret.Get(0).(models.SearchResponse)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = returnFunc(ctx, term)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockTrackSearcher_SearchSongs_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SearchSongs'
+type MockTrackSearcher_SearchSongs_Call struct {
+	*mock.Call
+}
+
+// SearchSongs is a helper method to define mock.On call
+//   - ctx context.Context
+//   - term string
+func (_e *MockTrackSearcher_Expecter) SearchSongs(ctx interface{}, term interface{}) *MockTrackSearcher_SearchSongs_Call {
+	return &MockTrackSearcher_SearchSongs_Call{Call: _e.mock.On("SearchSongs", ctx, term)}
+}
+
+func (_c *MockTrackSearcher_SearchSongs_Call) Run(run func(ctx context.Context, term string)) *MockTrackSearcher_SearchSongs_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockTrackSearcher_SearchSongs_Call) Return(searchResponse models.SearchResponse, err error) *MockTrackSearcher_SearchSongs_Call {
+	_c.Call.Return(searchResponse, err)
+	return _c
+}
+
+func (_c *MockTrackSearcher_SearchSongs_Call) RunAndReturn(run func(ctx context.Context, term string) (models.SearchResponse, error)) *MockTrackSearcher_SearchSongs_Call {
+	_c.Call.Return(run)
+	return _c
+}
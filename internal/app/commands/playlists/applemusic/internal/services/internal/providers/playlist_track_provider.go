@@ -0,0 +1,57 @@
+package providers
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/jbenzshawel/playlist-generator/internal/app/commands/playlists/applemusic/models"
+)
+
+const maxPageSize = 100
+
+type TrackGetter interface {
+	GetLibraryPlaylistTracks(ctx context.Context, playlistID string, limit, offset int) (models.LibrarySongPage, error)
+}
+
+type PlaylistTrackProvider interface {
+	// GetTrackIDs returns the catalog IDs of the songs in a library playlist.
+	GetTrackIDs(ctx context.Context, playlistID string) ([]string, error)
+}
+
+func NewPlaylistTrackProvider(getter TrackGetter) PlaylistTrackProvider {
+	return &playlistTrackProvider{
+		getter: getter,
+	}
+}
+
+type playlistTrackProvider struct {
+	getter TrackGetter
+}
+
+func (p *playlistTrackProvider) GetTrackIDs(ctx context.Context, playlistID string) ([]string, error) {
+	var trackIDs []string
+
+	// the library API only returns the next page's URL, so pages are loaded in order
+	for offset := 0; ; {
+		page, err := p.getter.GetLibraryPlaylistTracks(ctx, playlistID, maxPageSize, offset)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, song := range page.Data {
+			// songs added from the catalog are in the library under another ID
+			if song.Attributes.PlayParams.CatalogID != "" {
+				trackIDs = append(trackIDs, song.Attributes.PlayParams.CatalogID)
+			}
+		}
+
+		offset += len(page.Data)
+		if page.Next == "" || len(page.Data) == 0 {
+			break
+		}
+	}
+
+	slog.Debug("retrieved tracks for playlist", slog.Int("total", len(trackIDs)))
+
+	return trackIDs, nil
+}
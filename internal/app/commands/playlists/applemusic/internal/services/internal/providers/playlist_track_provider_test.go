@@ -0,0 +1,95 @@
+package providers
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/jbenzshawel/playlist-generator/internal/app/commands/playlists/applemusic/models"
+)
+
+func TestPlaylistTrackProvider_GetTrackIDs(t *testing.T) {
+	const (
+		total      = 201
+		playlistID = "p.testPlaylistID"
+	)
+
+	ctx := context.Background()
+
+	testTrackPages := getTestLibrarySongPages(total)
+
+	mockGetter := NewMockTrackGetter(t)
+	for idx, page := range testTrackPages {
+		mockGetter.EXPECT().GetLibraryPlaylistTracks(mock.Anything, playlistID, maxPageSize, idx*maxPageSize).Return(page, nil)
+	}
+
+	p := NewPlaylistTrackProvider(mockGetter)
+
+	actualTrackIDs, err := p.GetTrackIDs(ctx, playlistID)
+	require.NoError(t, err)
+
+	trackIDs := map[string]struct{}{}
+	for _, trackID := range actualTrackIDs {
+		trackIDs[trackID] = struct{}{}
+	}
+	assert.Len(t, trackIDs, total)
+}
+
+func TestPlaylistTrackProvider_GetTrackIDs_SkipsLibraryOnlySongs(t *testing.T) {
+	const playlistID = "p.testPlaylistID"
+
+	ctx := context.Background()
+
+	page := models.LibrarySongPage{
+		Data: []models.LibrarySong{
+			newTestLibrarySong("i.1", "1440839912"),
+			// uploaded songs aren't in the catalog
+			newTestLibrarySong("i.2", ""),
+		},
+	}
+
+	mockGetter := NewMockTrackGetter(t)
+	mockGetter.EXPECT().GetLibraryPlaylistTracks(mock.Anything, playlistID, maxPageSize, 0).Return(page, nil)
+
+	p := NewPlaylistTrackProvider(mockGetter)
+
+	actualTrackIDs, err := p.GetTrackIDs(ctx, playlistID)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"1440839912"}, actualTrackIDs)
+}
+
+func newTestLibrarySong(id, catalogID string) models.LibrarySong {
+	s := models.LibrarySong{
+		ID:   id,
+		Type: "library-songs",
+	}
+	s.Attributes.PlayParams.ID = id
+	s.Attributes.PlayParams.CatalogID = catalogID
+
+	return s
+}
+
+func getTestLibrarySongPages(numTracks int) []models.LibrarySongPage {
+	numPages := (numTracks + maxPageSize - 1) / maxPageSize
+
+	pages := make([]models.LibrarySongPage, numPages)
+	for pageIdx := 0; pageIdx < numPages; pageIdx++ {
+		pageSize := min(maxPageSize, numTracks-pageIdx*maxPageSize)
+
+		for trackIdx := 0; trackIdx < pageSize; trackIdx++ {
+			id := fmt.Sprintf("%d-%d", pageIdx, trackIdx)
+			pages[pageIdx].Data = append(pages[pageIdx].Data, newTestLibrarySong("i."+id, id))
+		}
+		pages[pageIdx].Meta.Total = numTracks
+
+		if pageIdx < numPages-1 {
+			pages[pageIdx].Next = fmt.Sprintf("/v1/me/library/playlists/p.testPlaylistID/tracks?offset=%d", (pageIdx+1)*maxPageSize)
+		}
+	}
+
+	return pages
+}
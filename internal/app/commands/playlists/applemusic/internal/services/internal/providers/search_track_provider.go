@@ -0,0 +1,121 @@
+package providers
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+
+	"github.com/jbenzshawel/playlist-generator/internal/app/commands/playlists/applemusic/models"
+	"github.com/jbenzshawel/playlist-generator/internal/app/commands/playlists/internal/matching"
+	"github.com/jbenzshawel/playlist-generator/internal/domain"
+)
+
+type TrackSearcher interface {
+	SearchSongs(ctx context.Context, term string) (models.SearchResponse, error)
+	GetAlbumsByUPC(ctx context.Context, upc string) (models.AlbumResponse, error)
+}
+
+type SearchTrackProvider interface {
+	// SearchTrack returns the Apple Music track that best matches a song.
+	SearchTrack(ctx context.Context, song domain.Song) (domain.Track, error)
+}
+
+func NewSearchTrackProvider(s TrackSearcher, scoring domain.MatchScoring) SearchTrackProvider {
+	return &searchTrackProvider{
+		searcher: s,
+		scoring:  scoring,
+	}
+}
+
+type searchTrackProvider struct {
+	searcher TrackSearcher
+	scoring  domain.MatchScoring
+}
+
+func (s *searchTrackProvider) SearchTrack(ctx context.Context, song domain.Song) (domain.Track, error) {
+	// Identifiers are more reliable than names, fuzzy search is only used as a fallback
+	track, err := s.searchUPC(ctx, song)
+	if err == nil {
+		return track, nil
+	}
+	if !errors.Is(err, matching.ErrTrackNotFound) {
+		return domain.Track{}, err
+	}
+
+	resp, err := s.searcher.SearchSongs(ctx, song.Artist()+" "+song.Track())
+	if err != nil {
+		return domain.Track{}, err
+	}
+
+	if len(resp.Results.Songs.Data) > 0 {
+		return findSongTrackMatch(s.scoring, resp.Results.Songs.Data, song)
+	}
+
+	return domain.Track{}, matching.ErrTrackNotFound
+}
+
+// searchUPC searches for the songs on the album with the song's UPC and returns the
+// song with the closest matching name.
+func (s *searchTrackProvider) searchUPC(ctx context.Context, song domain.Song) (domain.Track, error) {
+	if song.UPC() == "" {
+		return domain.Track{}, matching.ErrTrackNotFound
+	}
+
+	resp, err := s.searcher.GetAlbumsByUPC(ctx, song.UPC())
+	if err != nil {
+		return domain.Track{}, err
+	}
+
+	var (
+		best        models.Song
+		bestAlbum   string
+		bestPercent float64
+	)
+	for _, album := range resp.Data {
+		for _, t := range album.Relationships.Tracks.Data {
+			// albums also include music videos
+			if t.Type != models.SongResourceType {
+				continue
+			}
+
+			percent := matching.TitleSimilarity(s.scoring, song.Track(), t.Attributes.Name)
+			if percent > bestPercent {
+				best, bestAlbum, bestPercent = t, album.Attributes.Name, percent
+			}
+		}
+	}
+
+	if bestPercent < matching.MinMatchPercent {
+		return domain.Track{}, matching.ErrTrackNotFound
+	}
+
+	slog.Debug("upc match track found", slog.Any("match", best))
+
+	if best.Attributes.AlbumName == "" {
+		best.Attributes.AlbumName = bestAlbum
+	}
+
+	return newCandidate(best).Track(domain.AppleMusicPlaylistType, song, domain.UPCMatchMethod, bestPercent), nil
+}
+
+func findSongTrackMatch(scoring domain.MatchScoring, songs []models.Song, song domain.Song) (domain.Track, error) {
+	slog.Debug("apple music search songs found", slog.Int("count", len(songs)))
+
+	candidates := make([]matching.Candidate, 0, len(songs))
+	for _, s := range songs {
+		candidates = append(candidates, newCandidate(s))
+	}
+
+	return matching.FindTrackMatch(domain.AppleMusicPlaylistType, scoring, candidates, song)
+}
+
+func newCandidate(s models.Song) matching.Candidate {
+	return matching.Candidate{
+		ID:         s.ID,
+		URI:        s.Attributes.URL,
+		ArtistName: s.Attributes.ArtistName,
+		Title:      s.Attributes.Name,
+		Album:      s.Attributes.AlbumName,
+		ISRC:       s.Attributes.ISRC,
+	}
+}
@@ -0,0 +1,219 @@
+package providers
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/jbenzshawel/playlist-generator/internal/app/commands/playlists/applemusic/models"
+	"github.com/jbenzshawel/playlist-generator/internal/app/commands/playlists/internal/matching"
+	"github.com/jbenzshawel/playlist-generator/internal/domain"
+)
+
+func newTestSong(id, name, artist, album string) models.Song {
+	s := models.Song{
+		ID:   id,
+		Type: models.SongResourceType,
+	}
+	s.Attributes.Name = name
+	s.Attributes.ArtistName = artist
+	s.Attributes.AlbumName = album
+	s.Attributes.ISRC = "USAM1" + id
+	s.Attributes.URL = "https://music.apple.com/us/song/" + id
+
+	return s
+}
+
+func TestSearchTrackProvider_SearchTrack(t *testing.T) {
+	const (
+		artist = "Cake"
+		track  = "Never There"
+		album  = "Prolonging The Magic"
+
+		trackID = "1440839912"
+		url     = "https://music.apple.com/us/song/" + trackID
+	)
+
+	song, err := domain.NewSong(artist, track, album, "")
+	require.NoError(t, err)
+
+	testCases := []struct {
+		name          string
+		searchResults []models.Song
+		expectedTrack domain.Track
+		expectedErr   error
+	}{
+		{
+			name: "single result",
+			searchResults: []models.Song{
+				newTestSong(trackID, track, artist, album),
+			},
+			expectedTrack: domain.NewTrack(domain.AppleMusicPlaylistType, song.ID(), trackID, url, domain.SingleResultMatchMethod, 100, artist, track, album, "USAM1"+trackID),
+		},
+		{
+			name: "single result below threshold",
+			searchResults: []models.Song{
+				newTestSong(trackID, "Satan Is My Motor", "Cake", "Fashion Nugget"),
+			},
+			expectedErr: matching.ErrMatchBelowThreshold,
+		},
+		{
+			name: "exact match",
+			searchResults: []models.Song{
+				newTestSong("live", "Never There (Live)", artist, "Live at the Fillmore"),
+				newTestSong(trackID, track, artist, album),
+			},
+			expectedTrack: domain.NewTrack(domain.AppleMusicPlaylistType, song.ID(), trackID, url, domain.ExactMatchMethod, 100, artist, track, album, "USAM1"+trackID),
+		},
+		{
+			name: "fuzzy match",
+			searchResults: []models.Song{
+				newTestSong("other", "Satan Is My Motor", artist, "Prolonging The Magic"),
+				newTestSong(trackID, track, "CAKE", "Prolonging Magic"),
+			},
+			expectedTrack: domain.NewTrack(domain.AppleMusicPlaylistType, song.ID(), trackID, url, domain.FuzzyMatchMethod, 95, "CAKE", track, "Prolonging Magic", "USAM1"+trackID),
+		},
+		{
+			name: "fuzzy match below threshold",
+			searchResults: []models.Song{
+				newTestSong("other", "Satan Is My Motor", "Cake", "Fashion Nugget"),
+				newTestSong("another", "The Distance", "Cake", "Fashion Nugget"),
+			},
+			expectedErr: matching.ErrMatchBelowThreshold,
+		},
+		{
+			name:        "no results",
+			expectedErr: matching.ErrTrackNotFound,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := context.Background()
+
+			var resp models.SearchResponse
+			resp.Results.Songs.Data = tc.searchResults
+
+			searcher := NewMockTrackSearcher(t)
+			searcher.EXPECT().SearchSongs(ctx, artist+" "+track).Return(resp, nil)
+
+			provider := searchTrackProvider{
+				searcher: searcher,
+			}
+
+			actualTrack, err := provider.SearchTrack(ctx, song)
+			if tc.expectedErr != nil {
+				assert.ErrorIs(t, err, tc.expectedErr)
+				return
+			}
+
+			require.NoError(t, err)
+			assertTrack(t, tc.expectedTrack, actualTrack)
+		})
+	}
+}
+
+func TestSearchTrackProvider_SearchTrack_UPC(t *testing.T) {
+	const (
+		artist = "Cake"
+		track  = "Never There"
+		album  = "Prolonging The Magic"
+		upc    = "008811121520"
+
+		trackID = "1440839912"
+		url     = "https://music.apple.com/us/song/" + trackID
+	)
+
+	song, err := domain.NewSong(artist, track, album, upc)
+	require.NoError(t, err)
+
+	newAlbum := func(tracks ...models.Song) models.Album {
+		var a models.Album
+		a.Attributes.Name = album
+		a.Attributes.UPC = upc
+		a.Relationships.Tracks.Data = tracks
+
+		return a
+	}
+
+	video := newTestSong("video", track, artist, "")
+	video.Type = "music-videos"
+
+	fuzzyResults := models.SearchResponse{}
+	fuzzyResults.Results.Songs.Data = []models.Song{
+		newTestSong("fuzzy", track, artist, album),
+	}
+
+	testCases := []struct {
+		name          string
+		upcResults    models.AlbumResponse
+		expectFuzzy   bool
+		expectedTrack domain.Track
+	}{
+		{
+			name: "upc match",
+			upcResults: models.AlbumResponse{
+				Data: []models.Album{
+					newAlbum(
+						video,
+						newTestSong("other", "Satan Is My Motor", artist, ""),
+						newTestSong(trackID, track, artist, ""),
+					),
+				},
+			},
+			expectedTrack: domain.NewTrack(domain.AppleMusicPlaylistType, song.ID(), trackID, url, domain.UPCMatchMethod, 100, artist, track, album, "USAM1"+trackID),
+		},
+		{
+			name: "track name mismatch falls back to fuzzy search",
+			upcResults: models.AlbumResponse{
+				Data: []models.Album{
+					newAlbum(newTestSong(trackID, "Satan Is My Motor", artist, album)),
+				},
+			},
+			expectFuzzy:   true,
+			expectedTrack: domain.NewTrack(domain.AppleMusicPlaylistType, song.ID(), "fuzzy", "https://music.apple.com/us/song/fuzzy", domain.SingleResultMatchMethod, 100, artist, track, album, "USAM1fuzzy"),
+		},
+		{
+			name:          "no upc results falls back to fuzzy search",
+			expectFuzzy:   true,
+			expectedTrack: domain.NewTrack(domain.AppleMusicPlaylistType, song.ID(), "fuzzy", "https://music.apple.com/us/song/fuzzy", domain.SingleResultMatchMethod, 100, artist, track, album, "USAM1fuzzy"),
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := context.Background()
+
+			searcher := NewMockTrackSearcher(t)
+			searcher.EXPECT().GetAlbumsByUPC(ctx, upc).Return(tc.upcResults, nil)
+			if tc.expectFuzzy {
+				searcher.EXPECT().SearchSongs(ctx, artist+" "+track).Return(fuzzyResults, nil)
+			}
+
+			provider := searchTrackProvider{
+				searcher: searcher,
+			}
+
+			actualTrack, err := provider.SearchTrack(ctx, song)
+			require.NoError(t, err)
+			assertTrack(t, tc.expectedTrack, actualTrack)
+		})
+	}
+}
+
+// assertTrack compares tracks ignoring when the tracks were matched.
+func assertTrack(t *testing.T, expected, actual domain.Track) {
+	t.Helper()
+
+	assert.Equal(t, expected.PlaylistType(), actual.PlaylistType())
+	assert.Equal(t, expected.SongID(), actual.SongID())
+	assert.Equal(t, expected.TrackID(), actual.TrackID())
+	assert.Equal(t, expected.URI(), actual.URI())
+	assert.Equal(t, expected.MatchFound(), actual.MatchFound())
+	assert.Equal(t, expected.MatchMethod(), actual.MatchMethod())
+	assert.InDelta(t, expected.Confidence(), actual.Confidence(), 0.01)
+	assert.Equal(t, expected.MatchedArtist(), actual.MatchedArtist())
+	assert.Equal(t, expected.MatchedTrack(), actual.MatchedTrack())
+	assert.Equal(t, expected.MatchedAlbum(), actual.MatchedAlbum())
+	assert.Equal(t, expected.ISRC(), actual.ISRC())
+}
@@ -0,0 +1,126 @@
+package models
+
+import "log/slog"
+
+// SongResourceType is the resource type of catalog songs, albums also include music videos.
+const SongResourceType = "songs"
+
+type SearchResponse struct {
+	Results SearchResults `json:"results"`
+}
+
+type SearchResults struct {
+	Songs SongPage `json:"songs"`
+}
+
+type SongPage struct {
+	Endpoint string `json:"href"`
+	Next     string `json:"next"`
+	Data     []Song `json:"data"`
+}
+
+type Song struct {
+	ID         string         `json:"id"`
+	Type       string         `json:"type"`
+	Endpoint   string         `json:"href"`
+	Attributes SongAttributes `json:"attributes"`
+}
+
+type SongAttributes struct {
+	Name       string `json:"name"`
+	ArtistName string `json:"artistName"`
+	AlbumName  string `json:"albumName"`
+	ISRC       string `json:"isrc"`
+	URL        string `json:"url"`
+}
+
+func (s Song) LogValue() slog.Value {
+	return slog.GroupValue(
+		slog.String("artist", s.Attributes.ArtistName),
+		slog.String("track", s.Attributes.Name),
+	)
+}
+
+type AlbumResponse struct {
+	Data []Album `json:"data"`
+}
+
+type Album struct {
+	ID            string             `json:"id"`
+	Attributes    AlbumAttributes    `json:"attributes"`
+	Relationships AlbumRelationships `json:"relationships"`
+}
+
+type AlbumAttributes struct {
+	Name       string `json:"name"`
+	ArtistName string `json:"artistName"`
+	UPC        string `json:"upc"`
+	IsSingle   bool   `json:"isSingle"`
+}
+
+type AlbumRelationships struct {
+	Tracks SongPage `json:"tracks"`
+}
+
+type LibraryPlaylistResponse struct {
+	Data []LibraryPlaylist `json:"data"`
+}
+
+type LibraryPlaylist struct {
+	ID         string                    `json:"id"`
+	Type       string                    `json:"type"`
+	Endpoint   string                    `json:"href"`
+	Attributes LibraryPlaylistAttributes `json:"attributes"`
+}
+
+type LibraryPlaylistAttributes struct {
+	Name string `json:"name"`
+}
+
+type CreateLibraryPlaylistRequest struct {
+	Attributes CreateLibraryPlaylistAttributes `json:"attributes"`
+}
+
+type CreateLibraryPlaylistAttributes struct {
+	Name        string `json:"name"`
+	Description string `json:"description,omitempty"`
+}
+
+type AddTracksToLibraryPlaylistRequest struct {
+	Data []TrackReference `json:"data"`
+}
+
+// TrackReference references a catalog song by ID
+type TrackReference struct {
+	ID   string `json:"id"`
+	Type string `json:"type"`
+}
+
+type LibrarySongPage struct {
+	Next string          `json:"next"`
+	Data []LibrarySong   `json:"data"`
+	Meta LibraryPageMeta `json:"meta"`
+}
+
+type LibraryPageMeta struct {
+	Total int `json:"total"`
+}
+
+type LibrarySong struct {
+	ID         string                `json:"id"`
+	Type       string                `json:"type"`
+	Attributes LibrarySongAttributes `json:"attributes"`
+}
+
+type LibrarySongAttributes struct {
+	Name       string     `json:"name"`
+	ArtistName string     `json:"artistName"`
+	PlayParams PlayParams `json:"playParams"`
+}
+
+// PlayParams identifies the catalog song a library song was added from
+type PlayParams struct {
+	ID        string `json:"id"`
+	Kind      string `json:"kind"`
+	CatalogID string `json:"catalogId"`
+}
@@ -0,0 +1,29 @@
+package applemusic
+
+import (
+	"context"
+
+	"github.com/jbenzshawel/playlist-generator/internal/app/commands/playlists/applemusic/internal/services"
+	"github.com/jbenzshawel/playlist-generator/internal/app/commands/playlists/internal/tracks"
+	"github.com/jbenzshawel/playlist-generator/internal/domain"
+)
+
+// SearchTracksCommand searches Apple Music for the songs that haven't been searched for.
+type SearchTracksCommand = tracks.SearchTracksCommand
+
+type SearchTracksCommandResult = tracks.SearchTracksCommandResult
+
+type SearchTracksCommandHandler = tracks.SearchTracksCommandHandler
+
+func NewSearchTracksCommand(searchService services.SearchService, repository domain.Repository) SearchTracksCommandHandler {
+	return tracks.NewSearchTracksCommand(domain.AppleMusicPlaylistType, trackSearcher{searchService}, repository)
+}
+
+// trackSearcher searches Apple Music without the ISRC of the song's match on another provider.
+type trackSearcher struct {
+	searchService services.SearchService
+}
+
+func (s trackSearcher) SearchTrack(ctx context.Context, song domain.Song, _ string) (domain.Track, error) {
+	return s.searchService.SearchTrack(ctx, song)
+}
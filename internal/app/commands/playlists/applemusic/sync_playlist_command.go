@@ -0,0 +1,18 @@
+package applemusic
+
+import (
+	"github.com/jbenzshawel/playlist-generator/internal/app/commands/playlists/applemusic/internal/services"
+	"github.com/jbenzshawel/playlist-generator/internal/app/commands/playlists/internal/tracks"
+	"github.com/jbenzshawel/playlist-generator/internal/domain"
+)
+
+type SyncPlaylistCommand = tracks.SyncPlaylistCommand
+
+type SyncPlaylistCommandHandler = tracks.SyncPlaylistCommandHandler
+
+func NewSyncPlaylistCommand(
+	playlistService services.PlaylistService,
+	repository domain.Repository,
+) SyncPlaylistCommandHandler {
+	return tracks.NewSyncPlaylistCommand(domain.AppleMusicPlaylistType, playlistService, repository)
+}
@@ -0,0 +1,202 @@
+// Package matching scores the tracks found by searching a streaming provider against a
+// song. Providers map their search results to candidates and keep only their API calls.
+package matching
+
+import (
+	"errors"
+	"log/slog"
+	"slices"
+	"strings"
+
+	"github.com/jbenzshawel/playlist-generator/internal/common/normalize"
+	"github.com/jbenzshawel/playlist-generator/internal/domain"
+)
+
+// MinMatchPercent is the lowest confidence a fuzzy match is accepted with
+const MinMatchPercent = 70.0
+
+var (
+	ErrTrackNotFound       = errors.New("track not found")
+	ErrMatchBelowThreshold = errors.New("match below threshold")
+)
+
+// Candidate is a track found on a provider.
+type Candidate struct {
+	ID  string
+	URI string
+
+	// Artists are the track's artists. Providers that join every artist into one name set
+	// ArtistName instead.
+	Artists    []string
+	ArtistName string
+
+	Title string
+	Album string
+
+	// SingleAlbum is set when the track's album is a single, whose name is usually the
+	// track's title rather than the album the song aired from.
+	SingleAlbum bool
+
+	ISRC string
+}
+
+func (c Candidate) LogValue() slog.Value {
+	return slog.GroupValue(
+		slog.String("artist", c.artist()),
+		slog.String("track", c.Title),
+	)
+}
+
+func (c Candidate) artist() string {
+	if c.ArtistName != "" {
+		return c.ArtistName
+	}
+	return strings.Join(c.Artists, ", ")
+}
+
+// Track returns the candidate as the track a song was matched to
+func (c Candidate) Track(playlistType domain.PlaylistType, song domain.Song, method domain.MatchMethod, confidence float64) domain.Track {
+	return domain.NewTrack(playlistType, song.ID(), c.ID, c.URI, method, confidence, c.artist(), c.Title, c.Album, c.ISRC)
+}
+
+// Match is a candidate scored against a song.
+type Match struct {
+	Candidate Candidate
+
+	scoring            domain.MatchScoring
+	artistPercentMatch float64
+	trackPercentMatch  float64
+	albumPercentMatch  float64
+}
+
+func NewMatch(scoring domain.MatchScoring, c Candidate, song domain.Song) Match {
+	return Match{
+		Candidate:          c,
+		scoring:            scoring,
+		trackPercentMatch:  TitleSimilarity(scoring, song.Track(), c.Title),
+		artistPercentMatch: percentArtistMatch(scoring, c, song.Artist()),
+		albumPercentMatch:  percentAlbumMatch(scoring, c, song),
+	}
+}
+
+func (m Match) IsExactMatch() bool {
+	return m.trackPercentMatch == 100 &&
+		m.artistPercentMatch == 100 &&
+		m.albumPercentMatch == 100
+}
+
+// ArtistPercentMatch returns how closely the candidate's artists match the song's credit
+func (m Match) ArtistPercentMatch() float64 {
+	return m.artistPercentMatch
+}
+
+func (m Match) WeightedAverage() float64 {
+	return m.scoring.WeightedAverage(m.artistPercentMatch, m.trackPercentMatch, m.albumPercentMatch)
+}
+
+// Track returns the matched candidate as the track the song was matched to
+func (m Match) Track(playlistType domain.PlaylistType, song domain.Song, method domain.MatchMethod) domain.Track {
+	return m.Candidate.Track(playlistType, song, method, m.WeightedAverage())
+}
+
+// RankMatches scores every candidate against the song, best match first. Candidates
+// that score the same keep the provider's order.
+func RankMatches(scoring domain.MatchScoring, candidates []Candidate, song domain.Song) []Match {
+	matches := make([]Match, 0, len(candidates))
+	for _, c := range candidates {
+		matches = append(matches, NewMatch(scoring, c, song))
+	}
+
+	slices.SortStableFunc(matches, func(a, b Match) int {
+		switch {
+		case a.WeightedAverage() > b.WeightedAverage():
+			return -1
+		case a.WeightedAverage() < b.WeightedAverage():
+			return 1
+		default:
+			return 0
+		}
+	})
+
+	return matches
+}
+
+// FindTrackMatch returns the track of the candidate that best matches the song, for
+// providers whose matches aren't queued for review. Even a single result needs the
+// minimum confidence.
+func FindTrackMatch(playlistType domain.PlaylistType, scoring domain.MatchScoring, candidates []Candidate, song domain.Song) (domain.Track, error) {
+	matches := RankMatches(scoring, candidates, song)
+	if len(matches) == 0 {
+		return domain.Track{}, ErrTrackNotFound
+	}
+
+	best := matches[0]
+	if best.WeightedAverage() < MinMatchPercent {
+		return domain.Track{}, ErrMatchBelowThreshold
+	}
+
+	switch {
+	case len(matches) == 1:
+		slog.Debug("match track found", slog.Any("match", best.Candidate))
+		return best.Track(playlistType, song, domain.SingleResultMatchMethod), nil
+	case best.IsExactMatch():
+		return best.Track(playlistType, song, domain.ExactMatchMethod), nil
+	}
+
+	slog.Debug("partial match track found",
+		slog.Any("percent", best.WeightedAverage()),
+		slog.Any("match", best.Candidate),
+	)
+
+	return best.Track(playlistType, song, domain.FuzzyMatchMethod), nil
+}
+
+// TitleSimilarity compares track or album titles after normalizing them so version notes
+// such as "- 2011 Remaster" don't lower the similarity.
+func TitleSimilarity(scoring domain.MatchScoring, s1, s2 string) float64 {
+	return scoring.Similarity(normalize.Title(s1), normalize.Title(s2))
+}
+
+func percentAlbumMatch(scoring domain.MatchScoring, c Candidate, song domain.Song) float64 {
+	if c.SingleAlbum && strings.HasPrefix(normalize.Title(song.Album()), normalize.Title(song.Track())) {
+		return 100.0
+	}
+
+	return TitleSimilarity(scoring, song.Album(), c.Album)
+}
+
+// percentArtistMatch scores the credited artists as a set against the candidate's artists.
+// Each reading of the credit scores the average of each credited artist's best match,
+// so a credit is only a full match when every credited artist is found on the track.
+// An artist name joining every artist is compared by each of its readings.
+func percentArtistMatch(scoring domain.MatchScoring, candidate Candidate, credit string) float64 {
+	var names []string
+	for _, a := range candidate.Artists {
+		names = append(names, normalize.Artist(a))
+	}
+	if candidate.ArtistName != "" {
+		for _, reading := range normalize.ArtistCredits(candidate.ArtistName) {
+			names = append(names, reading...)
+		}
+	}
+
+	if len(names) == 0 {
+		return 0
+	}
+
+	var best float64
+	for _, credited := range normalize.ArtistCredits(credit) {
+		var total float64
+		for _, c := range credited {
+			var found float64
+			for _, name := range names {
+				found = max(found, scoring.Similarity(c, name))
+			}
+			total += found
+		}
+
+		best = max(best, total/float64(len(credited)))
+	}
+
+	return best
+}
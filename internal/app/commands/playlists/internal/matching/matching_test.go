@@ -0,0 +1,137 @@
+package matching
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/jbenzshawel/playlist-generator/internal/domain"
+)
+
+func TestPercentArtistMatch(t *testing.T) {
+	testCases := []struct {
+		name      string
+		candidate Candidate
+		credit    string
+		expected  float64
+	}{
+		{
+			name:     "no artists",
+			credit:   "Cake",
+			expected: 0,
+		},
+		{
+			name:      "single artist",
+			candidate: Candidate{Artists: []string{"CAKE"}},
+			credit:    "Cake",
+			expected:  100,
+		},
+		{
+			name:      "joint credit",
+			candidate: Candidate{Artists: []string{"Norah Jones", "Mavis Staples"}},
+			credit:    "Norah Jones & Mavis Staples",
+			expected:  100,
+		},
+		{
+			name:      "joint credit is a single act",
+			candidate: Candidate{Artists: []string{"Simon & Garfunkel"}},
+			credit:    "Simon and Garfunkel",
+			expected:  100,
+		},
+		{
+			name:      "with credit",
+			candidate: Candidate{Artists: []string{"Mavis Staples", "Norah Jones"}},
+			credit:    "Norah Jones with Mavis Staples",
+			expected:  100,
+		},
+		{
+			name:      "featured artist missing from track",
+			candidate: Candidate{Artists: []string{"Khruangbin"}},
+			credit:    "Khruangbin feat. Leon Bridges",
+			expected:  100,
+		},
+		{
+			name:      "credited artist missing from track",
+			candidate: Candidate{Artists: []string{"Norah Jones"}},
+			credit:    "Norah Jones & Mavis Staples",
+			expected:  61.54,
+		},
+		{
+			name:      "same artist name",
+			candidate: Candidate{ArtistName: "Cake"},
+			credit:    "Cake",
+			expected:  100,
+		},
+		{
+			name:      "featured artist joined into the name",
+			candidate: Candidate{ArtistName: "Cake & Gus Seyffert"},
+			credit:    "Cake feat. Gus Seyffert",
+			expected:  100,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.InDelta(t, tc.expected, percentArtistMatch(domain.MatchScoring{}, tc.candidate, tc.credit), 0.01)
+		})
+	}
+}
+
+func TestFindTrackMatch(t *testing.T) {
+	song, err := domain.NewSong("Cake", "Never There", "Prolonging The Magic", "")
+	require.NoError(t, err)
+
+	exact := Candidate{ID: "1", URI: "uri1", Artists: []string{"Cake"}, Title: "Never There", Album: "Prolonging The Magic"}
+	live := Candidate{ID: "2", URI: "uri2", Artists: []string{"Cake"}, Title: "Never There", Album: "Live At The Crystal Palace"}
+	other := Candidate{ID: "3", URI: "uri3", Artists: []string{"Weezer"}, Title: "Buddy Holly", Album: "Weezer"}
+
+	testCases := []struct {
+		name           string
+		candidates     []Candidate
+		expectedID     string
+		expectedMethod domain.MatchMethod
+		expectedErr    error
+	}{
+		{
+			name:        "no candidates",
+			expectedErr: ErrTrackNotFound,
+		},
+		{
+			name:           "single result",
+			candidates:     []Candidate{live},
+			expectedID:     "2",
+			expectedMethod: domain.SingleResultMatchMethod,
+		},
+		{
+			name:        "single result below threshold",
+			candidates:  []Candidate{other},
+			expectedErr: ErrMatchBelowThreshold,
+		},
+		{
+			name:           "exact match",
+			candidates:     []Candidate{other, live, exact},
+			expectedID:     "1",
+			expectedMethod: domain.ExactMatchMethod,
+		},
+		{
+			name:           "best fuzzy match",
+			candidates:     []Candidate{other, live},
+			expectedID:     "2",
+			expectedMethod: domain.FuzzyMatchMethod,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			track, err := FindTrackMatch(domain.TidalPlaylistType, domain.MatchScoring{}, tc.candidates, song)
+			if tc.expectedErr != nil {
+				assert.ErrorIs(t, err, tc.expectedErr)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tc.expectedID, track.TrackID())
+			assert.Equal(t, tc.expectedMethod, track.MatchMethod())
+			assert.Equal(t, domain.TidalPlaylistType, track.PlaylistType())
+		})
+	}
+}
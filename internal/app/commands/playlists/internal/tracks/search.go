@@ -0,0 +1,47 @@
+package tracks
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"golang.org/x/sync/errgroup"
+
+	"github.com/jbenzshawel/playlist-generator/internal/domain"
+)
+
+// SearchSongs searches for songs concurrently. search mustn't use the repository, the
+// func it returns saves the song's search and is called for one song at a time.
+func SearchSongs(ctx context.Context, songs []domain.Song, search func(ctx context.Context, song domain.Song) func() error) error {
+	var mu sync.Mutex
+
+	g, gCtx := errgroup.WithContext(ctx)
+
+	g.SetLimit(6)
+
+	for _, song := range songs {
+		g.Go(func() (err error) {
+			defer func() {
+				if r := recover(); r != nil {
+					err = fmt.Errorf("panic occurred during searching for tracks: %v", r)
+				}
+			}()
+
+			select {
+			case <-gCtx.Done():
+				return gCtx.Err()
+			default:
+			}
+
+			save := search(gCtx, song)
+
+			// the repository's transaction isn't safe for concurrent use
+			mu.Lock()
+			defer mu.Unlock()
+
+			return save()
+		})
+	}
+
+	return g.Wait()
+}
@@ -0,0 +1,178 @@
+package tracks
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/jbenzshawel/playlist-generator/internal/common/decorator"
+	"github.com/jbenzshawel/playlist-generator/internal/domain"
+)
+
+// TrackSearcher searches a provider for the track that best matches a song.
+type TrackSearcher interface {
+	// SearchTrack returns the track that best matches a song. The ISRC is from the song's
+	// match on another provider, or empty when the song hasn't been matched.
+	SearchTrack(ctx context.Context, song domain.Song, isrc string) (domain.Track, error)
+}
+
+// SearchTracksCommand searches a provider for the songs that haven't been searched for.
+type SearchTracksCommand struct {
+	// RetryNotFound re-searches songs that weren't found once their back-off has
+	// passed instead of searching for songs that haven't been matched, since songs
+	// can be added to the provider after they air.
+	RetryNotFound bool
+}
+
+type SearchTracksCommandResult struct {
+	// Matched are the songs that were matched to a track
+	Matched []domain.Song
+}
+
+type SearchTracksCommandHandler decorator.CommandWithResultHandler[SearchTracksCommand, SearchTracksCommandResult]
+
+func NewSearchTracksCommand(
+	playlistType domain.PlaylistType,
+	searcher TrackSearcher,
+	repository domain.Repository,
+) SearchTracksCommandHandler {
+	return decorator.ApplyDBTransactionDecorator(
+		&searchTracksCommandHandler{
+			playlistType: playlistType,
+			searcher:     searcher,
+			repository:   repository.Track(playlistType),
+		},
+		repository,
+	)
+}
+
+type searchTracksCommandHandler struct {
+	playlistType domain.PlaylistType
+	searcher     TrackSearcher
+	repository   domain.TrackRepository
+}
+
+func (t *searchTracksCommandHandler) Execute(ctx context.Context, cmd SearchTracksCommand) (SearchTracksCommandResult, error) {
+	songs, err := t.getSongs(ctx, cmd)
+	if err != nil {
+		return SearchTracksCommandResult{}, err
+	}
+
+	isrcs, err := t.getISRCs(ctx, songs)
+	if err != nil {
+		return SearchTracksCommandResult{}, err
+	}
+
+	var matched []domain.Song
+
+	err = SearchSongs(ctx, songs, func(ctx context.Context, song domain.Song) func() error {
+		track, searchErr := t.searcher.SearchTrack(ctx, song, isrcs[song.ID()])
+
+		return func() error {
+			if searchErr != nil {
+				slog.Warn("track not found for song",
+					slog.String("playlistType", t.playlistType.String()),
+					slog.Any("song", song),
+					slog.Any("error", searchErr),
+				)
+
+				var err error
+				track, err = t.notFoundTrack(ctx, cmd, song)
+				if err != nil {
+					return err
+				}
+			}
+
+			err := t.saveTrack(ctx, cmd, track)
+			if err != nil {
+				return err
+			}
+
+			if track.MatchFound() {
+				matched = append(matched, song)
+			}
+
+			return nil
+		}
+	})
+	if err != nil {
+		return SearchTracksCommandResult{}, err
+	}
+
+	return SearchTracksCommandResult{Matched: matched}, nil
+}
+
+func (t *searchTracksCommandHandler) getSongs(ctx context.Context, cmd SearchTracksCommand) ([]domain.Song, error) {
+	if cmd.RetryNotFound {
+		songs, err := t.repository.GetRetryableSongs(ctx, time.Now())
+		if err != nil {
+			return nil, err
+		}
+
+		slog.Info("found not found songs to search again",
+			slog.String("playlistType", t.playlistType.String()),
+			slog.Int("numSongs", len(songs)),
+		)
+		return songs, nil
+	}
+
+	songs, err := t.repository.GetUnknownSongs(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	slog.Info("found unknown songs to search",
+		slog.String("playlistType", t.playlistType.String()),
+		slog.Int("numSongs", len(songs)),
+	)
+	return songs, nil
+}
+
+// getISRCs returns the ISRCs of the songs' matches on other providers, which find the
+// same recording.
+func (t *searchTracksCommandHandler) getISRCs(ctx context.Context, songs []domain.Song) (map[uuid.UUID]string, error) {
+	isrcs := make(map[uuid.UUID]string, len(songs))
+	for _, song := range songs {
+		isrc, err := t.repository.GetISRC(ctx, song.ID())
+		if err != nil {
+			return nil, fmt.Errorf("isrc lookup error: %w", err)
+		}
+		isrcs[song.ID()] = isrc
+	}
+	return isrcs, nil
+}
+
+// notFoundTrack returns the track for a song that wasn't found. Songs searched for again
+// count every attempt so the back-off grows.
+func (t *searchTracksCommandHandler) notFoundTrack(ctx context.Context, cmd SearchTracksCommand, song domain.Song) (domain.Track, error) {
+	if !cmd.RetryNotFound {
+		return domain.NewNotFoundTrack(t.playlistType, song.ID(), 1), nil
+	}
+
+	previous, err := t.repository.GetTrackBySongID(ctx, song.ID())
+	if err != nil {
+		return domain.Track{}, err
+	}
+
+	return domain.NewNotFoundTrack(t.playlistType, song.ID(), previous.AttemptCount()+1), nil
+}
+
+func (t *searchTracksCommandHandler) saveTrack(ctx context.Context, cmd SearchTracksCommand, track domain.Track) error {
+	// songs being searched for again already have a track that is replaced
+	if cmd.RetryNotFound {
+		err := t.repository.Replace(ctx, track)
+		if err != nil {
+			return fmt.Errorf("%s track replace error: %w", t.playlistType, err)
+		}
+		return nil
+	}
+
+	err := t.repository.Insert(ctx, track)
+	if err != nil {
+		return fmt.Errorf("%s track insert error: %w", t.playlistType, err)
+	}
+	return nil
+}
@@ -0,0 +1,66 @@
+package tracks
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/jbenzshawel/playlist-generator/internal/domain"
+	"github.com/jbenzshawel/playlist-generator/internal/infrastructure/storage"
+)
+
+func TestSearchTracksCommand_SearchesByISRC(t *testing.T) {
+	repository := storage.NewRepository(storage.InitTestStorage(t))
+
+	matchedSong, err := domain.NewSong("Cake", "Never There", "Prolonging The Magic", "")
+	require.NoError(t, err)
+
+	unmatchedSong, err := domain.NewSong("Cake", "Sheep Go To Heaven", "Prolonging The Magic", "")
+	require.NoError(t, err)
+
+	require.NoError(t, repository.Begin(t.Context()))
+	require.NoError(t, repository.Song().BulkInsert(t.Context(), []domain.Song{matchedSong, unmatchedSong}))
+	require.NoError(t, repository.Track(domain.SpotifyPlaylistType).Insert(t.Context(),
+		domain.NewTrack(domain.SpotifyPlaylistType, matchedSong.ID(), "spotify1", "spotify:track:spotify1", domain.ExactMatchMethod, 100, "Cake", "Never There", "", "USCA29800388"),
+	))
+	require.NoError(t, repository.Commit())
+
+	searcher := &testTrackSearcher{
+		isrc:  "USCA29800388",
+		track: domain.NewTrack(domain.TidalPlaylistType, matchedSong.ID(), "tidal1", "tidal:track:tidal1", domain.ExactMatchMethod, 100, "Cake", "Never There", "", "USCA29800388"),
+	}
+
+	result, err := NewSearchTracksCommand(domain.TidalPlaylistType, searcher, repository).Execute(t.Context(), SearchTracksCommand{})
+	require.NoError(t, err)
+	require.Len(t, result.Matched, 1)
+	assert.Equal(t, matchedSong.ID(), result.Matched[0].ID())
+
+	require.NoError(t, repository.Begin(t.Context()))
+	defer func() { require.NoError(t, repository.Commit()) }()
+
+	track, err := repository.Track(domain.TidalPlaylistType).GetTrackBySongID(t.Context(), matchedSong.ID())
+	require.NoError(t, err)
+	assert.True(t, track.MatchFound())
+	assert.Equal(t, "tidal:track:tidal1", track.URI())
+
+	track, err = repository.Track(domain.TidalPlaylistType).GetTrackBySongID(t.Context(), unmatchedSong.ID())
+	require.NoError(t, err)
+	assert.False(t, track.MatchFound())
+	assert.Equal(t, 1, track.AttemptCount())
+}
+
+// testTrackSearcher only finds the song with the ISRC.
+type testTrackSearcher struct {
+	isrc  string
+	track domain.Track
+}
+
+func (s *testTrackSearcher) SearchTrack(_ context.Context, _ domain.Song, isrc string) (domain.Track, error) {
+	if isrc != s.isrc {
+		return domain.Track{}, errors.New("track not found")
+	}
+	return s.track, nil
+}
@@ -0,0 +1,83 @@
+package tracks
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/jbenzshawel/playlist-generator/internal/common/decorator"
+	"github.com/jbenzshawel/playlist-generator/internal/domain"
+)
+
+type SongPlaylistsCommand struct {
+	Songs []domain.Song
+}
+
+type SongPlaylistsCommandResult struct {
+	// Playlists are the existing calendar playlists that cover a day one of the
+	// songs was played
+	Playlists []domain.Playlist
+}
+
+type SongPlaylistsCommandHandler decorator.CommandWithResultHandler[SongPlaylistsCommand, SongPlaylistsCommandResult]
+
+func NewSongPlaylistsCommand(playlistType domain.PlaylistType, repository domain.Repository) SongPlaylistsCommandHandler {
+	return decorator.ApplyDBTransactionDecorator(
+		&songPlaylistsCommandHandler{
+			playlistType:         playlistType,
+			songSourceRepository: repository.SongSource(),
+			playlistRepository:   repository.Playlist(),
+		},
+		repository,
+	)
+}
+
+type songPlaylistsCommandHandler struct {
+	playlistType         domain.PlaylistType
+	songSourceRepository domain.SongSourceRepository
+	playlistRepository   domain.PlaylistRepository
+}
+
+// Execute finds the day, month, and year playlists of the provider, for the source and the
+// program, that should hold the songs. Rolling playlists aren't included since they
+// are rebuilt from their window every time they are synced.
+func (c *songPlaylistsCommandHandler) Execute(ctx context.Context, cmd SongPlaylistsCommand) (SongPlaylistsCommandResult, error) {
+	seen := map[string]struct{}{}
+	var playlists []domain.Playlist
+
+	for _, song := range cmd.Songs {
+		sources, err := c.songSourceRepository.GetSongSources(ctx, song.SongHash())
+		if err != nil {
+			return SongPlaylistsCommandResult{}, err
+		}
+
+		for _, source := range sources {
+			played, err := time.Parse(time.DateOnly, source.Day())
+			if err != nil {
+				return SongPlaylistsCommandResult{}, fmt.Errorf("invalid date played %q: %w", source.Day(), err)
+			}
+
+			for _, scope := range domain.AllPlaylistDateScopes() {
+				if scope == domain.RollingPlaylistDateScope {
+					continue
+				}
+
+				for _, program := range []string{"", source.ProgramName()} {
+					p, err := c.playlistRepository.GetPlaylistByDate(ctx, c.playlistType, source.SourceType(), program, scope, scope.Format(played))
+					if err != nil {
+						return SongPlaylistsCommandResult{}, err
+					}
+
+					if _, ok := seen[p.ID()]; p.ID() == "" || ok {
+						continue
+					}
+
+					seen[p.ID()] = struct{}{}
+					playlists = append(playlists, p)
+				}
+			}
+		}
+	}
+
+	return SongPlaylistsCommandResult{Playlists: playlists}, nil
+}
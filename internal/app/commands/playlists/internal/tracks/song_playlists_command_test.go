@@ -0,0 +1,46 @@
+package tracks
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/jbenzshawel/playlist-generator/internal/domain"
+	"github.com/jbenzshawel/playlist-generator/internal/infrastructure/storage"
+)
+
+func TestSongPlaylistsCommand(t *testing.T) {
+	repository := storage.NewRepository(storage.InitTestStorage(t))
+
+	song, err := domain.NewSong("Cake", "Never There", "Prolonging The Magic", "")
+	require.NoError(t, err)
+
+	dayPlaylist := domain.NewPlaylist("day", "tidal:day", "The Current 2025-01-02", "2025-01-02", domain.DayPlaylistDateScope, domain.TidalPlaylistType, domain.TheCurrentSourceType, "")
+	programPlaylist := domain.NewPlaylist("program", "tidal:program", "The Current Morning Show 2025-01", "2025-01", domain.MonthPlaylistDateScope, domain.TidalPlaylistType, domain.TheCurrentSourceType, "Morning Show")
+
+	require.NoError(t, repository.Begin(t.Context()))
+	require.NoError(t, repository.Song().BulkInsert(t.Context(), []domain.Song{song}))
+	require.NoError(t, repository.SongSource().BulkInsert(t.Context(), []domain.SongSource{
+		domain.NewSongSource("source1", song.SongHash(), domain.TheCurrentSourceType, "Morning Show", "2025-01-02", time.Date(2025, 1, 2, 14, 5, 0, 0, time.UTC)),
+	}))
+	for _, p := range []domain.Playlist{
+		dayPlaylist,
+		programPlaylist,
+		// playlists of another provider aren't returned
+		domain.NewPlaylist("spotify", "spotify:playlist:day", "The Current 2025-01-02", "2025-01-02", domain.DayPlaylistDateScope, domain.SpotifyPlaylistType, domain.TheCurrentSourceType, ""),
+	} {
+		require.NoError(t, repository.Playlist().Insert(t.Context(), p))
+	}
+	require.NoError(t, repository.Commit())
+
+	result, err := NewSongPlaylistsCommand(domain.TidalPlaylistType, repository).Execute(t.Context(), SongPlaylistsCommand{Songs: []domain.Song{song}})
+	require.NoError(t, err)
+
+	var ids []string
+	for _, p := range result.Playlists {
+		ids = append(ids, p.ID())
+	}
+	assert.Equal(t, []string{"day", "program"}, ids)
+}
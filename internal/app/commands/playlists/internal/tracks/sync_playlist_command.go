@@ -0,0 +1,118 @@
+package tracks
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/jbenzshawel/playlist-generator/internal/common/decorator"
+	"github.com/jbenzshawel/playlist-generator/internal/domain"
+)
+
+// PlaylistService reads and adds to a provider's playlists.
+type PlaylistService interface {
+	// GetTrackIDs returns the IDs of the tracks in a playlist.
+	GetTrackIDs(ctx context.Context, playlistID string) ([]string, error)
+	AddTracks(ctx context.Context, playlistID string, trackIDs []string) error
+}
+
+type SyncPlaylistCommand struct {
+	Playlist domain.Playlist
+	// Date is the day being synced. Only songs played since the playlist was last
+	// synced are added unless an earlier day is synced. When the date is empty
+	// every song played in the playlist's date range is added.
+	Date string
+}
+
+type SyncPlaylistCommandHandler decorator.CommandHandler[SyncPlaylistCommand]
+
+func NewSyncPlaylistCommand(
+	playlistType domain.PlaylistType,
+	playlistService PlaylistService,
+	repository domain.Repository,
+) SyncPlaylistCommandHandler {
+	return decorator.ApplyDBTransactionDecorator(
+		&syncPlaylistCommandHandler{
+			playlistType:       playlistType,
+			playlistService:    playlistService,
+			playlistRepository: repository.Playlist(),
+			trackRepository:    repository.Track(playlistType),
+		},
+		repository,
+	)
+}
+
+type syncPlaylistCommandHandler struct {
+	playlistType       domain.PlaylistType
+	playlistService    PlaylistService
+	playlistRepository domain.PlaylistRepository
+	trackRepository    domain.TrackRepository
+}
+
+func (c *syncPlaylistCommandHandler) Execute(ctx context.Context, cmd SyncPlaylistCommand) (any, error) {
+	startDate, err := cmd.Playlist.StartDate()
+	if err != nil {
+		return nil, err
+	}
+
+	// Only look at songs since the last sync unless an earlier date is being synced
+	lastDaySynced := cmd.Playlist.LastDaySynced()
+	if cmd.Date != "" && lastDaySynced != "" && cmd.Date >= lastDaySynced && lastDaySynced > startDate {
+		startDate = lastDaySynced
+	}
+
+	endDate, err := cmd.Playlist.EndDate()
+	if err != nil {
+		return nil, err
+	}
+
+	tracks, err := c.trackRepository.GetTracksPlayedInRange(ctx, cmd.Playlist.SourceType(), cmd.Playlist.ProgramName(), startDate, endDate)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(tracks) == 0 {
+		slog.Info("no new downloaded tracks to sync")
+	}
+
+	playlistTrackIDs, err := c.playlistService.GetTrackIDs(ctx, cmd.Playlist.ID())
+	if err != nil {
+		return nil, err
+	}
+
+	trackLookup := make(map[string]struct{}, len(playlistTrackIDs))
+	for _, trackID := range playlistTrackIDs {
+		trackLookup[trackID] = struct{}{}
+	}
+
+	trackIDs := make([]string, 0, len(tracks))
+	for _, track := range tracks {
+		if _, ok := trackLookup[track.TrackID()]; !ok {
+			trackIDs = append(trackIDs, track.TrackID())
+			trackLookup[track.TrackID()] = struct{}{}
+		}
+	}
+
+	if len(trackIDs) == 0 {
+		slog.Info("all downloaded tracks synced to playlist")
+	}
+
+	err = c.playlistService.AddTracks(ctx, cmd.Playlist.ID(), trackIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	// Set last date synced to yesterday since we want to pick up other songs from today
+	syncDate := time.Now().AddDate(0, 0, -1).Format(time.DateOnly)
+	err = c.playlistRepository.SetLastDaySynced(ctx, cmd.Playlist.ID(), syncDate)
+	if err != nil {
+		return nil, err
+	}
+
+	slog.Info("tracks sync complete",
+		slog.String("playlistType", c.playlistType.String()),
+		slog.Int("numTracks", len(trackIDs)),
+	)
+
+	return nil, nil
+}
@@ -1,16 +1,51 @@
 package playlists
 
 import (
+	"github.com/jbenzshawel/playlist-generator/internal/app/commands/playlists/applemusic"
 	"github.com/jbenzshawel/playlist-generator/internal/app/commands/playlists/spotify"
+	"github.com/jbenzshawel/playlist-generator/internal/app/commands/playlists/subsonic"
+	"github.com/jbenzshawel/playlist-generator/internal/app/commands/playlists/tidal"
 	"github.com/jbenzshawel/playlist-generator/internal/domain"
 )
 
 type Commands struct {
 	Spotify spotify.Commands
+	// AppleMusic is nil when Apple Music isn't configured
+	AppleMusic *applemusic.Commands
+	// Tidal is nil when Tidal isn't configured
+	Tidal *tidal.Commands
+	// Subsonic is nil when a Subsonic server isn't configured
+	Subsonic *subsonic.Commands
 }
 
-func NewCommands(client spotify.Client, repository domain.Repository) Commands {
-	return Commands{
-		Spotify: spotify.NewCommands(client, repository),
+// NewCommands returns the commands for every streaming provider. Apple Music, Tidal, and
+// Subsonic commands are only created when their clients aren't nil.
+func NewCommands(
+	client spotify.Client,
+	appleMusicClient applemusic.Client,
+	tidalClient tidal.Client,
+	subsonicClient subsonic.Client,
+	repository domain.Repository,
+	scoring domain.MatchScoring,
+) Commands {
+	c := Commands{
+		Spotify: spotify.NewCommands(client, repository, scoring),
 	}
+
+	if appleMusicClient != nil {
+		appleMusic := applemusic.NewCommands(appleMusicClient, repository, scoring)
+		c.AppleMusic = &appleMusic
+	}
+
+	if tidalClient != nil {
+		tidalCommands := tidal.NewCommands(tidalClient, repository, scoring)
+		c.Tidal = &tidalCommands
+	}
+
+	if subsonicClient != nil {
+		subsonicCommands := subsonic.NewCommands(subsonicClient, repository, scoring)
+		c.Subsonic = &subsonicCommands
+	}
+
+	return c
 }
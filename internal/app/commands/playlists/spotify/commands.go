@@ -11,19 +11,29 @@ type Client interface {
 
 type Commands struct {
 	CreatePlaylist       CreatePlaylistCommandHandler
+	MatchReviews         MatchReviewsCommandHandler
+	MatchSong            MatchSongCommandHandler
 	RandomTracksPlaylist RandomTracksPlaylistCommandHandler
+	ReviewMatch          ReviewMatchCommandHandler
 	SearchTracks         SearchTracksCommandHandler
+	SongPlaylists        SongPlaylistsCommandHandler
 	SyncPlaylist         SyncPlaylistCommandHandler
+	SyncRollingPlaylist  SyncRollingPlaylistCommandHandler
 }
 
-func NewCommands(client services.Client, repository domain.Repository) Commands {
+func NewCommands(client services.Client, repository domain.Repository, scoring domain.MatchScoring) Commands {
 	playlistService := services.NewPlaylistService(client)
-	searchService := services.NewSearchService(client)
+	searchService := services.NewSearchService(client, scoring)
 
 	return Commands{
 		CreatePlaylist:       NewCreatePlaylistCommand(playlistService, repository),
+		MatchReviews:         NewMatchReviewsCommand(repository),
+		MatchSong:            NewMatchSongCommand(repository),
 		RandomTracksPlaylist: NewRandomTracksPlaylistCommand(playlistService, repository),
+		ReviewMatch:          NewReviewMatchCommand(repository),
 		SearchTracks:         NewSearchTracksCommand(searchService, repository),
+		SongPlaylists:        NewSongPlaylistsCommand(repository),
 		SyncPlaylist:         NewSyncPlaylistCommand(playlistService, repository),
+		SyncRollingPlaylist:  NewSyncRollingPlaylistCommand(playlistService, repository),
 	}
 }
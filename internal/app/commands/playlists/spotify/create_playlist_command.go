@@ -7,13 +7,21 @@ import (
 	"time"
 
 	"github.com/jbenzshawel/playlist-generator/internal/app/commands/playlists/spotify/internal/services"
-	"github.com/jbenzshawel/playlist-generator/internal/common/dateformat"
 	"github.com/jbenzshawel/playlist-generator/internal/common/decorator"
 	"github.com/jbenzshawel/playlist-generator/internal/domain"
 )
 
 type CreatePlaylistCommand struct {
-	Date string
+	Date      string
+	DateScope domain.PlaylistDateScope
+	// RollingDays is the size of the window for the rolling date scope. Date is
+	// ignored for rolling playlists.
+	RollingDays int
+	SourceName  string
+	SourceType  domain.SourceType
+	// ProgramName optionally limits the playlist to songs played during a program.
+	// Program playlists are named after the program instead of the source.
+	ProgramName string
 }
 
 type CreatePlaylistCommandResult struct {
@@ -41,28 +49,38 @@ type createPlaylistCommand struct {
 }
 
 func (c *createPlaylistCommand) Execute(ctx context.Context, cmd CreatePlaylistCommand) (CreatePlaylistCommandResult, error) {
-	date, err := time.Parse(time.DateOnly, cmd.Date)
+	playlistDate, name, err := playlistDateAndName(cmd)
 	if err != nil {
-		return CreatePlaylistCommandResult{}, fmt.Errorf("invalid create playlist date: %w", err)
+		return CreatePlaylistCommandResult{}, err
 	}
 
-	playlistDate := date.Format(dateformat.YearMonth)
-
-	p, err := c.playlistRepository.GetPlaylistByDate(ctx, domain.SpotifyPlaylistType, playlistDate)
+	p, err := c.playlistRepository.GetPlaylistByDate(ctx, domain.SpotifyPlaylistType, cmd.SourceType, cmd.ProgramName, cmd.DateScope, playlistDate)
 	if err != nil {
 		return CreatePlaylistCommandResult{}, err
 	}
 
 	if !p.IsZero() {
 		slog.Info("existing spotify playlist found", slog.Any("playlist", p))
+
+		if cmd.DateScope == domain.RollingPlaylistDateScope {
+			p, err = c.updateRollingWindow(ctx, p, name, cmd.RollingDays)
+			if err != nil {
+				return CreatePlaylistCommandResult{}, err
+			}
+		}
+
 		return CreatePlaylistCommandResult{Playlist: p}, nil
 	}
 
-	p, err = c.playlistService.CreatePlaylist(ctx, fmt.Sprintf("Studio One %s", playlistDate), date)
+	p, err = c.playlistService.CreatePlaylist(ctx, name, playlistDate, cmd.DateScope, cmd.SourceType, cmd.ProgramName)
 	if err != nil {
 		return CreatePlaylistCommandResult{}, err
 	}
 
+	if cmd.DateScope == domain.RollingPlaylistDateScope {
+		p = p.WithRollingWindow(p.Name(), cmd.RollingDays)
+	}
+
 	err = c.playlistRepository.Insert(ctx, p)
 	if err != nil {
 		return CreatePlaylistCommandResult{}, err
@@ -72,3 +90,50 @@ func (c *createPlaylistCommand) Execute(ctx context.Context, cmd CreatePlaylistC
 
 	return CreatePlaylistCommandResult{Playlist: p}, nil
 }
+
+// updateRollingWindow renames an existing rolling playlist when the size of its window
+// changed, so the next sync rewrites the same playlist for the new window.
+func (c *createPlaylistCommand) updateRollingWindow(ctx context.Context, p domain.Playlist, name string, days int) (domain.Playlist, error) {
+	current, err := p.RollingDays()
+	if err == nil && current == days {
+		return p, nil
+	}
+
+	err = c.playlistService.RenamePlaylist(ctx, p.ID(), name)
+	if err != nil {
+		return domain.Playlist{}, err
+	}
+
+	err = c.playlistRepository.SetRollingWindow(ctx, p.ID(), name, days)
+	if err != nil {
+		return domain.Playlist{}, err
+	}
+
+	slog.Info("spotify rolling playlist window changed", slog.Any("playlist", p), slog.Int("days", days))
+
+	return p.WithRollingWindow(name, days), nil
+}
+
+func playlistDateAndName(cmd CreatePlaylistCommand) (string, string, error) {
+	namePrefix := cmd.SourceName
+	if cmd.ProgramName != "" {
+		namePrefix = cmd.ProgramName
+	}
+
+	if cmd.DateScope == domain.RollingPlaylistDateScope {
+		if cmd.RollingDays < 1 {
+			return "", "", fmt.Errorf("invalid rolling playlist days %d", cmd.RollingDays)
+		}
+
+		return domain.RollingPlaylistDate, fmt.Sprintf("%s Last %d Days", namePrefix, cmd.RollingDays), nil
+	}
+
+	date, err := time.Parse(time.DateOnly, cmd.Date)
+	if err != nil {
+		return "", "", fmt.Errorf("invalid create playlist date: %w", err)
+	}
+
+	playlistDate := cmd.DateScope.Format(date)
+
+	return playlistDate, fmt.Sprintf("%s %s", namePrefix, playlistDate), nil
+}
@@ -0,0 +1,76 @@
+package spotify
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/jbenzshawel/playlist-generator/internal/app/commands/playlists/spotify/internal/services"
+	"github.com/jbenzshawel/playlist-generator/internal/domain"
+	"github.com/jbenzshawel/playlist-generator/internal/infrastructure/storage"
+)
+
+func TestCreatePlaylistCommand_RollingWindowChanged(t *testing.T) {
+	repository := storage.NewRepository(storage.InitTestStorage(t))
+	playlistService := &testPlaylistService{}
+	handler := NewCreatePlaylistCommand(playlistService, repository)
+
+	execute := func(days int) domain.Playlist {
+		t.Helper()
+
+		res, err := handler.Execute(t.Context(), CreatePlaylistCommand{
+			DateScope:   domain.RollingPlaylistDateScope,
+			RollingDays: days,
+			SourceName:  "Studio One",
+			SourceType:  domain.StudioOneSourceType,
+		})
+		require.NoError(t, err)
+		return res.Playlist
+	}
+
+	created := execute(30)
+	assert.Equal(t, "Studio One Last 30 Days", created.Name())
+
+	changed := execute(7)
+	assert.Equal(t, created.ID(), changed.ID())
+	assert.Equal(t, "Studio One Last 7 Days", changed.Name())
+	days, err := changed.RollingDays()
+	require.NoError(t, err)
+	assert.Equal(t, 7, days)
+
+	unchanged := execute(7)
+	assert.Equal(t, changed.ID(), unchanged.ID())
+
+	assert.Equal(t, 1, playlistService.created)
+	assert.Equal(t, map[string]string{created.ID(): "Studio One Last 7 Days"}, playlistService.renamed)
+
+	inTransaction(t, repository, func() {
+		stored, err := repository.Playlist().GetPlaylistByID(t.Context(), created.ID())
+		require.NoError(t, err)
+		assert.Equal(t, "Studio One Last 7 Days", stored.Name())
+		assert.Equal(t, domain.RollingPlaylistDate, stored.Date())
+	})
+}
+
+// testPlaylistService creates and renames playlists without a Spotify account.
+type testPlaylistService struct {
+	services.PlaylistService
+
+	created int
+	renamed map[string]string
+}
+
+func (s *testPlaylistService) CreatePlaylist(_ context.Context, name, date string, dateScope domain.PlaylistDateScope, sourceType domain.SourceType, programName string) (domain.Playlist, error) {
+	s.created++
+	return domain.NewPlaylist("playlist1", "spotify:playlist:playlist1", name, date, dateScope, domain.SpotifyPlaylistType, sourceType, programName), nil
+}
+
+func (s *testPlaylistService) RenamePlaylist(_ context.Context, playlistID, name string) error {
+	if s.renamed == nil {
+		s.renamed = map[string]string{}
+	}
+	s.renamed[playlistID] = name
+	return nil
+}
@@ -2,20 +2,22 @@ package mutators
 
 import (
 	"context"
-	"time"
 
 	"github.com/jbenzshawel/playlist-generator/internal/app/commands/playlists/spotify/models"
-	"github.com/jbenzshawel/playlist-generator/internal/common/dateformat"
 	"github.com/jbenzshawel/playlist-generator/internal/domain"
 )
 
 type PlaylistCreator interface {
 	CurrentUser(ctx context.Context) (models.User, error)
 	CreatePlaylist(ctx context.Context, userID string, request models.CreatePlaylistRequest) (models.SimplePlaylist, error)
+	ChangePlaylistDetails(ctx context.Context, playlistID string, request models.ChangePlaylistDetailsRequest) error
 }
 
 type CreatePlaylistMutator interface {
-	CreatePlaylist(ctx context.Context, name string, date time.Time) (domain.Playlist, error)
+	// CreatePlaylist creates a playlist for a source and date. The date must be
+	// formatted for the date scope, see domain.PlaylistDateScope.Format.
+	CreatePlaylist(ctx context.Context, name, date string, dateScope domain.PlaylistDateScope, sourceType domain.SourceType, programName string) (domain.Playlist, error)
+	RenamePlaylist(ctx context.Context, playlistID, name string) error
 }
 
 func NewCreatePlaylistMutator(creator PlaylistCreator) CreatePlaylistMutator {
@@ -28,14 +30,12 @@ type createPlaylistMutator struct {
 	creator PlaylistCreator
 }
 
-func (c *createPlaylistMutator) CreatePlaylist(ctx context.Context, name string, date time.Time) (domain.Playlist, error) {
+func (c *createPlaylistMutator) CreatePlaylist(ctx context.Context, name, date string, dateScope domain.PlaylistDateScope, sourceType domain.SourceType, programName string) (domain.Playlist, error) {
 	u, err := c.creator.CurrentUser(ctx)
 	if err != nil {
 		return domain.Playlist{}, err
 	}
 
-	playlistDate := date.Format(dateformat.YearMonth)
-
 	spotifyPlaylist, err := c.creator.CreatePlaylist(ctx, u.ID, models.CreatePlaylistRequest{
 		Name: name,
 	})
@@ -47,10 +47,18 @@ func (c *createPlaylistMutator) CreatePlaylist(ctx context.Context, name string,
 		spotifyPlaylist.ID,
 		spotifyPlaylist.URI,
 		spotifyPlaylist.Name,
-		playlistDate,
+		date,
+		dateScope,
 		domain.SpotifyPlaylistType,
-		domain.StudioOneSourceType,
+		sourceType,
+		programName,
 	)
 
 	return p, nil
 }
+
+func (c *createPlaylistMutator) RenamePlaylist(ctx context.Context, playlistID, name string) error {
+	return c.creator.ChangePlaylistDetails(ctx, playlistID, models.ChangePlaylistDetailsRequest{
+		Name: name,
+	})
+}
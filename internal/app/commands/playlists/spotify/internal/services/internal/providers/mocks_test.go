@@ -144,6 +144,216 @@ func (_m *MockTrackSearcher) EXPECT() *MockTrackSearcher_Expecter {
 	return &MockTrackSearcher_Expecter{mock: &_m.Mock}
 }
 
+// GetAlbumTracks provides a mock function for the type MockTrackSearcher
+func (_mock *MockTrackSearcher) GetAlbumTracks(ctx context.Context, albumID string, limit int, offset int) (models.AlbumTrackPage, error) {
+	ret := _mock.Called(ctx, albumID, limit, offset)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetAlbumTracks")
+	}
+
+	var r0 models.AlbumTrackPage
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, int, int) (models.AlbumTrackPage, error)); ok {
+		return returnFunc(ctx, albumID, limit, offset)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, int, int) models.AlbumTrackPage); ok {
+		r0 = returnFunc(ctx, albumID, limit, offset)
+	} else {
+		r0 = ret.Get(0).(models.AlbumTrackPage)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, int, int) error); ok {
+		r1 = returnFunc(ctx, albumID, limit, offset)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockTrackSearcher_GetAlbumTracks_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetAlbumTracks'
+type MockTrackSearcher_GetAlbumTracks_Call struct {
+	*mock.Call
+}
+
+// GetAlbumTracks is a helper method to define mock.On call
+//   - ctx context.Context
+//   - albumID string
+//   - limit int
+//   - offset int
+func (_e *MockTrackSearcher_Expecter) GetAlbumTracks(ctx interface{}, albumID interface{}, limit interface{}, offset interface{}) *MockTrackSearcher_GetAlbumTracks_Call {
+	return &MockTrackSearcher_GetAlbumTracks_Call{Call: _e.mock.On("GetAlbumTracks", ctx, albumID, limit, offset)}
+}
+
+func (_c *MockTrackSearcher_GetAlbumTracks_Call) Run(run func(ctx context.Context, albumID string, limit int, offset int)) *MockTrackSearcher_GetAlbumTracks_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 int
+		if args[2] != nil {
+			arg2 = args[2].(int)
+		}
+		var arg3 int
+		if args[3] != nil {
+			arg3 = args[3].(int)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+		)
+	})
+	return _c
+}
+
+func (_c *MockTrackSearcher_GetAlbumTracks_Call) Return(albumTrackPage models.AlbumTrackPage, err error) *MockTrackSearcher_GetAlbumTracks_Call {
+	_c.Call.Return(albumTrackPage, err)
+	return _c
+}
+
+func (_c *MockTrackSearcher_GetAlbumTracks_Call) RunAndReturn(run func(ctx context.Context, albumID string, limit int, offset int) (models.AlbumTrackPage, error)) *MockTrackSearcher_GetAlbumTracks_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetTracks provides a mock function for the type MockTrackSearcher
+func (_mock *MockTrackSearcher) GetTracks(ctx context.Context, trackIDs []string) (models.TracksResponse, error) {
+	ret := _mock.Called(ctx, trackIDs)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetTracks")
+	}
+
+	var r0 models.TracksResponse
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, []string) (models.TracksResponse, error)); ok {
+		return returnFunc(ctx, trackIDs)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, []string) models.TracksResponse); ok {
+		r0 = returnFunc(ctx, trackIDs)
+	} else {
+		r0 = ret.Get(0).(models.TracksResponse)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, []string) error); ok {
+		r1 = returnFunc(ctx, trackIDs)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockTrackSearcher_GetTracks_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetTracks'
+type MockTrackSearcher_GetTracks_Call struct {
+	*mock.Call
+}
+
+// GetTracks is a helper method to define mock.On call
+//   - ctx context.Context
+//   - trackIDs []string
+func (_e *MockTrackSearcher_Expecter) GetTracks(ctx interface{}, trackIDs interface{}) *MockTrackSearcher_GetTracks_Call {
+	return &MockTrackSearcher_GetTracks_Call{Call: _e.mock.On("GetTracks", ctx, trackIDs)}
+}
+
+func (_c *MockTrackSearcher_GetTracks_Call) Run(run func(ctx context.Context, trackIDs []string)) *MockTrackSearcher_GetTracks_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 []string
+		if args[1] != nil {
+			arg1 = args[1].([]string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockTrackSearcher_GetTracks_Call) Return(tracksResponse models.TracksResponse, err error) *MockTrackSearcher_GetTracks_Call {
+	_c.Call.Return(tracksResponse, err)
+	return _c
+}
+
+func (_c *MockTrackSearcher_GetTracks_Call) RunAndReturn(run func(ctx context.Context, trackIDs []string) (models.TracksResponse, error)) *MockTrackSearcher_GetTracks_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SearchAlbumByUPC provides a mock function for the type MockTrackSearcher
+func (_mock *MockTrackSearcher) SearchAlbumByUPC(ctx context.Context, upc string) (models.SearchAlbumResponse, error) {
+	ret := _mock.Called(ctx, upc)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SearchAlbumByUPC")
+	}
+
+	var r0 models.SearchAlbumResponse
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) (models.SearchAlbumResponse, error)); ok {
+		return returnFunc(ctx, upc)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) models.SearchAlbumResponse); ok {
+		r0 = returnFunc(ctx, upc)
+	} else {
+		r0 = ret.Get(0).(models.SearchAlbumResponse)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = returnFunc(ctx, upc)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockTrackSearcher_SearchAlbumByUPC_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SearchAlbumByUPC'
+type MockTrackSearcher_SearchAlbumByUPC_Call struct {
+	*mock.Call
+}
+
+// SearchAlbumByUPC is a helper method to define mock.On call
+//   - ctx context.Context
+//   - upc string
+func (_e *MockTrackSearcher_Expecter) SearchAlbumByUPC(ctx interface{}, upc interface{}) *MockTrackSearcher_SearchAlbumByUPC_Call {
+	return &MockTrackSearcher_SearchAlbumByUPC_Call{Call: _e.mock.On("SearchAlbumByUPC", ctx, upc)}
+}
+
+func (_c *MockTrackSearcher_SearchAlbumByUPC_Call) Run(run func(ctx context.Context, upc string)) *MockTrackSearcher_SearchAlbumByUPC_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockTrackSearcher_SearchAlbumByUPC_Call) Return(searchAlbumResponse models.SearchAlbumResponse, err error) *MockTrackSearcher_SearchAlbumByUPC_Call {
+	_c.Call.Return(searchAlbumResponse, err)
+	return _c
+}
+
+func (_c *MockTrackSearcher_SearchAlbumByUPC_Call) RunAndReturn(run func(ctx context.Context, upc string) (models.SearchAlbumResponse, error)) *MockTrackSearcher_SearchAlbumByUPC_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // SearchTrack provides a mock function for the type MockTrackSearcher
 func (_mock *MockTrackSearcher) SearchTrack(ctx context.Context, artist string, track string, album string) (models.SearchTrackResponse, error) {
 	ret := _mock.Called(ctx, artist, track, album)
@@ -221,3 +431,69 @@ func (_c *MockTrackSearcher_SearchTrack_Call) RunAndReturn(run func(ctx context.
 	_c.Call.Return(run)
 	return _c
 }
+
+// SearchTrackByISRC provides a mock function for the type MockTrackSearcher
+func (_mock *MockTrackSearcher) SearchTrackByISRC(ctx context.Context, isrc string) (models.SearchTrackResponse, error) {
+	ret := _mock.Called(ctx, isrc)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SearchTrackByISRC")
+	}
+
+	var r0 models.SearchTrackResponse
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) (models.SearchTrackResponse, error)); ok {
+		return returnFunc(ctx, isrc)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) models.SearchTrackResponse); ok {
+		r0 = returnFunc(ctx, isrc)
+	} else {
+		r0 = ret.Get(0).(models.SearchTrackResponse)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = returnFunc(ctx, isrc)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockTrackSearcher_SearchTrackByISRC_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SearchTrackByISRC'
+type MockTrackSearcher_SearchTrackByISRC_Call struct {
+	*mock.Call
+}
+
+// SearchTrackByISRC is a helper method to define mock.On call
+//   - ctx context.Context
+//   - isrc string
+func (_e *MockTrackSearcher_Expecter) SearchTrackByISRC(ctx interface{}, isrc interface{}) *MockTrackSearcher_SearchTrackByISRC_Call {
+	return &MockTrackSearcher_SearchTrackByISRC_Call{Call: _e.mock.On("SearchTrackByISRC", ctx, isrc)}
+}
+
+func (_c *MockTrackSearcher_SearchTrackByISRC_Call) Run(run func(ctx context.Context, isrc string)) *MockTrackSearcher_SearchTrackByISRC_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockTrackSearcher_SearchTrackByISRC_Call) Return(searchTrackResponse models.SearchTrackResponse, err error) *MockTrackSearcher_SearchTrackByISRC_Call {
+	_c.Call.Return(searchTrackResponse, err)
+	return _c
+}
+
+func (_c *MockTrackSearcher_SearchTrackByISRC_Call) RunAndReturn(run func(ctx context.Context, isrc string) (models.SearchTrackResponse, error)) *MockTrackSearcher_SearchTrackByISRC_Call {
+	_c.Call.Return(run)
+	return _c
+}
@@ -4,152 +4,236 @@ import (
 	"context"
 	"errors"
 	"log/slog"
-	"slices"
-	"strings"
 
+	"github.com/jbenzshawel/playlist-generator/internal/app/commands/playlists/internal/matching"
 	"github.com/jbenzshawel/playlist-generator/internal/app/commands/playlists/spotify/models"
-	"github.com/jbenzshawel/playlist-generator/internal/common/compare"
 	"github.com/jbenzshawel/playlist-generator/internal/domain"
 )
 
-const minMatchPercent = 70.0
-
-var (
-	errTrackNotFound       = errors.New("track not found")
-	errMatchBelowThreshold = errors.New("match below threshold")
-)
+// maxReviewCandidates is the number of search results kept for matches that need review
+const maxReviewCandidates = 5
 
 type TrackSearcher interface {
 	SearchTrack(ctx context.Context, artist, track, album string) (models.SearchTrackResponse, error)
+	SearchTrackByISRC(ctx context.Context, isrc string) (models.SearchTrackResponse, error)
+	SearchAlbumByUPC(ctx context.Context, upc string) (models.SearchAlbumResponse, error)
+	GetAlbumTracks(ctx context.Context, albumID string, limit, offset int) (models.AlbumTrackPage, error)
+	GetTracks(ctx context.Context, trackIDs []string) (models.TracksResponse, error)
 }
 
 type SearchTrackProvider interface {
-	SearchTrack(ctx context.Context, song domain.Song) (domain.SpotifyTrack, error)
+	// SearchTrack returns the track that best matches a song. When the match needs
+	// review the best scoring search results are returned as candidates. The ISRC is from
+	// the song's match on another provider, or empty when the song hasn't been matched.
+	SearchTrack(ctx context.Context, song domain.Song, isrc string) (domain.Track, []domain.MatchCandidate, error)
 }
 
-func NewSearchTrackProvider(s TrackSearcher) SearchTrackProvider {
+func NewSearchTrackProvider(s TrackSearcher, scoring domain.MatchScoring) SearchTrackProvider {
 	return &searchTrackProvider{
 		searcher: s,
+		scoring:  scoring,
 	}
 }
 
 type searchTrackProvider struct {
 	searcher TrackSearcher
+	scoring  domain.MatchScoring
 }
 
-func (s *searchTrackProvider) SearchTrack(ctx context.Context, song domain.Song) (domain.SpotifyTrack, error) {
+func (s *searchTrackProvider) SearchTrack(ctx context.Context, song domain.Song, isrc string) (domain.Track, []domain.MatchCandidate, error) {
+	// Identifiers are more reliable than names, fuzzy search is only used as a fallback
+	track, err := s.searchISRC(ctx, song, isrc)
+	if !errors.Is(err, matching.ErrTrackNotFound) {
+		return track, nil, err
+	}
+
+	track, candidates, err := s.searchUPC(ctx, song)
+	if !errors.Is(err, matching.ErrTrackNotFound) {
+		return track, candidates, err
+	}
+
 	// The album is sometimes incorrect in studio one data, let's leave it off for now
 	resp, err := s.searcher.SearchTrack(ctx, song.Artist(), song.Track(), "")
 	if err != nil {
-		return domain.SpotifyTrack{}, err
+		return domain.Track{}, nil, err
 	}
 
 	if resp.Tracks.Total > 0 {
-		return findSongTrackMatch(resp.Tracks, song)
+		return findSongTrackMatch(s.scoring, resp.Tracks, song)
 	}
 
-	return domain.SpotifyTrack{}, errTrackNotFound
+	return domain.Track{}, nil, matching.ErrTrackNotFound
 }
 
-type match struct {
-	item               models.SimpleTrack
-	track              domain.SpotifyTrack
-	artistPercentMatch float64
-	trackPercentMatch  float64
-	albumPercentMatch  float64
-}
+// searchISRC returns the track of the recording with the ISRC on the album closest to
+// the song's album.
+func (s *searchTrackProvider) searchISRC(ctx context.Context, song domain.Song, isrc string) (domain.Track, error) {
+	if isrc == "" {
+		return domain.Track{}, matching.ErrTrackNotFound
+	}
+
+	resp, err := s.searcher.SearchTrackByISRC(ctx, isrc)
+	if err != nil {
+		return domain.Track{}, err
+	}
+
+	candidates := make([]matching.Candidate, 0, len(resp.Tracks.Items))
+	for _, t := range resp.Tracks.Items {
+		candidates = append(candidates, newCandidate(t))
+	}
+
+	if len(candidates) == 0 {
+		return domain.Track{}, matching.ErrTrackNotFound
+	}
 
-func (m match) isExactMatch() bool {
-	return m.trackPercentMatch == 100 &&
-		m.artistPercentMatch == 100 &&
-		m.albumPercentMatch == 100
+	best := matching.RankMatches(s.scoring, candidates, song)[0]
+
+	slog.Debug("isrc match track found", slog.Any("match", best.Candidate))
+
+	return best.Track(domain.SpotifyPlaylistType, song, domain.ISRCMatchMethod), nil
 }
 
-func (m match) weightedAverage() float64 {
+// searchUPC searches for the album with the song's UPC and returns the album track
+// with the closest matching name. A UPC can be shared by a compilation, so the track's
+// artist must match too. The match needs review when its confidence is too low.
+func (s *searchTrackProvider) searchUPC(ctx context.Context, song domain.Song) (domain.Track, []domain.MatchCandidate, error) {
+	if song.UPC() == "" {
+		return domain.Track{}, nil, matching.ErrTrackNotFound
+	}
+
+	resp, err := s.searcher.SearchAlbumByUPC(ctx, song.UPC())
+	if err != nil {
+		return domain.Track{}, nil, err
+	}
+
 	var (
-		weightArtist = 0.35
-		weightTrack  = 0.40
-		weightAlbum  = 0.25
+		bestID      string
+		bestPercent float64
 	)
+	for _, album := range resp.Albums.Items {
+		tracks, err := s.albumTracks(ctx, album.ID)
+		if err != nil {
+			return domain.Track{}, nil, err
+		}
 
-	return m.artistPercentMatch*weightArtist + m.trackPercentMatch*weightTrack + m.albumPercentMatch*weightAlbum
-}
+		for _, t := range tracks {
+			percent := matching.TitleSimilarity(s.scoring, song.Track(), t.Name)
+			if percent > bestPercent {
+				bestID, bestPercent = t.ID, percent
+			}
+		}
+	}
 
-func findSongTrackMatch(tracks models.TrackCollection, song domain.Song) (domain.SpotifyTrack, error) {
-	slog.Debug("spotify search tracks found", slog.Int("count", tracks.Total))
+	if bestPercent < matching.MinMatchPercent {
+		return domain.Track{}, nil, matching.ErrTrackNotFound
+	}
 
-	if tracks.Total == 1 {
-		t := tracks.Items[0]
-		slog.Debug("match track found", slog.Any("match", t))
+	// album tracks don't include their album or ISRC
+	full, err := s.searcher.GetTracks(ctx, []string{bestID})
+	if err != nil {
+		return domain.Track{}, nil, err
+	}
+
+	if len(full.Tracks) == 0 || full.Tracks[0] == nil {
+		return domain.Track{}, nil, matching.ErrTrackNotFound
+	}
 
-		return domain.NewSpotifyTrack(song.ID(), t.ID, t.URI), nil
+	m := matching.NewMatch(s.scoring, newCandidate(*full.Tracks[0]), song)
+	if m.ArtistPercentMatch() < matching.MinMatchPercent {
+		slog.Debug("upc match artist mismatch", slog.Any("match", m.Candidate))
+		return domain.Track{}, nil, matching.ErrTrackNotFound
 	}
 
-	var matches []match
+	slog.Debug("upc match track found", slog.Any("match", m.Candidate))
 
-	for _, t := range tracks.Items {
-		m := match{
-			item:               t,
-			track:              domain.NewSpotifyTrack(song.ID(), t.ID, t.URI),
-			trackPercentMatch:  stringSimilarity(song.Track(), t.Name),
-			artistPercentMatch: percentArtistMatch(t.Artists, song.Artist()),
-			albumPercentMatch:  percentAlbumMatch(t.Album, song),
+	track := m.Track(domain.SpotifyPlaylistType, song, domain.UPCMatchMethod)
+	if s.scoring.NeedsReview(track) {
+		return track, []domain.MatchCandidate{domain.NewMatchCandidate(0, track)}, nil
+	}
+
+	return track, nil, nil
+}
+
+func (s *searchTrackProvider) albumTracks(ctx context.Context, albumID string) ([]models.SimpleTrack, error) {
+	var tracks []models.SimpleTrack
+	for offset := 0; ; offset += maxPageSize {
+		page, err := s.searcher.GetAlbumTracks(ctx, albumID, maxPageSize, offset)
+		if err != nil {
+			return nil, err
 		}
 
-		if m.isExactMatch() {
-			return m.track, nil
+		tracks = append(tracks, page.Items...)
+
+		if len(page.Items) == 0 || offset+maxPageSize >= page.Total {
+			return tracks, nil
 		}
+	}
+}
 
-		matches = append(matches, m)
+func findSongTrackMatch(scoring domain.MatchScoring, tracks models.TrackCollection, song domain.Song) (domain.Track, []domain.MatchCandidate, error) {
+	slog.Debug("spotify search tracks found", slog.Int("count", tracks.Total))
+
+	candidates := make([]matching.Candidate, 0, len(tracks.Items))
+	for _, t := range tracks.Items {
+		candidates = append(candidates, newCandidate(t))
 	}
 
-	slices.SortFunc(matches, func(a, b match) int {
-		if a.weightedAverage() < b.weightedAverage() {
-			return 1
-		}
-		if a.weightedAverage() > b.weightedAverage() {
-			return -1
+	matches := matching.RankMatches(scoring, candidates, song)
+	if len(matches) == 0 {
+		return domain.Track{}, nil, matching.ErrTrackNotFound
+	}
+
+	if tracks.Total == 1 {
+		slog.Debug("match track found", slog.Any("match", matches[0].Candidate))
+
+		track := matches[0].Track(domain.SpotifyPlaylistType, song, domain.SingleResultMatchMethod)
+		if scoring.NeedsReview(track) {
+			return track, []domain.MatchCandidate{domain.NewMatchCandidate(0, track)}, nil
 		}
-		return 0
-	})
+		return track, nil, nil
+	}
+
+	if matches[0].IsExactMatch() {
+		return matches[0].Track(domain.SpotifyPlaylistType, song, domain.ExactMatchMethod), nil, nil
+	}
 
-	if len(matches) == 0 || matches[0].weightedAverage() < minMatchPercent {
-		return domain.SpotifyTrack{}, errMatchBelowThreshold
+	if matches[0].WeightedAverage() < matching.MinMatchPercent {
+		return domain.Track{}, nil, matching.ErrMatchBelowThreshold
 	}
 
 	slog.Debug("partial match track found",
-		slog.Any("percent", matches[0].weightedAverage()),
-		slog.Any("match", matches[0].item),
+		slog.Any("percent", matches[0].WeightedAverage()),
+		slog.Any("match", matches[0].Candidate),
 	)
 
-	return matches[0].track, nil
-}
+	track := matches[0].Track(domain.SpotifyPlaylistType, song, domain.FuzzyMatchMethod)
+	if !scoring.NeedsReview(track) {
+		return track, nil, nil
+	}
 
-func percentAlbumMatch(trackAlbum models.Album, song domain.Song) float64 {
-	if trackAlbum.AlbumType == models.SingleAlbumType && strings.HasPrefix(song.Album(), song.Track()) {
-		return 100.0
+	var candidateTracks []domain.MatchCandidate
+	for rank, m := range matches[:min(len(matches), maxReviewCandidates)] {
+		candidateTracks = append(candidateTracks, domain.NewMatchCandidate(rank, m.Track(domain.SpotifyPlaylistType, song, domain.FuzzyMatchMethod)))
 	}
 
-	return stringSimilarity(song.Album(), trackAlbum.Name)
+	slog.Debug("match needs review", slog.Int("numCandidates", len(candidateTracks)))
+
+	return track, candidateTracks, nil
 }
 
-func percentArtistMatch(artists []models.Artist, artist string) float64 {
-	if len(artists) == 1 {
-		return stringSimilarity(artist, artists[0].Name)
+func newCandidate(t models.SimpleTrack) matching.Candidate {
+	var artists []string
+	for _, a := range t.Artists {
+		artists = append(artists, a.Name)
 	}
 
-	// TODO: still need to figure out how source handles multiple artists
-	// for now just pick the highest?
-	var matches []float64
-	for _, a := range artists {
-		matches = append(matches, stringSimilarity(artist, a.Name))
+	return matching.Candidate{
+		ID:          t.ID,
+		URI:         t.URI,
+		Artists:     artists,
+		Title:       t.Name,
+		Album:       t.Album.Name,
+		SingleAlbum: t.Album.AlbumType == models.SingleAlbumType,
+		ISRC:        t.ExternalIDs.ISRC,
 	}
-
-	slices.Sort(matches)
-
-	return matches[len(matches)-1]
-}
-
-func stringSimilarity(s1, s2 string) float64 {
-	return compare.StringSimilarity(strings.ToLower(s1), strings.ToLower(s2))
 }
@@ -2,12 +2,15 @@ package providers
 
 import (
 	"context"
+	"slices"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
+	"github.com/jbenzshawel/playlist-generator/internal/app/commands/playlists/internal/matching"
 	"github.com/jbenzshawel/playlist-generator/internal/app/commands/playlists/spotify/models"
+	"github.com/jbenzshawel/playlist-generator/internal/common/compare"
 	"github.com/jbenzshawel/playlist-generator/internal/domain"
 )
 
@@ -28,11 +31,14 @@ func TestSearchTrackProvider_SearchTrack(t *testing.T) {
 	songSingle, err := domain.NewSong(artist, track, albumSingle, "")
 	require.NoError(t, err)
 
+	songLive, err := domain.NewSong("Cake feat. Gus Seyffert", "Never There (Live at the Fillmore)", album, "")
+	require.NoError(t, err)
+
 	testCases := []struct {
 		name          string
 		song          domain.Song
 		searchResults models.SearchTrackResponse
-		expectedTrack domain.SpotifyTrack
+		expectedTrack domain.Track
 		expectedErr   error
 	}{
 		{
@@ -59,7 +65,7 @@ func TestSearchTrackProvider_SearchTrack(t *testing.T) {
 					},
 				},
 			},
-			expectedTrack: domain.NewSpotifyTrack(song.ID(), trackID, uri),
+			expectedTrack: domain.NewTrack(domain.SpotifyPlaylistType, song.ID(), trackID, uri, domain.SingleResultMatchMethod, 100, artist, track, album, ""),
 		},
 		{
 			name: "multiple partial matches",
@@ -113,7 +119,7 @@ func TestSearchTrackProvider_SearchTrack(t *testing.T) {
 					},
 				},
 			},
-			expectedTrack: domain.NewSpotifyTrack(song.ID(), trackID, uri),
+			expectedTrack: domain.NewTrack(domain.SpotifyPlaylistType, song.ID(), trackID, uri, domain.FuzzyMatchMethod, 95, "CAKE", track, "Prolonging Magic", ""),
 		},
 		{
 			name: "single album type",
@@ -153,7 +159,47 @@ func TestSearchTrackProvider_SearchTrack(t *testing.T) {
 					},
 				},
 			},
-			expectedTrack: domain.NewSpotifyTrack(songSingle.ID(), trackID, uri),
+			expectedTrack: domain.NewTrack(domain.SpotifyPlaylistType, songSingle.ID(), trackID, uri, domain.ExactMatchMethod, 100, artist, track, "single", ""),
+		},
+		{
+			name: "normalized names",
+			song: songLive,
+			searchResults: models.SearchTrackResponse{
+				Tracks: models.TrackCollection{
+					Total: 2,
+					Items: []models.SimpleTrack{
+						{
+							Album: models.Album{
+								AlbumType: models.AlbumAlbumType,
+								Name:      "Comfort Eagle",
+							},
+							Artists: []models.Artist{
+								{
+									Name: artist,
+								},
+							},
+							Name: "Long Line of Cars",
+							ID:   "no match",
+							URI:  "no match",
+						},
+						{
+							Album: models.Album{
+								AlbumType: models.AlbumAlbumType,
+								Name:      "Prolonging the Magic (Deluxe Edition)",
+							},
+							Artists: []models.Artist{
+								{
+									Name: "CAKE",
+								},
+							},
+							Name: "Never There - Live",
+							ID:   trackID,
+							URI:  uri,
+						},
+					},
+				},
+			},
+			expectedTrack: domain.NewTrack(domain.SpotifyPlaylistType, songLive.ID(), trackID, uri, domain.ExactMatchMethod, 100, "CAKE", "Never There - Live", "Prolonging the Magic (Deluxe Edition)", ""),
 		},
 		{
 			name: "match at min threshold ",
@@ -193,7 +239,7 @@ func TestSearchTrackProvider_SearchTrack(t *testing.T) {
 					},
 				},
 			},
-			expectedTrack: domain.NewSpotifyTrack(song.ID(), trackID, uri),
+			expectedTrack: domain.NewTrack(domain.SpotifyPlaylistType, song.ID(), trackID, uri, domain.FuzzyMatchMethod, 70, "cak", track, "mag", ""),
 		},
 		{
 			name: "match below min threshold ",
@@ -233,7 +279,7 @@ func TestSearchTrackProvider_SearchTrack(t *testing.T) {
 					},
 				},
 			},
-			expectedErr: errMatchBelowThreshold,
+			expectedErr: matching.ErrMatchBelowThreshold,
 		},
 	}
 	for _, tc := range testCases {
@@ -247,9 +293,438 @@ func TestSearchTrackProvider_SearchTrack(t *testing.T) {
 				searcher: searcher,
 			}
 
-			actualTrack, err := provider.SearchTrack(ctx, tc.song)
+			actualTrack, candidates, err := provider.SearchTrack(ctx, tc.song, "")
 			assert.ErrorIs(t, err, tc.expectedErr)
-			assert.Equal(t, tc.expectedTrack, actualTrack)
+			assertTrack(t, tc.expectedTrack, actualTrack)
+			assert.Empty(t, candidates)
+		})
+	}
+}
+
+func TestSearchTrackProvider_SearchTrack_UPC(t *testing.T) {
+	const (
+		artist = "Cake"
+		track  = "Never There"
+		album  = "Prolonging The Magic"
+		upc    = "008811115621"
+		isrc   = "USCA29800388"
+
+		trackID = "7aKWgpecgLEqisWcXPElDl"
+		uri     = "spotify:track:7aKWgpecgLEqisWcXPElDl"
+	)
+
+	song, err := domain.NewSong(artist, track, album, upc)
+	require.NoError(t, err)
+
+	fuzzyResults := models.SearchTrackResponse{
+		Tracks: models.TrackCollection{
+			Total: 1,
+			Items: []models.SimpleTrack{
+				{
+					Artists: []models.Artist{
+						{
+							Name: artist,
+						},
+					},
+					Name: track,
+					ID:   "fuzzy",
+					URI:  "fuzzy",
+				},
+			},
+		},
+	}
+
+	upcAlbum := models.Album{ID: "1GbbnFrgsVPqmlXSQxtSIW", Name: album, AlbumType: models.AlbumAlbumType}
+	upcResults := models.SearchAlbumResponse{
+		Albums: models.AlbumCollection{
+			Total: 1,
+			Items: []models.Album{upcAlbum},
+		},
+	}
+
+	fullTrack := models.SimpleTrack{
+		ID:          trackID,
+		URI:         uri,
+		Name:        track,
+		Album:       upcAlbum,
+		Artists:     []models.Artist{{Name: artist}},
+		ExternalIDs: models.ExternalIDs{ISRC: isrc},
+	}
+
+	testCases := []struct {
+		name          string
+		upcResults    models.SearchAlbumResponse
+		albumPages    []models.AlbumTrackPage
+		fullTracks    []*models.SimpleTrack
+		reviewBelow   float64
+		expectFuzzy   bool
+		expectReview  bool
+		expectedTrack domain.Track
+	}{
+		{
+			name:       "upc match takes priority over text search",
+			upcResults: upcResults,
+			albumPages: []models.AlbumTrackPage{
+				{
+					Total: 2,
+					Items: []models.SimpleTrack{
+						{
+							Artists: []models.Artist{{Name: artist}},
+							Name:    "Satan Is My Motor",
+							ID:      "other",
+							URI:     "other",
+						},
+						{
+							Artists: []models.Artist{{Name: artist}},
+							Name:    track,
+							ID:      trackID,
+							URI:     uri,
+						},
+					},
+				},
+			},
+			fullTracks:    []*models.SimpleTrack{&fullTrack},
+			expectedTrack: domain.NewTrack(domain.SpotifyPlaylistType, song.ID(), trackID, uri, domain.UPCMatchMethod, 100, artist, track, album, isrc),
+		},
+		{
+			name:       "upc match on a later album page",
+			upcResults: upcResults,
+			albumPages: []models.AlbumTrackPage{
+				{
+					Total: maxPageSize + 1,
+					Items: slices.Repeat([]models.SimpleTrack{{Name: "Satan Is My Motor", ID: "other", URI: "other"}}, maxPageSize),
+				},
+				{
+					Total: maxPageSize + 1,
+					Items: []models.SimpleTrack{
+						{
+							Name: track,
+							ID:   trackID,
+							URI:  uri,
+						},
+					},
+				},
+			},
+			fullTracks:    []*models.SimpleTrack{&fullTrack},
+			expectedTrack: domain.NewTrack(domain.SpotifyPlaylistType, song.ID(), trackID, uri, domain.UPCMatchMethod, 100, artist, track, album, isrc),
+		},
+		{
+			name:       "track name mismatch falls back to text search",
+			upcResults: upcResults,
+			albumPages: []models.AlbumTrackPage{
+				{
+					Total: 1,
+					Items: []models.SimpleTrack{
+						{
+							Name: "Satan Is My Motor",
+							ID:   trackID,
+							URI:  uri,
+						},
+					},
+				},
+			},
+			expectFuzzy:   true,
+			expectedTrack: domain.NewTrack(domain.SpotifyPlaylistType, song.ID(), "fuzzy", "fuzzy", domain.SingleResultMatchMethod, 75, artist, track, "", ""),
+		},
+		{
+			name:       "artist mismatch falls back to text search",
+			upcResults: upcResults,
+			albumPages: []models.AlbumTrackPage{
+				{
+					Total: 1,
+					Items: []models.SimpleTrack{{Name: track, ID: trackID, URI: uri}},
+				},
+			},
+			fullTracks: []*models.SimpleTrack{{
+				ID:      trackID,
+				URI:     uri,
+				Name:    track,
+				Album:   upcAlbum,
+				Artists: []models.Artist{{Name: "Weezer"}},
+			}},
+			expectFuzzy:   true,
+			expectedTrack: domain.NewTrack(domain.SpotifyPlaylistType, song.ID(), "fuzzy", "fuzzy", domain.SingleResultMatchMethod, 75, artist, track, "", ""),
+		},
+		{
+			name:       "missing full track falls back to text search",
+			upcResults: upcResults,
+			albumPages: []models.AlbumTrackPage{
+				{
+					Total: 1,
+					Items: []models.SimpleTrack{{Name: track, ID: trackID, URI: uri}},
+				},
+			},
+			fullTracks:    []*models.SimpleTrack{nil},
+			expectFuzzy:   true,
+			expectedTrack: domain.NewTrack(domain.SpotifyPlaylistType, song.ID(), "fuzzy", "fuzzy", domain.SingleResultMatchMethod, 75, artist, track, "", ""),
+		},
+		{
+			name:       "low confidence upc match needs review",
+			upcResults: upcResults,
+			albumPages: []models.AlbumTrackPage{
+				{
+					Total: 1,
+					Items: []models.SimpleTrack{{Name: track, ID: trackID, URI: uri}},
+				},
+			},
+			fullTracks: []*models.SimpleTrack{{
+				ID:      trackID,
+				URI:     uri,
+				Name:    track,
+				Album:   models.Album{Name: "Greatest Hits"},
+				Artists: []models.Artist{{Name: artist}},
+			}},
+			reviewBelow:   95,
+			expectReview:  true,
+			expectedTrack: domain.NewTrack(domain.SpotifyPlaylistType, song.ID(), trackID, uri, domain.UPCMatchMethod, 78.75, artist, track, "Greatest Hits", ""),
+		},
+		{
+			name:          "no upc results falls back to text search",
+			expectFuzzy:   true,
+			expectedTrack: domain.NewTrack(domain.SpotifyPlaylistType, song.ID(), "fuzzy", "fuzzy", domain.SingleResultMatchMethod, 75, artist, track, "", ""),
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := context.Background()
+
+			searcher := NewMockTrackSearcher(t)
+			searcher.EXPECT().SearchAlbumByUPC(ctx, upc).Return(tc.upcResults, nil)
+			for idx, page := range tc.albumPages {
+				searcher.EXPECT().GetAlbumTracks(ctx, upcAlbum.ID, maxPageSize, idx*maxPageSize).Return(page, nil)
+			}
+			if tc.fullTracks != nil {
+				searcher.EXPECT().GetTracks(ctx, []string{trackID}).Return(models.TracksResponse{Tracks: tc.fullTracks}, nil)
+			}
+			if tc.expectFuzzy {
+				searcher.EXPECT().SearchTrack(ctx, artist, track, "").Return(fuzzyResults, nil)
+			}
+
+			scoring, err := domain.NewMatchScoring("", 0, 0, 0, tc.reviewBelow)
+			require.NoError(t, err)
+
+			provider := searchTrackProvider{
+				searcher: searcher,
+				scoring:  scoring,
+			}
+
+			actualTrack, candidates, err := provider.SearchTrack(ctx, song, "")
+			require.NoError(t, err)
+			assertTrack(t, tc.expectedTrack, actualTrack)
+			if tc.expectReview {
+				require.Len(t, candidates, 1)
+				assert.Equal(t, trackID, candidates[0].TrackID())
+				assert.Equal(t, domain.UPCMatchMethod, candidates[0].MatchMethod())
+			} else {
+				assert.Empty(t, candidates)
+			}
+		})
+	}
+}
+
+func TestSearchTrackProvider_SearchTrack_ISRC(t *testing.T) {
+	const isrc = "USCA29800388"
+
+	song, err := domain.NewSong("Cake", "Never There", "Prolonging The Magic", "008811115621")
+	require.NoError(t, err)
+
+	t.Run("isrc match takes priority over upc and text search", func(t *testing.T) {
+		ctx := context.Background()
+
+		searcher := NewMockTrackSearcher(t)
+		searcher.EXPECT().SearchTrackByISRC(ctx, isrc).Return(models.SearchTrackResponse{
+			Tracks: models.TrackCollection{
+				Total: 2,
+				Items: []models.SimpleTrack{
+					{
+						ID:          "compilation",
+						URI:         "spotify:track:compilation",
+						Name:        "Never There",
+						Album:       models.Album{Name: "90s Alternative"},
+						Artists:     []models.Artist{{Name: "CAKE"}},
+						ExternalIDs: models.ExternalIDs{ISRC: isrc},
+					},
+					{
+						ID:          "album",
+						URI:         "spotify:track:album",
+						Name:        "Never There",
+						Album:       models.Album{Name: "Prolonging The Magic"},
+						Artists:     []models.Artist{{Name: "CAKE"}},
+						ExternalIDs: models.ExternalIDs{ISRC: isrc},
+					},
+				},
+			},
+		}, nil)
+
+		provider := searchTrackProvider{searcher: searcher}
+
+		actualTrack, candidates, err := provider.SearchTrack(ctx, song, isrc)
+		require.NoError(t, err)
+		assert.Empty(t, candidates)
+		assertTrack(t, domain.NewTrack(domain.SpotifyPlaylistType, song.ID(), "album", "spotify:track:album", domain.ISRCMatchMethod, 100, "CAKE", "Never There", "Prolonging The Magic", isrc), actualTrack)
+	})
+
+	t.Run("no isrc results falls back to upc search", func(t *testing.T) {
+		ctx := context.Background()
+
+		searcher := NewMockTrackSearcher(t)
+		searcher.EXPECT().SearchTrackByISRC(ctx, isrc).Return(models.SearchTrackResponse{}, nil)
+		searcher.EXPECT().SearchAlbumByUPC(ctx, song.UPC()).Return(models.SearchAlbumResponse{}, nil)
+		searcher.EXPECT().SearchTrack(ctx, song.Artist(), song.Track(), "").Return(models.SearchTrackResponse{}, nil)
+
+		provider := searchTrackProvider{searcher: searcher}
+
+		_, _, err := provider.SearchTrack(ctx, song, isrc)
+		assert.ErrorIs(t, err, matching.ErrTrackNotFound)
+	})
+}
+
+// assertTrack compares tracks ignoring when the tracks were matched.
+func assertTrack(t *testing.T, expected, actual domain.Track) {
+	t.Helper()
+
+	assert.Equal(t, expected.PlaylistType(), actual.PlaylistType())
+	assert.Equal(t, expected.SongID(), actual.SongID())
+	assert.Equal(t, expected.TrackID(), actual.TrackID())
+	assert.Equal(t, expected.URI(), actual.URI())
+	assert.Equal(t, expected.MatchFound(), actual.MatchFound())
+	assert.Equal(t, expected.MatchMethod(), actual.MatchMethod())
+	assert.InDelta(t, expected.Confidence(), actual.Confidence(), 0.01)
+	assert.Equal(t, expected.MatchedArtist(), actual.MatchedArtist())
+	assert.Equal(t, expected.MatchedTrack(), actual.MatchedTrack())
+	assert.Equal(t, expected.MatchedAlbum(), actual.MatchedAlbum())
+	assert.Equal(t, expected.ISRC(), actual.ISRC())
+}
+
+func TestSearchTrackProvider_SearchTrack_Scoring(t *testing.T) {
+	song, err := domain.NewSong("Cake", "Never There", "Prolonging The Magic", "")
+	require.NoError(t, err)
+
+	results := models.SearchTrackResponse{
+		Tracks: models.TrackCollection{
+			Total: 1,
+			Items: []models.SimpleTrack{
+				{
+					Album:   models.Album{AlbumType: models.AlbumAlbumType, Name: "Magic Prolonging"},
+					Artists: []models.Artist{{Name: "Cake"}},
+					Name:    "There Never",
+					ID:      "trackID",
+					URI:     "spotify:track:trackID",
+				},
+			},
+		},
+	}
+
+	testCases := []struct {
+		name               string
+		strategy           compare.Strategy
+		artist             float64
+		track              float64
+		album              float64
+		expectedConfidence float64
+	}{
+		{
+			name:               "default scoring",
+			expectedConfidence: 35 + 0.40*compare.StringSimilarity("never there", "there never") + 0.25*compare.StringSimilarity("prolonging the magic", "magic prolonging"),
+		},
+		{
+			name:               "token set ignores word order",
+			strategy:           compare.TokenSetStrategy,
+			expectedConfidence: 35 + 40 + 0.25*compare.TokenSetRatio("prolonging the magic", "magic prolonging"),
+		},
+		{
+			name:               "artist only weight",
+			artist:             1,
+			expectedConfidence: 100,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := context.Background()
+
+			scoring, err := domain.NewMatchScoring(tc.strategy, tc.artist, tc.track, tc.album, 0)
+			require.NoError(t, err)
+
+			searcher := NewMockTrackSearcher(t)
+			searcher.EXPECT().SearchTrack(ctx, song.Artist(), song.Track(), "").Return(results, nil)
+
+			provider := NewSearchTrackProvider(searcher, scoring)
+
+			actualTrack, _, err := provider.SearchTrack(ctx, song, "")
+			require.NoError(t, err)
+			assert.InDelta(t, tc.expectedConfidence, actualTrack.Confidence(), 0.01)
+		})
+	}
+}
+
+func TestSearchTrackProvider_SearchTrack_Review(t *testing.T) {
+	song, err := domain.NewSong("Cake", "Never There", "Prolonging The Magic", "")
+	require.NoError(t, err)
+
+	newTrack := func(id, artist, name, album string) models.SimpleTrack {
+		return models.SimpleTrack{
+			Album:   models.Album{AlbumType: models.AlbumAlbumType, Name: album},
+			Artists: []models.Artist{{Name: artist}},
+			Name:    name,
+			ID:      id,
+			URI:     "spotify:track:" + id,
+		}
+	}
+
+	exact := newTrack("exact", "Cake", "Never There", "Prolonging The Magic")
+	close1 := newTrack("close1", "Cake", "Never There", "Prolonging Magic")
+	close2 := newTrack("close2", "Cake", "Never Here", "Prolonging")
+	far := newTrack("far", "Cage", "Forever Where", "Magic")
+
+	scoring, err := domain.NewMatchScoring("", 0, 0, 0, 99)
+	require.NoError(t, err)
+
+	testCases := []struct {
+		name               string
+		tracks             []models.SimpleTrack
+		expectedTrackID    string
+		expectedCandidates []string
+	}{
+		{
+			name:            "exact match doesn't need review",
+			tracks:          []models.SimpleTrack{close1, exact},
+			expectedTrackID: "exact",
+		},
+		{
+			name:               "fuzzy match needs review",
+			tracks:             []models.SimpleTrack{far, close2, close1},
+			expectedTrackID:    "close1",
+			expectedCandidates: []string{"close1", "close2", "far"},
+		},
+		{
+			name:               "single result needs review",
+			tracks:             []models.SimpleTrack{close1},
+			expectedTrackID:    "close1",
+			expectedCandidates: []string{"close1"},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := context.Background()
+
+			searcher := NewMockTrackSearcher(t)
+			searcher.EXPECT().SearchTrack(ctx, song.Artist(), song.Track(), "").Return(models.SearchTrackResponse{
+				Tracks: models.TrackCollection{Total: len(tc.tracks), Items: tc.tracks},
+			}, nil)
+
+			provider := NewSearchTrackProvider(searcher, scoring)
+
+			actualTrack, candidates, err := provider.SearchTrack(ctx, song, "")
+			require.NoError(t, err)
+			assert.Equal(t, tc.expectedTrackID, actualTrack.TrackID())
+
+			var candidateIDs []string
+			for rank, c := range candidates {
+				assert.Equal(t, rank, c.Rank())
+				assert.Equal(t, song.ID(), c.SongID())
+				candidateIDs = append(candidateIDs, c.TrackID())
+			}
+			assert.Equal(t, tc.expectedCandidates, candidateIDs)
 		})
 	}
 }
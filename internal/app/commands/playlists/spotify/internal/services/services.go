@@ -3,6 +3,7 @@ package services
 import (
 	"github.com/jbenzshawel/playlist-generator/internal/app/commands/playlists/spotify/internal/services/internal/mutators"
 	"github.com/jbenzshawel/playlist-generator/internal/app/commands/playlists/spotify/internal/services/internal/providers"
+	"github.com/jbenzshawel/playlist-generator/internal/domain"
 )
 
 type Client interface {
@@ -40,8 +41,8 @@ type searchService struct {
 	providers.SearchTrackProvider
 }
 
-func NewSearchService(client Client) SearchService {
+func NewSearchService(client Client, scoring domain.MatchScoring) SearchService {
 	return &searchService{
-		SearchTrackProvider: providers.NewSearchTrackProvider(client),
+		SearchTrackProvider: providers.NewSearchTrackProvider(client, scoring),
 	}
 }
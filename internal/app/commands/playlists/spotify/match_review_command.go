@@ -0,0 +1,173 @@
+package spotify
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+
+	"github.com/jbenzshawel/playlist-generator/internal/common/decorator"
+	"github.com/jbenzshawel/playlist-generator/internal/domain"
+)
+
+// MatchReview is a song whose best match needs review along with the candidates
+// found for it, ordered from the best match.
+type MatchReview struct {
+	Song       domain.Song
+	Candidates []domain.MatchCandidate
+}
+
+type MatchReviewsCommand struct{}
+
+type MatchReviewsCommandResult struct {
+	Reviews []MatchReview
+}
+
+type MatchReviewsCommandHandler decorator.CommandWithResultHandler[MatchReviewsCommand, MatchReviewsCommandResult]
+
+func NewMatchReviewsCommand(repository domain.Repository) MatchReviewsCommandHandler {
+	return decorator.ApplyDBTransactionDecorator(
+		&matchReviewsCommandHandler{
+			candidateRepository: repository.MatchCandidate(),
+		},
+		repository,
+	)
+}
+
+type matchReviewsCommandHandler struct {
+	candidateRepository domain.MatchCandidateRepository
+}
+
+// Execute returns the songs with candidates waiting for review
+func (c *matchReviewsCommandHandler) Execute(ctx context.Context, _ MatchReviewsCommand) (MatchReviewsCommandResult, error) {
+	songs, err := c.candidateRepository.GetReviewSongs(ctx)
+	if err != nil {
+		return MatchReviewsCommandResult{}, err
+	}
+
+	reviews := make([]MatchReview, 0, len(songs))
+	for _, song := range songs {
+		candidates, err := c.candidateRepository.GetCandidates(ctx, song.ID())
+		if err != nil {
+			return MatchReviewsCommandResult{}, err
+		}
+		reviews = append(reviews, MatchReview{Song: song, Candidates: candidates})
+	}
+
+	return MatchReviewsCommandResult{Reviews: reviews}, nil
+}
+
+// ReviewDecision is how a match waiting for review is settled
+type ReviewDecision string
+
+const (
+	// AcceptReviewDecision matches the song to its best candidate
+	AcceptReviewDecision ReviewDecision = "accept"
+	// PickReviewDecision matches the song to the candidate with the command's Rank
+	PickReviewDecision ReviewDecision = "pick"
+	// RejectReviewDecision rejects every candidate, the song is searched for again
+	// after its back-off
+	RejectReviewDecision ReviewDecision = "reject"
+)
+
+type ReviewMatchCommand struct {
+	SongHash string
+	Decision ReviewDecision
+	// Rank is the rank of the candidate picked with PickReviewDecision
+	Rank int
+}
+
+type ReviewMatchCommandResult struct {
+	Song domain.Song
+	// Matched is true when the song was matched to a candidate
+	Matched bool
+}
+
+type ReviewMatchCommandHandler decorator.CommandWithResultHandler[ReviewMatchCommand, ReviewMatchCommandResult]
+
+func NewReviewMatchCommand(repository domain.Repository) ReviewMatchCommandHandler {
+	return decorator.ApplyDBTransactionDecorator(
+		&reviewMatchCommandHandler{
+			songRepository:      repository.Song(),
+			trackRepository:     repository.Track(domain.SpotifyPlaylistType),
+			candidateRepository: repository.MatchCandidate(),
+		},
+		repository,
+	)
+}
+
+type reviewMatchCommandHandler struct {
+	songRepository      domain.SongRepository
+	trackRepository     domain.TrackRepository
+	candidateRepository domain.MatchCandidateRepository
+}
+
+// Execute settles a match waiting for review. Only an accepted or picked candidate
+// matches the song, the candidates are removed from the review queue either way.
+func (c *reviewMatchCommandHandler) Execute(ctx context.Context, cmd ReviewMatchCommand) (ReviewMatchCommandResult, error) {
+	song, err := c.songRepository.GetSongByHash(ctx, cmd.SongHash)
+	if err != nil {
+		return ReviewMatchCommandResult{}, err
+	}
+	if song.SongHash() == "" {
+		return ReviewMatchCommandResult{}, fmt.Errorf("no song found with hash %q", cmd.SongHash)
+	}
+
+	candidates, err := c.candidateRepository.GetCandidates(ctx, song.ID())
+	if err != nil {
+		return ReviewMatchCommandResult{}, err
+	}
+	if len(candidates) == 0 {
+		return ReviewMatchCommandResult{}, fmt.Errorf("song %q isn't waiting for review", cmd.SongHash)
+	}
+
+	var track domain.Track
+	switch cmd.Decision {
+	case AcceptReviewDecision:
+		track = candidates[0].Accept()
+	case PickReviewDecision:
+		if cmd.Rank < 0 || cmd.Rank >= len(candidates) {
+			return ReviewMatchCommandResult{}, fmt.Errorf("song %q has no candidate ranked %d", cmd.SongHash, cmd.Rank)
+		}
+		track = candidates[cmd.Rank].Accept()
+	case RejectReviewDecision:
+		track, err = c.rejectedTrack(ctx, song)
+		if err != nil {
+			return ReviewMatchCommandResult{}, err
+		}
+	default:
+		return ReviewMatchCommandResult{}, fmt.Errorf("unknown review decision %q", cmd.Decision)
+	}
+
+	err = c.trackRepository.Replace(ctx, track)
+	if err != nil {
+		return ReviewMatchCommandResult{}, fmt.Errorf("spotify track replace error: %w", err)
+	}
+
+	err = c.candidateRepository.Delete(ctx, song.ID())
+	if err != nil {
+		return ReviewMatchCommandResult{}, fmt.Errorf("match candidate delete error: %w", err)
+	}
+
+	slog.Info("song match reviewed",
+		slog.Any("song", song),
+		slog.String("decision", string(cmd.Decision)),
+		slog.String("uri", track.URI()),
+	)
+
+	return ReviewMatchCommandResult{Song: song, Matched: track.MatchFound()}, nil
+}
+
+// rejectedTrack keeps a song's existing match when a rematch queued the review,
+// otherwise the song isn't found and is searched for again after its back-off.
+func (c *reviewMatchCommandHandler) rejectedTrack(ctx context.Context, song domain.Song) (domain.Track, error) {
+	current, err := c.trackRepository.GetTrackBySongID(ctx, song.ID())
+	if err != nil {
+		return domain.Track{}, err
+	}
+
+	if current.MatchFound() {
+		return current, nil
+	}
+
+	return domain.NewNotFoundTrack(domain.SpotifyPlaylistType, song.ID(), max(current.AttemptCount(), 1)), nil
+}
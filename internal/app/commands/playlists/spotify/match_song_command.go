@@ -0,0 +1,148 @@
+package spotify
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+
+	"github.com/jbenzshawel/playlist-generator/internal/common/decorator"
+	"github.com/jbenzshawel/playlist-generator/internal/domain"
+)
+
+type MatchSongCommand struct {
+	// SongHash selects the song to override. When it is empty every song with the
+	// Artist and Track, on any album, is overridden.
+	SongHash string
+	Artist   string
+	Track    string
+	// TrackURI is the Spotify track URI or URL the songs are pinned to.
+	TrackURI string
+	// NeverMatch marks the songs as never matching a Spotify track instead of
+	// pinning them to TrackURI.
+	NeverMatch bool
+}
+
+type MatchSongCommandResult struct {
+	Songs []domain.Song
+}
+
+type MatchSongCommandHandler decorator.CommandWithResultHandler[MatchSongCommand, MatchSongCommandResult]
+
+func NewMatchSongCommand(repository domain.Repository) MatchSongCommandHandler {
+	return decorator.ApplyDBTransactionDecorator(
+		&matchSongCommandHandler{
+			songRepository:      repository.Song(),
+			trackRepository:     repository.Track(domain.SpotifyPlaylistType),
+			overrideRepository:  repository.MatchOverride(),
+			candidateRepository: repository.MatchCandidate(),
+		},
+		repository,
+	)
+}
+
+type matchSongCommandHandler struct {
+	songRepository      domain.SongRepository
+	trackRepository     domain.TrackRepository
+	overrideRepository  domain.MatchOverrideRepository
+	candidateRepository domain.MatchCandidateRepository
+}
+
+// Execute saves a match override for the selected songs and replaces their
+// current Spotify track with the override.
+func (c *matchSongCommandHandler) Execute(ctx context.Context, cmd MatchSongCommand) (MatchSongCommandResult, error) {
+	if cmd.NeverMatch == (cmd.TrackURI != "") {
+		return MatchSongCommandResult{}, errors.New("either a track URI or never match is required")
+	}
+
+	songs, err := c.getSongs(ctx, cmd)
+	if err != nil {
+		return MatchSongCommandResult{}, err
+	}
+
+	overrides, err := c.overrideRepository.GetMatchOverrides(ctx)
+	if err != nil {
+		return MatchSongCommandResult{}, err
+	}
+
+	previousURIs := make(map[string]string, len(overrides))
+	for _, o := range overrides {
+		previousURIs[o.SongHash()] = o.PreviousURI()
+	}
+
+	for _, song := range songs {
+		// a song overridden again keeps the URI it was matched to before any override,
+		// its current track is the earlier override
+		previousURI, ok := previousURIs[song.SongHash()]
+		if !ok {
+			current, err := c.trackRepository.GetTrackBySongID(ctx, song.ID())
+			if err != nil {
+				return MatchSongCommandResult{}, err
+			}
+
+			if current.MatchFound() {
+				previousURI = current.URI()
+			}
+		}
+
+		override := domain.NewNeverMatchOverride(song.SongHash(), previousURI)
+		if !cmd.NeverMatch {
+			override, err = domain.NewMatchOverride(song.SongHash(), cmd.TrackURI, previousURI)
+			if err != nil {
+				return MatchSongCommandResult{}, err
+			}
+		}
+
+		err = c.overrideRepository.Upsert(ctx, override)
+		if err != nil {
+			return MatchSongCommandResult{}, fmt.Errorf("match override upsert error: %w", err)
+		}
+
+		err = c.trackRepository.Replace(ctx, override.Track(song))
+		if err != nil {
+			return MatchSongCommandResult{}, fmt.Errorf("spotify track replace error: %w", err)
+		}
+
+		// an override settles any match waiting for review
+		err = c.candidateRepository.Delete(ctx, song.ID())
+		if err != nil {
+			return MatchSongCommandResult{}, fmt.Errorf("match candidate delete error: %w", err)
+		}
+
+		slog.Info("song match overridden",
+			slog.Any("song", song),
+			slog.String("uri", override.URI()),
+			slog.String("previousURI", previousURI),
+			slog.Bool("neverMatch", override.NeverMatch()),
+		)
+	}
+
+	return MatchSongCommandResult{Songs: songs}, nil
+}
+
+func (c *matchSongCommandHandler) getSongs(ctx context.Context, cmd MatchSongCommand) ([]domain.Song, error) {
+	if cmd.SongHash != "" {
+		song, err := c.songRepository.GetSongByHash(ctx, cmd.SongHash)
+		if err != nil {
+			return nil, err
+		}
+		if song.SongHash() == "" {
+			return nil, fmt.Errorf("no song found with hash %q", cmd.SongHash)
+		}
+		return []domain.Song{song}, nil
+	}
+
+	if cmd.Artist == "" || cmd.Track == "" {
+		return nil, errors.New("either a song hash or an artist and track is required")
+	}
+
+	songs, err := c.songRepository.GetSongsByArtistTrack(ctx, cmd.Artist, cmd.Track)
+	if err != nil {
+		return nil, err
+	}
+	if len(songs) == 0 {
+		return nil, fmt.Errorf("no songs found for artist %q and track %q", cmd.Artist, cmd.Track)
+	}
+
+	return songs, nil
+}
@@ -0,0 +1,56 @@
+package spotify
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/jbenzshawel/playlist-generator/internal/domain"
+	"github.com/jbenzshawel/playlist-generator/internal/infrastructure/storage"
+)
+
+func TestMatchSongCommand_OverrideTwice(t *testing.T) {
+	repository := storage.NewRepository(storage.InitTestStorage(t))
+
+	song, err := domain.NewSong("Cake", "Never There", "Prolonging The Magic", "")
+	require.NoError(t, err)
+
+	inTransaction(t, repository, func() {
+		require.NoError(t, repository.Song().BulkInsert(t.Context(), []domain.Song{song}))
+		require.NoError(t, repository.Track(domain.SpotifyPlaylistType).Insert(t.Context(),
+			domain.NewTrack(domain.SpotifyPlaylistType, song.ID(), "searched", "spotify:track:searched", domain.FuzzyMatchMethod, 72, "Cake", "Never There", "", ""),
+		))
+	})
+
+	handler := NewMatchSongCommand(repository)
+
+	for _, uri := range []string{"spotify:track:first", "spotify:track:second"} {
+		_, err = handler.Execute(t.Context(), MatchSongCommand{SongHash: song.SongHash(), TrackURI: uri})
+		require.NoError(t, err)
+	}
+
+	inTransaction(t, repository, func() {
+		overrides, err := repository.MatchOverride().GetMatchOverrides(t.Context())
+		require.NoError(t, err)
+		require.Len(t, overrides, 1)
+		assert.Equal(t, "spotify:track:second", overrides[0].URI())
+		assert.Equal(t, "spotify:track:searched", overrides[0].PreviousURI())
+
+		replaced, err := repository.Track(domain.SpotifyPlaylistType).GetReplacedURIs(t.Context())
+		require.NoError(t, err)
+		assert.Equal(t, map[string]string{
+			"spotify:track:searched": "spotify:track:second",
+			"spotify:track:first":    "spotify:track:second",
+		}, replaced)
+	})
+}
+
+// inTransaction runs fn in a committed transaction.
+func inTransaction(t *testing.T, repository domain.Repository, fn func()) {
+	t.Helper()
+
+	require.NoError(t, repository.Begin(t.Context()))
+	fn()
+	require.NoError(t, repository.Commit())
+}
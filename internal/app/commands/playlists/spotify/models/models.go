@@ -52,6 +52,28 @@ type Album struct {
 	Name       string    `json:"name"`
 }
 
+type SearchAlbumResponse struct {
+	Albums AlbumCollection `json:"albums"`
+}
+
+type AlbumCollection struct {
+	Total int     `json:"total"`
+	Items []Album `json:"items"`
+}
+
+// AlbumTrackPage is a page of an album's tracks. Album tracks don't include the
+// album or external ids.
+type AlbumTrackPage struct {
+	Total int           `json:"total"`
+	Items []SimpleTrack `json:"items"`
+}
+
+// TracksResponse is the tracks requested by ID, with their album and external ids. A
+// track that doesn't exist is null.
+type TracksResponse struct {
+	Tracks []*SimpleTrack `json:"tracks"`
+}
+
 type ExternalIDs struct {
 	ISRC string `json:"isrc"`
 	EAN  string `json:"ean"`
@@ -84,6 +106,10 @@ type CreatePlaylistRequest struct {
 	Collaborative bool   `json:"collaborative"`
 }
 
+type ChangePlaylistDetailsRequest struct {
+	Name string `json:"name"`
+}
+
 type SimplePlaylist struct {
 	Collaborative bool              `json:"collaborative"`
 	Description   string            `json:"description"`
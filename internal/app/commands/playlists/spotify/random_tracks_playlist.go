@@ -28,7 +28,7 @@ func NewRandomTracksPlaylistCommand(
 	return decorator.ApplyDBTransactionDecorator(
 		&randomTracksPlaylistCommand{
 			playlistService: playlistService,
-			repository:      repository.SpotifyTrack(),
+			repository:      repository.Track(domain.SpotifyPlaylistType),
 		},
 		repository,
 	)
@@ -36,7 +36,7 @@ func NewRandomTracksPlaylistCommand(
 
 type randomTracksPlaylistCommand struct {
 	playlistService services.PlaylistService
-	repository      domain.SpotifyTrackRepository
+	repository      domain.TrackRepository
 }
 
 func (r *randomTracksPlaylistCommand) Execute(ctx context.Context, cmd RandomTracksPlaylistCommand) (any, error) {
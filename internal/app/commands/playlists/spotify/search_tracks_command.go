@@ -4,84 +4,273 @@ import (
 	"context"
 	"fmt"
 	"log/slog"
+	"time"
 
-	"golang.org/x/sync/errgroup"
+	"github.com/google/uuid"
 
+	"github.com/jbenzshawel/playlist-generator/internal/app/commands/playlists/internal/tracks"
 	"github.com/jbenzshawel/playlist-generator/internal/app/commands/playlists/spotify/internal/services"
 	"github.com/jbenzshawel/playlist-generator/internal/common/decorator"
 	"github.com/jbenzshawel/playlist-generator/internal/domain"
 )
 
-type SearchTracksCommand struct{}
+type SearchTracksCommand struct {
+	// MinConfidence re-searches songs matched with a confidence below it instead
+	// of searching for songs that haven't been matched. A new match replaces the
+	// existing match, the existing match is kept when no track is found.
+	MinConfidence float64
+	// RetryNotFound re-searches songs that weren't found once their back-off has
+	// passed instead of searching for songs that haven't been matched.
+	RetryNotFound bool
+}
+
+type SearchTracksCommandResult struct {
+	// Matched are the songs that were matched to a Spotify track
+	Matched []domain.Song
+	// Review are the songs whose best match is waiting for review
+	Review []domain.Song
+}
 
-type SearchTracksCommandHandler decorator.CommandHandler[SearchTracksCommand]
+type SearchTracksCommandHandler decorator.CommandWithResultHandler[SearchTracksCommand, SearchTracksCommandResult]
 
 func NewSearchTracksCommand(searchService services.SearchService, repository domain.Repository) SearchTracksCommandHandler {
 	return decorator.ApplyDBTransactionDecorator(
 		&searchTracksCommandHandler{
-			searchService: searchService,
-			repository:    repository.SpotifyTrack(),
+			searchService:       searchService,
+			repository:          repository.Track(domain.SpotifyPlaylistType),
+			overrideRepository:  repository.MatchOverride(),
+			candidateRepository: repository.MatchCandidate(),
 		},
 		repository,
 	)
 }
 
 type searchTracksCommandHandler struct {
-	searchService services.SearchService
-	repository    domain.SpotifyTrackRepository
+	searchService       services.SearchService
+	repository          domain.TrackRepository
+	overrideRepository  domain.MatchOverrideRepository
+	candidateRepository domain.MatchCandidateRepository
 }
 
-func (t *searchTracksCommandHandler) Execute(ctx context.Context, _ SearchTracksCommand) (any, error) {
-	songs, err := t.repository.GetUnknownSongs(ctx)
+func (t *searchTracksCommandHandler) Execute(ctx context.Context, cmd SearchTracksCommand) (SearchTracksCommandResult, error) {
+	songs, err := t.getSongs(ctx, cmd)
 	if err != nil {
-		return nil, err
+		return SearchTracksCommandResult{}, err
 	}
 
-	slog.Info("found unknown songs to search", slog.Int("numSongs", len(songs)))
+	overrides, err := t.getOverrides(ctx)
+	if err != nil {
+		return SearchTracksCommandResult{}, err
+	}
 
-	g, gCtx := errgroup.WithContext(ctx)
+	isrcs, err := t.getISRCs(ctx, songs)
+	if err != nil {
+		return SearchTracksCommandResult{}, err
+	}
 
-	g.SetLimit(6)
+	var (
+		matched []domain.Song
+		review  []domain.Song
+	)
 
-	for idx := 0; idx < len(songs); idx++ {
-		g.Go(func() error {
-			defer func() {
-				if r := recover(); r != nil {
-					err = fmt.Errorf("panic occurred during searching for tracks: %v", r)
-				}
-			}()
+	err = tracks.SearchSongs(ctx, songs, func(ctx context.Context, song domain.Song) func() error {
+		track, candidates, searchErr := t.searchSong(ctx, song, overrides, isrcs[song.ID()])
 
-			select {
-			case <-gCtx.Done():
-				return gCtx.Err()
-			default:
-				var track domain.SpotifyTrack
-				var err error
-				song := songs[idx]
+		return func() error {
+			status, err := t.saveSearch(ctx, cmd, song, track, candidates, searchErr)
+			if err != nil {
+				return err
+			}
+
+			switch status {
+			case songMatched:
+				matched = append(matched, song)
+			case songNeedsReview:
+				review = append(review, song)
+			}
+
+			return nil
+		}
+	})
+	if err != nil {
+		return SearchTracksCommandResult{}, err
+	}
 
-				track, err = t.searchService.SearchTrack(ctx, song)
-				if err != nil {
-					slog.Warn("spotify track not found for song",
-						slog.Any("song", song),
-						slog.Any("error", err),
-					)
-					track = domain.NewNotFoundSpotifyTrack(song.ID())
-				}
+	if len(review) > 0 {
+		slog.Info("song matches waiting for review", slog.Int("numSongs", len(review)))
+	}
 
-				err = t.repository.Insert(ctx, track)
-				if err != nil {
-					return fmt.Errorf("spotify track insert error: %w", err)
-				}
+	return SearchTracksCommandResult{Matched: matched, Review: review}, nil
+}
 
-				return nil
+func (t *searchTracksCommandHandler) getSongs(ctx context.Context, cmd SearchTracksCommand) ([]domain.Song, error) {
+	switch {
+	case cmd.MinConfidence > 0:
+		songs, err := t.repository.GetLowConfidenceSongs(ctx, cmd.MinConfidence)
+		if err != nil {
+			return nil, err
+		}
+
+		slog.Info("found low confidence songs to search",
+			slog.Int("numSongs", len(songs)),
+			slog.Float64("minConfidence", cmd.MinConfidence),
+		)
+		return songs, nil
+	case cmd.RetryNotFound:
+		songs, err := t.repository.GetRetryableSongs(ctx, time.Now())
+		if err != nil {
+			return nil, err
+		}
+
+		slog.Info("found not found songs to search again", slog.Int("numSongs", len(songs)))
+		return songs, nil
+	default:
+		songs, err := t.repository.GetUnknownSongs(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		slog.Info("found unknown songs to search", slog.Int("numSongs", len(songs)))
+		return songs, nil
+	}
+}
+
+type searchStatus int
+
+const (
+	songNotMatched searchStatus = iota
+	songMatched
+	songNeedsReview
+)
+
+// searchSong searches for a song's track. It doesn't use the repository so songs
+// can be searched for concurrently.
+func (t *searchTracksCommandHandler) searchSong(ctx context.Context, song domain.Song, overrides map[string]domain.MatchOverride, isrc string) (domain.Track, []domain.MatchCandidate, error) {
+	// overrides win over searching so manual fixes survive re-searches
+	if override, ok := overrides[song.SongHash()]; ok {
+		return override.Track(song), nil, nil
+	}
+
+	return t.searchService.SearchTrack(ctx, song, isrc)
+}
+
+// saveSearch saves the result of searching for a song. Songs whose best match needs
+// review aren't matched until a candidate is accepted.
+func (t *searchTracksCommandHandler) saveSearch(ctx context.Context, cmd SearchTracksCommand, song domain.Song, track domain.Track, candidates []domain.MatchCandidate, err error) (searchStatus, error) {
+	if err == nil && len(candidates) > 0 {
+		return t.queueReview(ctx, cmd, song, candidates)
+	}
+
+	if err != nil {
+		slog.Warn("spotify track not found for song",
+			slog.Any("song", song),
+			slog.Any("error", err),
+		)
+
+		switch {
+		case cmd.MinConfidence > 0:
+			// keep the existing low confidence match
+			return songNotMatched, nil
+		case cmd.RetryNotFound:
+			previous, err := t.repository.GetTrackBySongID(ctx, song.ID())
+			if err != nil {
+				return songNotMatched, err
 			}
-		})
+			track = domain.NewNotFoundTrack(domain.SpotifyPlaylistType, song.ID(), previous.AttemptCount()+1)
+		default:
+			track = domain.NewNotFoundTrack(domain.SpotifyPlaylistType, song.ID(), 1)
+		}
+	}
+
+	err = t.saveTrack(ctx, cmd, track)
+	if err != nil {
+		return songNotMatched, err
+	}
+
+	if !track.MatchFound() {
+		return songNotMatched, nil
+	}
+
+	// a song searched for again may have had a match waiting for review
+	if cmd.MinConfidence > 0 || cmd.RetryNotFound {
+		err = t.candidateRepository.Delete(ctx, song.ID())
+		if err != nil {
+			return songNotMatched, fmt.Errorf("match candidate delete error: %w", err)
+		}
+	}
+
+	return songMatched, nil
+}
+
+// queueReview saves the candidates for a song whose best match needs review. A low
+// confidence match being searched for again is kept until a candidate is accepted.
+func (t *searchTracksCommandHandler) queueReview(ctx context.Context, cmd SearchTracksCommand, song domain.Song, candidates []domain.MatchCandidate) (searchStatus, error) {
+	err := t.candidateRepository.Replace(ctx, song.ID(), candidates)
+	if err != nil {
+		return songNotMatched, fmt.Errorf("match candidate replace error: %w", err)
+	}
+
+	if cmd.MinConfidence > 0 {
+		return songNeedsReview, nil
+	}
+
+	attemptCount := 1
+	if cmd.RetryNotFound {
+		previous, err := t.repository.GetTrackBySongID(ctx, song.ID())
+		if err != nil {
+			return songNotMatched, err
+		}
+		attemptCount = previous.AttemptCount() + 1
+	}
+
+	err = t.saveTrack(ctx, cmd, domain.NewReviewTrack(domain.SpotifyPlaylistType, song.ID(), attemptCount))
+	if err != nil {
+		return songNotMatched, err
+	}
+
+	return songNeedsReview, nil
+}
+
+func (t *searchTracksCommandHandler) saveTrack(ctx context.Context, cmd SearchTracksCommand, track domain.Track) error {
+	// songs being searched for again already have a track that is replaced
+	if cmd.MinConfidence > 0 || cmd.RetryNotFound {
+		err := t.repository.Replace(ctx, track)
+		if err != nil {
+			return fmt.Errorf("spotify track replace error: %w", err)
+		}
+		return nil
 	}
 
-	err = g.Wait()
+	err := t.repository.Insert(ctx, track)
+	if err != nil {
+		return fmt.Errorf("spotify track insert error: %w", err)
+	}
+	return nil
+}
+
+func (t *searchTracksCommandHandler) getOverrides(ctx context.Context) (map[string]domain.MatchOverride, error) {
+	overrides, err := t.overrideRepository.GetMatchOverrides(ctx)
 	if err != nil {
 		return nil, err
 	}
 
-	return nil, nil
+	lookup := make(map[string]domain.MatchOverride, len(overrides))
+	for _, o := range overrides {
+		lookup[o.SongHash()] = o
+	}
+	return lookup, nil
+}
+
+// getISRCs returns the ISRCs of the songs' matches on other providers, which find the
+// same recording.
+func (t *searchTracksCommandHandler) getISRCs(ctx context.Context, songs []domain.Song) (map[uuid.UUID]string, error) {
+	isrcs := make(map[uuid.UUID]string, len(songs))
+	for _, song := range songs {
+		isrc, err := t.repository.GetISRC(ctx, song.ID())
+		if err != nil {
+			return nil, err
+		}
+		isrcs[song.ID()] = isrc
+	}
+	return isrcs, nil
 }
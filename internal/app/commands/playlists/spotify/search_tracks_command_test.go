@@ -0,0 +1,57 @@
+package spotify
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/jbenzshawel/playlist-generator/internal/domain"
+	"github.com/jbenzshawel/playlist-generator/internal/infrastructure/storage"
+)
+
+func TestSearchTracksCommand_OverrideBeatsSearch(t *testing.T) {
+	repository := storage.NewRepository(storage.InitTestStorage(t))
+
+	song, err := domain.NewSong("Cake", "Never There", "Prolonging The Magic", "")
+	require.NoError(t, err)
+
+	override, err := domain.NewMatchOverride(song.SongHash(), "spotify:track:override", "")
+	require.NoError(t, err)
+
+	inTransaction(t, repository, func() {
+		require.NoError(t, repository.Song().BulkInsert(t.Context(), []domain.Song{song}))
+		require.NoError(t, repository.Track(domain.SpotifyPlaylistType).Insert(t.Context(),
+			domain.NewTrack(domain.SpotifyPlaylistType, song.ID(), "searched", "spotify:track:searched", domain.FuzzyMatchMethod, 72, "Cake", "Never There", "", ""),
+		))
+		require.NoError(t, repository.MatchOverride().Upsert(t.Context(), override))
+	})
+
+	searchService := &testSearchService{
+		track: domain.NewTrack(domain.SpotifyPlaylistType, song.ID(), "better", "spotify:track:better", domain.ExactMatchMethod, 100, "Cake", "Never There", "", ""),
+	}
+
+	result, err := NewSearchTracksCommand(searchService, repository).Execute(t.Context(), SearchTracksCommand{MinConfidence: 80})
+	require.NoError(t, err)
+	require.Len(t, result.Matched, 1)
+	assert.Equal(t, song.ID(), result.Matched[0].ID())
+	assert.Zero(t, searchService.calls)
+
+	inTransaction(t, repository, func() {
+		track, err := repository.Track(domain.SpotifyPlaylistType).GetTrackBySongID(t.Context(), song.ID())
+		require.NoError(t, err)
+		assert.Equal(t, "spotify:track:override", track.URI())
+		assert.Equal(t, domain.OverrideMatchMethod, track.MatchMethod())
+	})
+}
+
+type testSearchService struct {
+	track domain.Track
+	calls int
+}
+
+func (s *testSearchService) SearchTrack(_ context.Context, _ domain.Song, _ string) (domain.Track, []domain.MatchCandidate, error) {
+	s.calls++
+	return s.track, nil, nil
+}
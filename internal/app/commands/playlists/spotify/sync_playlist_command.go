@@ -13,7 +13,10 @@ import (
 
 type SyncPlaylistCommand struct {
 	Playlist domain.Playlist
-	Date     string
+	// Date is the day being synced. Only songs played since the playlist was last
+	// synced are added unless an earlier day is synced. When the date is empty
+	// every song played in the playlist's date range is added.
+	Date string
 }
 
 type SyncPlaylistCommandHandler decorator.CommandHandler[SyncPlaylistCommand]
@@ -26,7 +29,7 @@ func NewSyncPlaylistCommand(
 		&syncPlaylistCommandHandler{
 			playlistService:    playlistService,
 			playlistRepository: repository.Playlist(),
-			trackRepository:    repository.SpotifyTrack(),
+			trackRepository:    repository.Track(domain.SpotifyPlaylistType),
 		},
 		repository,
 	)
@@ -39,13 +42,19 @@ type playlist interface {
 type syncPlaylistCommandHandler struct {
 	playlistService    services.PlaylistService
 	playlistRepository domain.PlaylistRepository
-	trackRepository    domain.SpotifyTrackRepository
+	trackRepository    domain.TrackRepository
 }
 
 func (c *syncPlaylistCommandHandler) Execute(ctx context.Context, cmd SyncPlaylistCommand) (any, error) {
-	startDate := cmd.Playlist.LastDaySynced()
-	if startDate == "" || cmd.Date < cmd.Playlist.LastDaySynced() {
-		startDate = cmd.Playlist.StartDate()
+	startDate, err := cmd.Playlist.StartDate()
+	if err != nil {
+		return nil, err
+	}
+
+	// Only look at songs since the last sync unless an earlier date is being synced
+	lastDaySynced := cmd.Playlist.LastDaySynced()
+	if cmd.Date != "" && lastDaySynced != "" && cmd.Date >= lastDaySynced && lastDaySynced > startDate {
+		startDate = lastDaySynced
 	}
 
 	endDate, err := cmd.Playlist.EndDate()
@@ -53,7 +62,7 @@ func (c *syncPlaylistCommandHandler) Execute(ctx context.Context, cmd SyncPlayli
 		return nil, err
 	}
 
-	tracks, err := c.trackRepository.GetTracksPlayedInRange(ctx, domain.StudioOneSourceType, startDate, endDate)
+	tracks, err := c.trackRepository.GetTracksPlayedInRange(ctx, cmd.Playlist.SourceType(), cmd.Playlist.ProgramName(), startDate, endDate)
 	if err != nil {
 		return nil, err
 	}
@@ -62,7 +71,7 @@ func (c *syncPlaylistCommandHandler) Execute(ctx context.Context, cmd SyncPlayli
 		slog.Info("no new downloaded tracks to sync")
 	}
 
-	trackURIs, err := c.getTrackURIs(ctx, cmd.Playlist, tracks)
+	trackURIs, removeURIs, err := c.getTrackURIs(ctx, cmd.Playlist, tracks)
 	if err != nil {
 		return nil, err
 	}
@@ -71,6 +80,11 @@ func (c *syncPlaylistCommandHandler) Execute(ctx context.Context, cmd SyncPlayli
 		slog.Info("all downloaded tracks synced to playlist")
 	}
 
+	err = c.playlistService.RemoveTracks(ctx, cmd.Playlist.ID(), removeURIs)
+	if err != nil {
+		return nil, err
+	}
+
 	err = c.playlistService.AddTracks(ctx, cmd.Playlist.ID(), trackURIs)
 	if err != nil {
 		return nil, err
@@ -83,27 +97,50 @@ func (c *syncPlaylistCommandHandler) Execute(ctx context.Context, cmd SyncPlayli
 		return nil, err
 	}
 
-	slog.Info("tracks sync complete", slog.Int("numTracks", len(trackURIs)))
+	slog.Info("tracks sync complete", slog.Int("numTracks", len(trackURIs)), slog.Int("numRemoved", len(removeURIs)))
 
 	return nil, nil
 }
 
-func (c *syncPlaylistCommandHandler) getTrackURIs(ctx context.Context, p domain.Playlist, tracks []domain.SpotifyTrack) ([]string, error) {
+// getTrackURIs returns the URIs of tracks to add to the playlist and the URIs of tracks to
+// remove. Tracks in the playlist whose song was matched again, by a rematch, review or
+// match override, are swapped for the new match.
+func (c *syncPlaylistCommandHandler) getTrackURIs(ctx context.Context, p domain.Playlist, tracks []domain.Track) ([]string, []string, error) {
 	playlistTracks, err := c.playlistService.GetTracks(ctx, p.ID())
 	if err != nil {
-		return nil, err
+		return nil, nil, err
+	}
+
+	replacedURIs, err := c.trackRepository.GetReplacedURIs(ctx)
+	if err != nil {
+		return nil, nil, err
 	}
 
 	trackLookup := make(map[string]struct{}, len(playlistTracks))
 	for _, track := range playlistTracks {
-		trackLookup[track.ID] = struct{}{}
+		trackLookup[track.URI] = struct{}{}
 	}
 
 	trackURIs := make([]string, 0, len(tracks))
+	var removeURIs []string
+	for _, track := range playlistTracks {
+		uri, ok := replacedURIs[track.URI]
+		if !ok {
+			continue
+		}
+
+		removeURIs = append(removeURIs, track.URI)
+		if _, ok := trackLookup[uri]; uri != "" && !ok {
+			trackURIs = append(trackURIs, uri)
+			trackLookup[uri] = struct{}{}
+		}
+	}
+
 	for _, track := range tracks {
-		if _, ok := trackLookup[track.TrackID()]; !ok {
+		if _, ok := trackLookup[track.URI()]; !ok {
 			trackURIs = append(trackURIs, track.URI())
+			trackLookup[track.URI()] = struct{}{}
 		}
 	}
-	return trackURIs, nil
+	return trackURIs, removeURIs, nil
 }
@@ -0,0 +1,112 @@
+package spotify
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/jbenzshawel/playlist-generator/internal/app/commands/playlists/spotify/internal/services"
+	"github.com/jbenzshawel/playlist-generator/internal/common/decorator"
+	"github.com/jbenzshawel/playlist-generator/internal/domain"
+)
+
+type SyncRollingPlaylistCommand struct {
+	Playlist domain.Playlist
+}
+
+type SyncRollingPlaylistCommandHandler decorator.CommandHandler[SyncRollingPlaylistCommand]
+
+func NewSyncRollingPlaylistCommand(
+	playlistService services.PlaylistService,
+	repository domain.Repository,
+) SyncRollingPlaylistCommandHandler {
+	return decorator.ApplyDBTransactionDecorator(
+		&syncRollingPlaylistCommandHandler{
+			playlistService:    playlistService,
+			playlistRepository: repository.Playlist(),
+			trackRepository:    repository.Track(domain.SpotifyPlaylistType),
+		},
+		repository,
+	)
+}
+
+type syncRollingPlaylistCommandHandler struct {
+	playlistService    services.PlaylistService
+	playlistRepository domain.PlaylistRepository
+	trackRepository    domain.TrackRepository
+}
+
+// Execute updates a rolling playlist so it holds exactly the tracks played within its
+// window. Tracks that aged out of the window are removed and new plays are added.
+func (c *syncRollingPlaylistCommandHandler) Execute(ctx context.Context, cmd SyncRollingPlaylistCommand) (any, error) {
+	if cmd.Playlist.DateScope() != domain.RollingPlaylistDateScope {
+		return nil, fmt.Errorf("playlist %s is not a rolling playlist", cmd.Playlist.ID())
+	}
+
+	startDate, err := cmd.Playlist.StartDate()
+	if err != nil {
+		return nil, err
+	}
+
+	endDate, err := cmd.Playlist.EndDate()
+	if err != nil {
+		return nil, err
+	}
+
+	tracks, err := c.trackRepository.GetTracksPlayedInRange(ctx, cmd.Playlist.SourceType(), cmd.Playlist.ProgramName(), startDate, endDate)
+	if err != nil {
+		return nil, err
+	}
+
+	playlistTracks, err := c.playlistService.GetTracks(ctx, cmd.Playlist.ID())
+	if err != nil {
+		return nil, err
+	}
+
+	inWindow := make(map[string]struct{}, len(tracks))
+	for _, track := range tracks {
+		inWindow[track.TrackID()] = struct{}{}
+	}
+
+	inPlaylist := make(map[string]struct{}, len(playlistTracks))
+	var removeURIs []string
+	for _, track := range playlistTracks {
+		inPlaylist[track.ID] = struct{}{}
+		if _, ok := inWindow[track.ID]; !ok {
+			removeURIs = append(removeURIs, track.URI)
+		}
+	}
+
+	var addURIs []string
+	for _, track := range tracks {
+		if _, ok := inPlaylist[track.TrackID()]; !ok {
+			addURIs = append(addURIs, track.URI())
+			// a track can be played more than once in the window
+			inPlaylist[track.TrackID()] = struct{}{}
+		}
+	}
+
+	err = c.playlistService.RemoveTracks(ctx, cmd.Playlist.ID(), removeURIs)
+	if err != nil {
+		return nil, err
+	}
+
+	err = c.playlistService.AddTracks(ctx, cmd.Playlist.ID(), addURIs)
+	if err != nil {
+		return nil, err
+	}
+
+	err = c.playlistRepository.SetLastDaySynced(ctx, cmd.Playlist.ID(), time.Now().Format(time.DateOnly))
+	if err != nil {
+		return nil, err
+	}
+
+	slog.Info("rolling playlist sync complete",
+		slog.Any("playlist", cmd.Playlist),
+		slog.Int("numAdded", len(addURIs)),
+		slog.Int("numRemoved", len(removeURIs)),
+	)
+
+	return nil, nil
+}
@@ -0,0 +1,90 @@
+package subsonic
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/jbenzshawel/playlist-generator/internal/app/commands/playlists/subsonic/internal/services"
+	"github.com/jbenzshawel/playlist-generator/internal/common/decorator"
+	"github.com/jbenzshawel/playlist-generator/internal/domain"
+)
+
+type CreatePlaylistCommand struct {
+	Date       string
+	DateScope  domain.PlaylistDateScope
+	SourceName string
+	SourceType domain.SourceType
+	// ProgramName optionally limits the playlist to songs played during a program.
+	// Program playlists are named after the program instead of the source.
+	ProgramName string
+}
+
+type CreatePlaylistCommandResult struct {
+	Playlist domain.Playlist
+}
+
+type CreatePlaylistCommandHandler decorator.CommandWithResultHandler[CreatePlaylistCommand, CreatePlaylistCommandResult]
+
+func NewCreatePlaylistCommand(
+	playlistService services.PlaylistService,
+	repository domain.Repository,
+) CreatePlaylistCommandHandler {
+	return decorator.ApplyDBTransactionDecorator(
+		&createPlaylistCommand{
+			playlistService:    playlistService,
+			playlistRepository: repository.Playlist(),
+		},
+		repository,
+	)
+}
+
+type createPlaylistCommand struct {
+	playlistService    services.PlaylistService
+	playlistRepository domain.PlaylistRepository
+}
+
+func (c *createPlaylistCommand) Execute(ctx context.Context, cmd CreatePlaylistCommand) (CreatePlaylistCommandResult, error) {
+	// tracks are only appended to Subsonic playlists, so there are no rolling playlists
+	if cmd.DateScope == domain.RollingPlaylistDateScope {
+		return CreatePlaylistCommandResult{}, fmt.Errorf("%s playlists aren't supported by subsonic", cmd.DateScope)
+	}
+
+	date, err := time.Parse(time.DateOnly, cmd.Date)
+	if err != nil {
+		return CreatePlaylistCommandResult{}, fmt.Errorf("invalid create playlist date: %w", err)
+	}
+
+	namePrefix := cmd.SourceName
+	if cmd.ProgramName != "" {
+		namePrefix = cmd.ProgramName
+	}
+
+	playlistDate := cmd.DateScope.Format(date)
+	name := fmt.Sprintf("%s %s", namePrefix, playlistDate)
+
+	p, err := c.playlistRepository.GetPlaylistByDate(ctx, domain.SubsonicPlaylistType, cmd.SourceType, cmd.ProgramName, cmd.DateScope, playlistDate)
+	if err != nil {
+		return CreatePlaylistCommandResult{}, err
+	}
+
+	if !p.IsZero() {
+		slog.Info("existing subsonic playlist found", slog.Any("playlist", p))
+		return CreatePlaylistCommandResult{Playlist: p}, nil
+	}
+
+	p, err = c.playlistService.CreatePlaylist(ctx, name, playlistDate, cmd.DateScope, cmd.SourceType, cmd.ProgramName)
+	if err != nil {
+		return CreatePlaylistCommandResult{}, err
+	}
+
+	err = c.playlistRepository.Insert(ctx, p)
+	if err != nil {
+		return CreatePlaylistCommandResult{}, err
+	}
+
+	slog.Info("new subsonic playlist created", slog.Any("playlist", p))
+
+	return CreatePlaylistCommandResult{Playlist: p}, nil
+}
@@ -0,0 +1,52 @@
+package mutators
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/jbenzshawel/playlist-generator/internal/app/commands/playlists/subsonic/models"
+	"github.com/jbenzshawel/playlist-generator/internal/domain"
+)
+
+// playlistURIFormat identifies a playlist on the server, which has no public URL
+const playlistURIFormat = "subsonic:playlist:%s"
+
+type PlaylistCreator interface {
+	CreatePlaylist(ctx context.Context, name string) (models.Playlist, error)
+}
+
+type CreatePlaylistMutator interface {
+	// CreatePlaylist creates a playlist for a source and date. The date must be formatted
+	// for the date scope, see domain.PlaylistDateScope.Format.
+	CreatePlaylist(ctx context.Context, name, date string, dateScope domain.PlaylistDateScope, sourceType domain.SourceType, programName string) (domain.Playlist, error)
+}
+
+func NewCreatePlaylistMutator(creator PlaylistCreator) CreatePlaylistMutator {
+	return &createPlaylistMutator{
+		creator: creator,
+	}
+}
+
+type createPlaylistMutator struct {
+	creator PlaylistCreator
+}
+
+func (c *createPlaylistMutator) CreatePlaylist(ctx context.Context, name, date string, dateScope domain.PlaylistDateScope, sourceType domain.SourceType, programName string) (domain.Playlist, error) {
+	playlist, err := c.creator.CreatePlaylist(ctx, name)
+	if err != nil {
+		return domain.Playlist{}, err
+	}
+
+	p := domain.NewPlaylist(
+		playlist.ID,
+		fmt.Sprintf(playlistURIFormat, playlist.ID),
+		playlist.Name,
+		date,
+		dateScope,
+		domain.SubsonicPlaylistType,
+		sourceType,
+		programName,
+	)
+
+	return p, nil
+}
@@ -0,0 +1,39 @@
+package mutators
+
+import (
+	"context"
+)
+
+// batchSize is the max number of songs added to a playlist in one request, which keeps
+// the request URL short since every song ID is a query parameter
+const batchSize = 50
+
+type TrackAdder interface {
+	AddPlaylistSongs(ctx context.Context, playlistID string, songIDs []string) error
+}
+
+type PlaylistTrackMutator interface {
+	// AddTracks appends tracks to a playlist.
+	AddTracks(ctx context.Context, playlistID string, trackIDs []string) error
+}
+
+type playlistTrackMutator struct {
+	adder TrackAdder
+}
+
+func NewPlaylistTrackMutator(adder TrackAdder) PlaylistTrackMutator {
+	return &playlistTrackMutator{
+		adder: adder,
+	}
+}
+
+func (p *playlistTrackMutator) AddTracks(ctx context.Context, playlistID string, trackIDs []string) error {
+	for offset := 0; offset < len(trackIDs); offset += batchSize {
+		err := p.adder.AddPlaylistSongs(ctx, playlistID, trackIDs[offset:min(offset+batchSize, len(trackIDs))])
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,199 @@
+// Code generated by mockery; DO NOT EDIT.
+// github.com/vektra/mockery
+// template: testify
+
+package providers
+
+import (
+	"context"
+
+	mock "github.com/stretchr/testify/mock"
+
+	"github.com/jbenzshawel/playlist-generator/internal/app/commands/playlists/subsonic/models"
+)
+
+// NewMockTrackGetter creates a new instance of MockTrackGetter. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockTrackGetter(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockTrackGetter {
+	mock := &MockTrackGetter{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
+
+// MockTrackGetter is an autogenerated mock type for the TrackGetter type
+type MockTrackGetter struct {
+	mock.Mock
+}
+
+type MockTrackGetter_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockTrackGetter) EXPECT() *MockTrackGetter_Expecter {
+	return &MockTrackGetter_Expecter{mock: &_m.Mock}
+}
+
+// GetPlaylist provides a mock function for the type MockTrackGetter
+func (_mock *MockTrackGetter) GetPlaylist(ctx context.Context, playlistID string) (models.Playlist, error) {
+	ret := _mock.Called(ctx, playlistID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetPlaylist")
+	}
+
+	var r0 models.Playlist
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) (models.Playlist, error)); ok {
+		return returnFunc(ctx, playlistID)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) models.Playlist); ok {
+		r0 = returnFunc(ctx, playlistID)
+	} else {
+		r0 = ret.Get(0).(models.Playlist)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = returnFunc(ctx, playlistID)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockTrackGetter_GetPlaylist_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetPlaylist'
+type MockTrackGetter_GetPlaylist_Call struct {
+	*mock.Call
+}
+
+// GetPlaylist is a helper method to define mock.On call
+//   - ctx context.Context
+//   - playlistID string
+func (_e *MockTrackGetter_Expecter) GetPlaylist(ctx interface{}, playlistID interface{}) *MockTrackGetter_GetPlaylist_Call {
+	return &MockTrackGetter_GetPlaylist_Call{Call: _e.mock.On("GetPlaylist", ctx, playlistID)}
+}
+
+func (_c *MockTrackGetter_GetPlaylist_Call) Run(run func(ctx context.Context, playlistID string)) *MockTrackGetter_GetPlaylist_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockTrackGetter_GetPlaylist_Call) Return(playlist models.Playlist, err error) *MockTrackGetter_GetPlaylist_Call {
+	_c.Call.Return(playlist, err)
+	return _c
+}
+
+func (_c *MockTrackGetter_GetPlaylist_Call) RunAndReturn(run func(ctx context.Context, playlistID string) (models.Playlist, error)) *MockTrackGetter_GetPlaylist_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockTrackSearcher creates a new instance of MockTrackSearcher. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockTrackSearcher(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockTrackSearcher {
+	mock := &MockTrackSearcher{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
+
+// MockTrackSearcher is an autogenerated mock type for the TrackSearcher type
+type MockTrackSearcher struct {
+	mock.Mock
+}
+
+type MockTrackSearcher_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockTrackSearcher) EXPECT() *MockTrackSearcher_Expecter {
+	return &MockTrackSearcher_Expecter{mock: &_m.Mock}
+}
+
+// SearchSongs provides a mock function for the type MockTrackSearcher
+func (_mock *MockTrackSearcher) SearchSongs(ctx context.Context, query string) (models.SearchResult3, error) {
+	ret := _mock.Called(ctx, query)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SearchSongs")
+	}
+
+	var r0 models.SearchResult3
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) (models.SearchResult3, error)); ok {
+		return returnFunc(ctx, query)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) models.SearchResult3); ok {
+		r0 = returnFunc(ctx, query)
+	} else {
+		r0 = ret.Get(0).(models.SearchResult3)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = returnFunc(ctx, query)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockTrackSearcher_SearchSongs_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SearchSongs'
+type MockTrackSearcher_SearchSongs_Call struct {
+	*mock.Call
+}
+
+// SearchSongs is a helper method to define mock.On call
+//   - ctx context.Context
+//   - query string
+func (_e *MockTrackSearcher_Expecter) SearchSongs(ctx interface{}, query interface{}) *MockTrackSearcher_SearchSongs_Call {
+	return &MockTrackSearcher_SearchSongs_Call{Call: _e.mock.On("SearchSongs", ctx, query)}
+}
+
+func (_c *MockTrackSearcher_SearchSongs_Call) Run(run func(ctx context.Context, query string)) *MockTrackSearcher_SearchSongs_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockTrackSearcher_SearchSongs_Call) Return(searchResult3 models.SearchResult3, err error) *MockTrackSearcher_SearchSongs_Call {
+	_c.Call.Return(searchResult3, err)
+	return _c
+}
+
+func (_c *MockTrackSearcher_SearchSongs_Call) RunAndReturn(run func(ctx context.Context, query string) (models.SearchResult3, error)) *MockTrackSearcher_SearchSongs_Call {
+	_c.Call.Return(run)
+	return _c
+}
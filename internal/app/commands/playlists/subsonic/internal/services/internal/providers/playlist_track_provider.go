@@ -0,0 +1,44 @@
+package providers
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/jbenzshawel/playlist-generator/internal/app/commands/playlists/subsonic/models"
+)
+
+type TrackGetter interface {
+	GetPlaylist(ctx context.Context, playlistID string) (models.Playlist, error)
+}
+
+type PlaylistTrackProvider interface {
+	// GetTrackIDs returns the IDs of the songs in a playlist.
+	GetTrackIDs(ctx context.Context, playlistID string) ([]string, error)
+}
+
+func NewPlaylistTrackProvider(getter TrackGetter) PlaylistTrackProvider {
+	return &playlistTrackProvider{
+		getter: getter,
+	}
+}
+
+type playlistTrackProvider struct {
+	getter TrackGetter
+}
+
+func (p *playlistTrackProvider) GetTrackIDs(ctx context.Context, playlistID string) ([]string, error) {
+	// playlists aren't paged, every song is returned with the playlist
+	playlist, err := p.getter.GetPlaylist(ctx, playlistID)
+	if err != nil {
+		return nil, err
+	}
+
+	trackIDs := make([]string, 0, len(playlist.Entry))
+	for _, entry := range playlist.Entry {
+		trackIDs = append(trackIDs, entry.ID)
+	}
+
+	slog.Debug("retrieved tracks for playlist", slog.Int("total", len(trackIDs)))
+
+	return trackIDs, nil
+}
@@ -0,0 +1,35 @@
+package providers
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/jbenzshawel/playlist-generator/internal/app/commands/playlists/subsonic/models"
+)
+
+func TestPlaylistTrackProvider_GetTrackIDs(t *testing.T) {
+	const playlistID = "testPlaylistID"
+
+	ctx := context.Background()
+
+	playlist := models.Playlist{
+		ID: playlistID,
+		Entry: []models.Song{
+			{ID: "2b8c6f0e"},
+			{ID: "7d1e9a3c"},
+		},
+	}
+
+	mockGetter := NewMockTrackGetter(t)
+	mockGetter.EXPECT().GetPlaylist(mock.Anything, playlistID).Return(playlist, nil)
+
+	p := NewPlaylistTrackProvider(mockGetter)
+
+	actualTrackIDs, err := p.GetTrackIDs(ctx, playlistID)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"2b8c6f0e", "7d1e9a3c"}, actualTrackIDs)
+}
@@ -0,0 +1,102 @@
+package providers
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"slices"
+
+	"github.com/jbenzshawel/playlist-generator/internal/app/commands/playlists/internal/matching"
+	"github.com/jbenzshawel/playlist-generator/internal/app/commands/playlists/subsonic/models"
+	"github.com/jbenzshawel/playlist-generator/internal/domain"
+)
+
+const trackURIFormat = "subsonic:track:%s"
+
+type TrackSearcher interface {
+	SearchSongs(ctx context.Context, query string) (models.SearchResult3, error)
+}
+
+type SearchTrackProvider interface {
+	// SearchTrack returns the library song that best matches a song. The ISRC is from the
+	// song's match on another provider, or empty when the song hasn't been matched.
+	SearchTrack(ctx context.Context, song domain.Song, isrc string) (domain.Track, error)
+}
+
+func NewSearchTrackProvider(s TrackSearcher, scoring domain.MatchScoring) SearchTrackProvider {
+	return &searchTrackProvider{
+		searcher: s,
+		scoring:  scoring,
+	}
+}
+
+type searchTrackProvider struct {
+	searcher TrackSearcher
+	scoring  domain.MatchScoring
+}
+
+func (s *searchTrackProvider) SearchTrack(ctx context.Context, song domain.Song, isrc string) (domain.Track, error) {
+	result, err := s.searcher.SearchSongs(ctx, song.Artist()+" "+song.Track())
+	if err != nil {
+		return domain.Track{}, err
+	}
+
+	if len(result.Song) == 0 {
+		return domain.Track{}, matching.ErrTrackNotFound
+	}
+
+	// Subsonic can't search by ISRC, but a result tagged with the ISRC is the recording
+	if track, ok := findISRCMatch(s.scoring, result.Song, song, isrc); ok {
+		return track, nil
+	}
+
+	slog.Debug("subsonic search songs found", slog.Int("count", len(result.Song)))
+
+	candidates := make([]matching.Candidate, 0, len(result.Song))
+	for _, r := range result.Song {
+		candidates = append(candidates, newCandidate(r))
+	}
+
+	return matching.FindTrackMatch(domain.SubsonicPlaylistType, s.scoring, candidates, song)
+}
+
+// findISRCMatch returns the result tagged with the ISRC on the album closest to the
+// song's album.
+func findISRCMatch(scoring domain.MatchScoring, songs []models.Song, song domain.Song, isrc string) (domain.Track, bool) {
+	if isrc == "" {
+		return domain.Track{}, false
+	}
+
+	var candidates []matching.Candidate
+	for _, s := range songs {
+		if slices.Contains(s.ISRC, isrc) {
+			candidates = append(candidates, newCandidate(s))
+		}
+	}
+
+	if len(candidates) == 0 {
+		return domain.Track{}, false
+	}
+
+	best := matching.RankMatches(scoring, candidates, song)[0]
+
+	slog.Debug("isrc match track found", slog.Any("match", best.Candidate))
+
+	return best.Track(domain.SubsonicPlaylistType, song, domain.ISRCMatchMethod), true
+}
+
+func newCandidate(s models.Song) matching.Candidate {
+	var isrc string
+	if len(s.ISRC) > 0 {
+		isrc = s.ISRC[0]
+	}
+
+	return matching.Candidate{
+		ID:         s.ID,
+		URI:        fmt.Sprintf(trackURIFormat, s.ID),
+		ArtistName: s.Artist,
+		Title:      s.Title,
+		Album:      s.Album,
+		ISRC:       isrc,
+	}
+}
@@ -0,0 +1,201 @@
+package providers
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/jbenzshawel/playlist-generator/internal/app/commands/playlists/internal/matching"
+	"github.com/jbenzshawel/playlist-generator/internal/app/commands/playlists/subsonic/models"
+	"github.com/jbenzshawel/playlist-generator/internal/domain"
+)
+
+func newTestSong(id, title, artist, album string) models.Song {
+	return models.Song{
+		ID:     id,
+		Title:  title,
+		Artist: artist,
+		Album:  album,
+		ISRC:   []string{"USSUB" + id},
+	}
+}
+
+func TestSearchTrackProvider_SearchTrack(t *testing.T) {
+	const (
+		artist = "Cake"
+		track  = "Never There"
+		album  = "Prolonging The Magic"
+
+		trackID = "2b8c6f0e"
+		uri     = "subsonic:track:" + trackID
+	)
+
+	song, err := domain.NewSong(artist, track, album, "")
+	require.NoError(t, err)
+
+	untagged := newTestSong(trackID, track, artist, album)
+	untagged.ISRC = nil
+
+	testCases := []struct {
+		name          string
+		searchResults []models.Song
+		expectedTrack domain.Track
+		expectedErr   error
+	}{
+		{
+			name: "single result",
+			searchResults: []models.Song{
+				newTestSong(trackID, track, artist, album),
+			},
+			expectedTrack: domain.NewTrack(domain.SubsonicPlaylistType, song.ID(), trackID, uri, domain.SingleResultMatchMethod, 100, artist, track, album, "USSUB"+trackID),
+		},
+		{
+			name: "single result below threshold",
+			searchResults: []models.Song{
+				newTestSong(trackID, "Satan Is My Motor", "Cake", "Fashion Nugget"),
+			},
+			expectedErr: matching.ErrMatchBelowThreshold,
+		},
+		{
+			name: "exact match",
+			searchResults: []models.Song{
+				newTestSong("live", "Never There (Live)", artist, "Live at the Fillmore"),
+				newTestSong(trackID, track, artist, album),
+			},
+			expectedTrack: domain.NewTrack(domain.SubsonicPlaylistType, song.ID(), trackID, uri, domain.ExactMatchMethod, 100, artist, track, album, "USSUB"+trackID),
+		},
+		{
+			name: "fuzzy match",
+			searchResults: []models.Song{
+				newTestSong("other", "Satan Is My Motor", artist, "Prolonging The Magic"),
+				newTestSong(trackID, track, "CAKE", "Prolonging Magic"),
+			},
+			expectedTrack: domain.NewTrack(domain.SubsonicPlaylistType, song.ID(), trackID, uri, domain.FuzzyMatchMethod, 95, "CAKE", track, "Prolonging Magic", "USSUB"+trackID),
+		},
+		{
+			name: "fuzzy match below threshold",
+			searchResults: []models.Song{
+				newTestSong("other", "Satan Is My Motor", "Cake", "Fashion Nugget"),
+				newTestSong("another", "The Distance", "Cake", "Fashion Nugget"),
+			},
+			expectedErr: matching.ErrMatchBelowThreshold,
+		},
+		{
+			name: "song without isrc tags",
+			searchResults: []models.Song{
+				untagged,
+			},
+			expectedTrack: domain.NewTrack(domain.SubsonicPlaylistType, song.ID(), trackID, uri, domain.SingleResultMatchMethod, 100, artist, track, album, ""),
+		},
+		{
+			name:        "no results",
+			expectedErr: matching.ErrTrackNotFound,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := context.Background()
+
+			searcher := NewMockTrackSearcher(t)
+			searcher.EXPECT().SearchSongs(ctx, artist+" "+track).Return(models.SearchResult3{Song: tc.searchResults}, nil)
+
+			provider := searchTrackProvider{
+				searcher: searcher,
+			}
+
+			actualTrack, err := provider.SearchTrack(ctx, song, "")
+			if tc.expectedErr != nil {
+				assert.ErrorIs(t, err, tc.expectedErr)
+				return
+			}
+
+			require.NoError(t, err)
+			assertTrack(t, tc.expectedTrack, actualTrack)
+		})
+	}
+}
+
+func TestSearchTrackProvider_SearchTrack_ISRC(t *testing.T) {
+	const (
+		artist = "Cake"
+		track  = "Never There"
+		album  = "Prolonging The Magic"
+		isrc   = "USMC19838577"
+	)
+
+	song, err := domain.NewSong(artist, track, album, "")
+	require.NoError(t, err)
+
+	compilation := newTestSong("compilation", track, artist, "Showroom of Compassion")
+	compilation.ISRC = []string{isrc}
+
+	original := newTestSong("original", track, artist, album)
+	original.ISRC = []string{isrc}
+
+	testCases := []struct {
+		name          string
+		searchResults []models.Song
+		expectedTrack domain.Track
+	}{
+		{
+			name: "tagged result on the closest album",
+			searchResults: []models.Song{
+				newTestSong("live", "Never There (Live)", artist, album),
+				compilation,
+				original,
+			},
+			expectedTrack: domain.NewTrack(domain.SubsonicPlaylistType, song.ID(), "original", "subsonic:track:original", domain.ISRCMatchMethod, 100, artist, track, album, isrc),
+		},
+		{
+			name: "tagged result wins over an exact name match",
+			searchResults: []models.Song{
+				newTestSong("untagged", track, artist, album),
+				compilation,
+			},
+			expectedTrack: domain.NewTrack(domain.SubsonicPlaylistType, song.ID(), "compilation", "subsonic:track:compilation", domain.ISRCMatchMethod, 79.55, artist, track, "Showroom of Compassion", isrc),
+		},
+		{
+			name: "no tagged results falls back to names",
+			searchResults: []models.Song{
+				newTestSong("other", "Satan Is My Motor", artist, album),
+				newTestSong("untagged", track, artist, album),
+			},
+			expectedTrack: domain.NewTrack(domain.SubsonicPlaylistType, song.ID(), "untagged", "subsonic:track:untagged", domain.ExactMatchMethod, 100, artist, track, album, "USSUBuntagged"),
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := context.Background()
+
+			searcher := NewMockTrackSearcher(t)
+			searcher.EXPECT().SearchSongs(ctx, artist+" "+track).Return(models.SearchResult3{Song: tc.searchResults}, nil)
+
+			provider := searchTrackProvider{
+				searcher: searcher,
+			}
+
+			actualTrack, err := provider.SearchTrack(ctx, song, isrc)
+			require.NoError(t, err)
+			assertTrack(t, tc.expectedTrack, actualTrack)
+		})
+	}
+}
+
+// assertTrack compares tracks ignoring when the tracks were matched.
+func assertTrack(t *testing.T, expected, actual domain.Track) {
+	t.Helper()
+
+	assert.Equal(t, expected.PlaylistType(), actual.PlaylistType())
+	assert.Equal(t, expected.SongID(), actual.SongID())
+	assert.Equal(t, expected.TrackID(), actual.TrackID())
+	assert.Equal(t, expected.URI(), actual.URI())
+	assert.Equal(t, expected.MatchFound(), actual.MatchFound())
+	assert.Equal(t, expected.MatchMethod(), actual.MatchMethod())
+	assert.InDelta(t, expected.Confidence(), actual.Confidence(), 0.01)
+	assert.Equal(t, expected.MatchedArtist(), actual.MatchedArtist())
+	assert.Equal(t, expected.MatchedTrack(), actual.MatchedTrack())
+	assert.Equal(t, expected.MatchedAlbum(), actual.MatchedAlbum())
+	assert.Equal(t, expected.ISRC(), actual.ISRC())
+}
@@ -0,0 +1,52 @@
+package models
+
+// Every Subsonic API response is wrapped in a subsonic-response object. Errors are returned
+// with a 200 status, so the status of the response body is checked instead.
+
+const (
+	OKStatus     = "ok"
+	FailedStatus = "failed"
+)
+
+type Response struct {
+	SubsonicResponse ResponseBody `json:"subsonic-response"`
+}
+
+type ResponseBody struct {
+	Status  string `json:"status"`
+	Version string `json:"version"`
+	// Error is set when the status is failed
+	Error *Error `json:"error,omitempty"`
+	// SearchResult3 is returned by search3
+	SearchResult3 SearchResult3 `json:"searchResult3"`
+	// Playlist is returned by getPlaylist and, since API version 1.14.0, createPlaylist
+	Playlist Playlist `json:"playlist"`
+}
+
+type Error struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
+
+type SearchResult3 struct {
+	Song []Song `json:"song"`
+}
+
+// Song is a song in the server's library. Only the fields used to match songs are decoded.
+type Song struct {
+	ID     string `json:"id"`
+	Title  string `json:"title"`
+	Artist string `json:"artist"`
+	Album  string `json:"album"`
+	// ISRC lists the recording's ISRCs. It is an OpenSubsonic extension, so servers
+	// without the extension leave it empty.
+	ISRC []string `json:"isrc"`
+}
+
+type Playlist struct {
+	ID        string `json:"id"`
+	Name      string `json:"name"`
+	SongCount int    `json:"songCount"`
+	// Entry lists the songs in the playlist, it's only returned by getPlaylist
+	Entry []Song `json:"entry"`
+}
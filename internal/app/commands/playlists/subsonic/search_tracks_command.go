@@ -0,0 +1,19 @@
+package subsonic
+
+import (
+	"github.com/jbenzshawel/playlist-generator/internal/app/commands/playlists/internal/tracks"
+	"github.com/jbenzshawel/playlist-generator/internal/app/commands/playlists/subsonic/internal/services"
+	"github.com/jbenzshawel/playlist-generator/internal/domain"
+)
+
+// SearchTracksCommand searches the Subsonic library for the songs that haven't been
+// searched for. Songs matched on other providers prefer results tagged with the match's ISRC.
+type SearchTracksCommand = tracks.SearchTracksCommand
+
+type SearchTracksCommandResult = tracks.SearchTracksCommandResult
+
+type SearchTracksCommandHandler = tracks.SearchTracksCommandHandler
+
+func NewSearchTracksCommand(searchService services.SearchService, repository domain.Repository) SearchTracksCommandHandler {
+	return tracks.NewSearchTracksCommand(domain.SubsonicPlaylistType, searchService, repository)
+}
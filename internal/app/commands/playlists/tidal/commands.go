@@ -0,0 +1,29 @@
+package tidal
+
+import (
+	"github.com/jbenzshawel/playlist-generator/internal/app/commands/playlists/tidal/internal/services"
+	"github.com/jbenzshawel/playlist-generator/internal/domain"
+)
+
+type Client interface {
+	services.Client
+}
+
+type Commands struct {
+	CreatePlaylist CreatePlaylistCommandHandler
+	SearchTracks   SearchTracksCommandHandler
+	SongPlaylists  SongPlaylistsCommandHandler
+	SyncPlaylist   SyncPlaylistCommandHandler
+}
+
+func NewCommands(client services.Client, repository domain.Repository, scoring domain.MatchScoring) Commands {
+	playlistService := services.NewPlaylistService(client)
+	searchService := services.NewSearchService(client, scoring)
+
+	return Commands{
+		CreatePlaylist: NewCreatePlaylistCommand(playlistService, repository),
+		SearchTracks:   NewSearchTracksCommand(searchService, repository),
+		SongPlaylists:  NewSongPlaylistsCommand(repository),
+		SyncPlaylist:   NewSyncPlaylistCommand(playlistService, repository),
+	}
+}
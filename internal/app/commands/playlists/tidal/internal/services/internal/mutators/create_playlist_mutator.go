@@ -0,0 +1,59 @@
+package mutators
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/jbenzshawel/playlist-generator/internal/app/commands/playlists/tidal/models"
+	"github.com/jbenzshawel/playlist-generator/internal/domain"
+)
+
+const playlistURLFormat = "https://tidal.com/browse/playlist/%s"
+
+type PlaylistCreator interface {
+	CreatePlaylist(ctx context.Context, request models.PlaylistDocument) (models.Playlist, error)
+}
+
+type CreatePlaylistMutator interface {
+	// CreatePlaylist creates a playlist for a source and date. The date must be formatted
+	// for the date scope, see domain.PlaylistDateScope.Format.
+	CreatePlaylist(ctx context.Context, name, date string, dateScope domain.PlaylistDateScope, sourceType domain.SourceType, programName string) (domain.Playlist, error)
+}
+
+func NewCreatePlaylistMutator(creator PlaylistCreator) CreatePlaylistMutator {
+	return &createPlaylistMutator{
+		creator: creator,
+	}
+}
+
+type createPlaylistMutator struct {
+	creator PlaylistCreator
+}
+
+func (c *createPlaylistMutator) CreatePlaylist(ctx context.Context, name, date string, dateScope domain.PlaylistDateScope, sourceType domain.SourceType, programName string) (domain.Playlist, error) {
+	playlist, err := c.creator.CreatePlaylist(ctx, models.PlaylistDocument{
+		Data: models.Playlist{
+			Type: models.PlaylistsResourceType,
+			Attributes: models.PlaylistAttributes{
+				Name:       name,
+				AccessType: models.UnlistedPlaylistAccessType,
+			},
+		},
+	})
+	if err != nil {
+		return domain.Playlist{}, err
+	}
+
+	p := domain.NewPlaylist(
+		playlist.ID,
+		fmt.Sprintf(playlistURLFormat, playlist.ID),
+		playlist.Attributes.Name,
+		date,
+		dateScope,
+		domain.TidalPlaylistType,
+		sourceType,
+		programName,
+	)
+
+	return p, nil
+}
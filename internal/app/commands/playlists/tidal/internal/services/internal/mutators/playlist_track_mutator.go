@@ -0,0 +1,52 @@
+package mutators
+
+import (
+	"context"
+
+	"github.com/jbenzshawel/playlist-generator/internal/app/commands/playlists/tidal/models"
+)
+
+// batchSize is the max number of tracks added to a playlist in one request
+const batchSize = 20
+
+type TrackAdder interface {
+	AddPlaylistItems(ctx context.Context, playlistID string, request models.AddPlaylistItemsRequest) error
+}
+
+type PlaylistTrackMutator interface {
+	// AddTracks appends tracks to a playlist.
+	AddTracks(ctx context.Context, playlistID string, trackIDs []string) error
+}
+
+type playlistTrackMutator struct {
+	adder TrackAdder
+}
+
+func NewPlaylistTrackMutator(adder TrackAdder) PlaylistTrackMutator {
+	return &playlistTrackMutator{
+		adder: adder,
+	}
+}
+
+func (p *playlistTrackMutator) AddTracks(ctx context.Context, playlistID string, trackIDs []string) error {
+	for offset := 0; offset < len(trackIDs); offset += batchSize {
+		batch := trackIDs[offset:min(offset+batchSize, len(trackIDs))]
+
+		items := make([]models.ResourceIdentifier, len(batch))
+		for idx, trackID := range batch {
+			items[idx] = models.ResourceIdentifier{
+				ID:   trackID,
+				Type: models.TracksResourceType,
+			}
+		}
+
+		err := p.adder.AddPlaylistItems(ctx, playlistID, models.AddPlaylistItemsRequest{
+			Data: items,
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,403 @@
+// Code generated by mockery; DO NOT EDIT.
+// github.com/vektra/mockery
+// template: testify
+
+package providers
+
+import (
+	"context"
+
+	mock "github.com/stretchr/testify/mock"
+
+	"github.com/jbenzshawel/playlist-generator/internal/app/commands/playlists/tidal/models"
+)
+
+// NewMockTrackGetter creates a new instance of MockTrackGetter. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockTrackGetter(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockTrackGetter {
+	mock := &MockTrackGetter{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
+
+// MockTrackGetter is an autogenerated mock type for the TrackGetter type
+type MockTrackGetter struct {
+	mock.Mock
+}
+
+type MockTrackGetter_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockTrackGetter) EXPECT() *MockTrackGetter_Expecter {
+	return &MockTrackGetter_Expecter{mock: &_m.Mock}
+}
+
+// GetPlaylistItems provides a mock function for the type MockTrackGetter
+func (_mock *MockTrackGetter) GetPlaylistItems(ctx context.Context, playlistID string, cursor string) (models.RelationshipDocument, error) {
+	ret := _mock.Called(ctx, playlistID, cursor)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetPlaylistItems")
+	}
+
+	var r0 models.RelationshipDocument
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string) (models.RelationshipDocument, error)); ok {
+		return returnFunc(ctx, playlistID, cursor)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string) models.RelationshipDocument); ok {
+		r0 = returnFunc(ctx, playlistID, cursor)
+	} else {
+		r0 = ret.Get(0).(models.RelationshipDocument)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = returnFunc(ctx, playlistID, cursor)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockTrackGetter_GetPlaylistItems_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetPlaylistItems'
+type MockTrackGetter_GetPlaylistItems_Call struct {
+	*mock.Call
+}
+
+// GetPlaylistItems is a helper method to define mock.On call
+//   - ctx context.Context
+//   - playlistID string
+//   - cursor string
+func (_e *MockTrackGetter_Expecter) GetPlaylistItems(ctx interface{}, playlistID interface{}, cursor interface{}) *MockTrackGetter_GetPlaylistItems_Call {
+	return &MockTrackGetter_GetPlaylistItems_Call{Call: _e.mock.On("GetPlaylistItems", ctx, playlistID, cursor)}
+}
+
+func (_c *MockTrackGetter_GetPlaylistItems_Call) Run(run func(ctx context.Context, playlistID string, cursor string)) *MockTrackGetter_GetPlaylistItems_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockTrackGetter_GetPlaylistItems_Call) Return(relationshipDocument models.RelationshipDocument, err error) *MockTrackGetter_GetPlaylistItems_Call {
+	_c.Call.Return(relationshipDocument, err)
+	return _c
+}
+
+func (_c *MockTrackGetter_GetPlaylistItems_Call) RunAndReturn(run func(ctx context.Context, playlistID string, cursor string) (models.RelationshipDocument, error)) *MockTrackGetter_GetPlaylistItems_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockTrackSearcher creates a new instance of MockTrackSearcher. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockTrackSearcher(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockTrackSearcher {
+	mock := &MockTrackSearcher{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
+
+// MockTrackSearcher is an autogenerated mock type for the TrackSearcher type
+type MockTrackSearcher struct {
+	mock.Mock
+}
+
+type MockTrackSearcher_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockTrackSearcher) EXPECT() *MockTrackSearcher_Expecter {
+	return &MockTrackSearcher_Expecter{mock: &_m.Mock}
+}
+
+// GetAlbumsByBarcodeID provides a mock function for the type MockTrackSearcher
+func (_mock *MockTrackSearcher) GetAlbumsByBarcodeID(ctx context.Context, barcodeID string) (models.AlbumDocument, error) {
+	ret := _mock.Called(ctx, barcodeID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetAlbumsByBarcodeID")
+	}
+
+	var r0 models.AlbumDocument
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) (models.AlbumDocument, error)); ok {
+		return returnFunc(ctx, barcodeID)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) models.AlbumDocument); ok {
+		r0 = returnFunc(ctx, barcodeID)
+	} else {
+		r0 = ret.Get(0).(models.AlbumDocument)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = returnFunc(ctx, barcodeID)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockTrackSearcher_GetAlbumsByBarcodeID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetAlbumsByBarcodeID'
+type MockTrackSearcher_GetAlbumsByBarcodeID_Call struct {
+	*mock.Call
+}
+
+// GetAlbumsByBarcodeID is a helper method to define mock.On call
+//   - ctx context.Context
+//   - barcodeID string
+func (_e *MockTrackSearcher_Expecter) GetAlbumsByBarcodeID(ctx interface{}, barcodeID interface{}) *MockTrackSearcher_GetAlbumsByBarcodeID_Call {
+	return &MockTrackSearcher_GetAlbumsByBarcodeID_Call{Call: _e.mock.On("GetAlbumsByBarcodeID", ctx, barcodeID)}
+}
+
+func (_c *MockTrackSearcher_GetAlbumsByBarcodeID_Call) Run(run func(ctx context.Context, barcodeID string)) *MockTrackSearcher_GetAlbumsByBarcodeID_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockTrackSearcher_GetAlbumsByBarcodeID_Call) Return(albumDocument models.AlbumDocument, err error) *MockTrackSearcher_GetAlbumsByBarcodeID_Call {
+	_c.Call.Return(albumDocument, err)
+	return _c
+}
+
+func (_c *MockTrackSearcher_GetAlbumsByBarcodeID_Call) RunAndReturn(run func(ctx context.Context, barcodeID string) (models.AlbumDocument, error)) *MockTrackSearcher_GetAlbumsByBarcodeID_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetTracks provides a mock function for the type MockTrackSearcher
+func (_mock *MockTrackSearcher) GetTracks(ctx context.Context, trackIDs []string) (models.TrackDocument, error) {
+	ret := _mock.Called(ctx, trackIDs)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetTracks")
+	}
+
+	var r0 models.TrackDocument
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, []string) (models.TrackDocument, error)); ok {
+		return returnFunc(ctx, trackIDs)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, []string) models.TrackDocument); ok {
+		r0 = returnFunc(ctx, trackIDs)
+	} else {
+		r0 = ret.Get(0).(models.TrackDocument)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, []string) error); ok {
+		r1 = returnFunc(ctx, trackIDs)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockTrackSearcher_GetTracks_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetTracks'
+type MockTrackSearcher_GetTracks_Call struct {
+	*mock.Call
+}
+
+// GetTracks is a helper method to define mock.On call
+//   - ctx context.Context
+//   - trackIDs []string
+func (_e *MockTrackSearcher_Expecter) GetTracks(ctx interface{}, trackIDs interface{}) *MockTrackSearcher_GetTracks_Call {
+	return &MockTrackSearcher_GetTracks_Call{Call: _e.mock.On("GetTracks", ctx, trackIDs)}
+}
+
+func (_c *MockTrackSearcher_GetTracks_Call) Run(run func(ctx context.Context, trackIDs []string)) *MockTrackSearcher_GetTracks_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 []string
+		if args[1] != nil {
+			arg1 = args[1].([]string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockTrackSearcher_GetTracks_Call) Return(trackDocument models.TrackDocument, err error) *MockTrackSearcher_GetTracks_Call {
+	_c.Call.Return(trackDocument, err)
+	return _c
+}
+
+func (_c *MockTrackSearcher_GetTracks_Call) RunAndReturn(run func(ctx context.Context, trackIDs []string) (models.TrackDocument, error)) *MockTrackSearcher_GetTracks_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetTracksByISRC provides a mock function for the type MockTrackSearcher
+func (_mock *MockTrackSearcher) GetTracksByISRC(ctx context.Context, isrc string) (models.TrackDocument, error) {
+	ret := _mock.Called(ctx, isrc)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetTracksByISRC")
+	}
+
+	var r0 models.TrackDocument
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) (models.TrackDocument, error)); ok {
+		return returnFunc(ctx, isrc)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) models.TrackDocument); ok {
+		r0 = returnFunc(ctx, isrc)
+	} else {
+		r0 = ret.Get(0).(models.TrackDocument)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = returnFunc(ctx, isrc)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockTrackSearcher_GetTracksByISRC_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetTracksByISRC'
+type MockTrackSearcher_GetTracksByISRC_Call struct {
+	*mock.Call
+}
+
+// GetTracksByISRC is a helper method to define mock.On call
+//   - ctx context.Context
+//   - isrc string
+func (_e *MockTrackSearcher_Expecter) GetTracksByISRC(ctx interface{}, isrc interface{}) *MockTrackSearcher_GetTracksByISRC_Call {
+	return &MockTrackSearcher_GetTracksByISRC_Call{Call: _e.mock.On("GetTracksByISRC", ctx, isrc)}
+}
+
+func (_c *MockTrackSearcher_GetTracksByISRC_Call) Run(run func(ctx context.Context, isrc string)) *MockTrackSearcher_GetTracksByISRC_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockTrackSearcher_GetTracksByISRC_Call) Return(trackDocument models.TrackDocument, err error) *MockTrackSearcher_GetTracksByISRC_Call {
+	_c.Call.Return(trackDocument, err)
+	return _c
+}
+
+func (_c *MockTrackSearcher_GetTracksByISRC_Call) RunAndReturn(run func(ctx context.Context, isrc string) (models.TrackDocument, error)) *MockTrackSearcher_GetTracksByISRC_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SearchTracks provides a mock function for the type MockTrackSearcher
+func (_mock *MockTrackSearcher) SearchTracks(ctx context.Context, query string) (models.RelationshipDocument, error) {
+	ret := _mock.Called(ctx, query)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SearchTracks")
+	}
+
+	var r0 models.RelationshipDocument
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) (models.RelationshipDocument, error)); ok {
+		return returnFunc(ctx, query)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) models.RelationshipDocument); ok {
+		r0 = returnFunc(ctx, query)
+	} else {
+		r0 = ret.Get(0).(models.RelationshipDocument)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = returnFunc(ctx, query)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockTrackSearcher_SearchTracks_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SearchTracks'
+type MockTrackSearcher_SearchTracks_Call struct {
+	*mock.Call
+}
+
+// SearchTracks is a helper method to define mock.On call
+//   - ctx context.Context
+//   - query string
+func (_e *MockTrackSearcher_Expecter) SearchTracks(ctx interface{}, query interface{}) *MockTrackSearcher_SearchTracks_Call {
+	return &MockTrackSearcher_SearchTracks_Call{Call: _e.mock.On("SearchTracks", ctx, query)}
+}
+
+func (_c *MockTrackSearcher_SearchTracks_Call) Run(run func(ctx context.Context, query string)) *MockTrackSearcher_SearchTracks_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockTrackSearcher_SearchTracks_Call) Return(relationshipDocument models.RelationshipDocument, err error) *MockTrackSearcher_SearchTracks_Call {
+	_c.Call.Return(relationshipDocument, err)
+	return _c
+}
+
+func (_c *MockTrackSearcher_SearchTracks_Call) RunAndReturn(run func(ctx context.Context, query string) (models.RelationshipDocument, error)) *MockTrackSearcher_SearchTracks_Call {
+	_c.Call.Return(run)
+	return _c
+}
@@ -0,0 +1,76 @@
+package providers
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"net/url"
+
+	"github.com/jbenzshawel/playlist-generator/internal/app/commands/playlists/tidal/models"
+)
+
+type TrackGetter interface {
+	GetPlaylistItems(ctx context.Context, playlistID, cursor string) (models.RelationshipDocument, error)
+}
+
+type PlaylistTrackProvider interface {
+	// GetTrackIDs returns the IDs of the tracks in a playlist.
+	GetTrackIDs(ctx context.Context, playlistID string) ([]string, error)
+}
+
+func NewPlaylistTrackProvider(getter TrackGetter) PlaylistTrackProvider {
+	return &playlistTrackProvider{
+		getter: getter,
+	}
+}
+
+type playlistTrackProvider struct {
+	getter TrackGetter
+}
+
+func (p *playlistTrackProvider) GetTrackIDs(ctx context.Context, playlistID string) ([]string, error) {
+	var trackIDs []string
+
+	// pages are linked by a cursor, so they are loaded in order
+	for cursor := ""; ; {
+		page, err := p.getter.GetPlaylistItems(ctx, playlistID, cursor)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, item := range page.Data {
+			// playlists can also include videos
+			if item.Type == models.TracksResourceType {
+				trackIDs = append(trackIDs, item.ID)
+			}
+		}
+
+		if page.Links.Next == "" || len(page.Data) == 0 {
+			break
+		}
+
+		cursor, err = nextCursor(page.Links.Next)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	slog.Debug("retrieved tracks for playlist", slog.Int("total", len(trackIDs)))
+
+	return trackIDs, nil
+}
+
+// nextCursor returns the page cursor from a next page link.
+func nextCursor(next string) (string, error) {
+	nextURL, err := url.Parse(next)
+	if err != nil {
+		return "", fmt.Errorf("failed to parse next page link: %w", err)
+	}
+
+	cursor := nextURL.Query().Get("page[cursor]")
+	if cursor == "" {
+		return "", fmt.Errorf("next page link %q missing cursor", next)
+	}
+
+	return cursor, nil
+}
@@ -0,0 +1,87 @@
+package providers
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/jbenzshawel/playlist-generator/internal/app/commands/playlists/tidal/models"
+)
+
+func TestPlaylistTrackProvider_GetTrackIDs(t *testing.T) {
+	const (
+		total      = 45
+		pageSize   = 20
+		playlistID = "testPlaylistID"
+	)
+
+	ctx := context.Background()
+
+	mockGetter := NewMockTrackGetter(t)
+	for idx, page := range getTestItemPages(playlistID, total, pageSize) {
+		cursor := ""
+		if idx > 0 {
+			cursor = fmt.Sprintf("cursor%d", idx)
+		}
+		mockGetter.EXPECT().GetPlaylistItems(mock.Anything, playlistID, cursor).Return(page, nil)
+	}
+
+	p := NewPlaylistTrackProvider(mockGetter)
+
+	actualTrackIDs, err := p.GetTrackIDs(ctx, playlistID)
+	require.NoError(t, err)
+
+	trackIDs := map[string]struct{}{}
+	for _, trackID := range actualTrackIDs {
+		trackIDs[trackID] = struct{}{}
+	}
+	assert.Len(t, trackIDs, total)
+}
+
+func TestPlaylistTrackProvider_GetTrackIDs_SkipsVideos(t *testing.T) {
+	const playlistID = "testPlaylistID"
+
+	ctx := context.Background()
+
+	page := models.RelationshipDocument{
+		Data: []models.ResourceIdentifier{
+			{ID: "1234567", Type: models.TracksResourceType},
+			{ID: "7654321", Type: "videos"},
+		},
+	}
+
+	mockGetter := NewMockTrackGetter(t)
+	mockGetter.EXPECT().GetPlaylistItems(mock.Anything, playlistID, "").Return(page, nil)
+
+	p := NewPlaylistTrackProvider(mockGetter)
+
+	actualTrackIDs, err := p.GetTrackIDs(ctx, playlistID)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"1234567"}, actualTrackIDs)
+}
+
+func getTestItemPages(playlistID string, numTracks, pageSize int) []models.RelationshipDocument {
+	numPages := (numTracks + pageSize - 1) / pageSize
+
+	pages := make([]models.RelationshipDocument, numPages)
+	for pageIdx := 0; pageIdx < numPages; pageIdx++ {
+		size := min(pageSize, numTracks-pageIdx*pageSize)
+
+		for trackIdx := 0; trackIdx < size; trackIdx++ {
+			pages[pageIdx].Data = append(pages[pageIdx].Data, models.ResourceIdentifier{
+				ID:   fmt.Sprintf("%d-%d", pageIdx, trackIdx),
+				Type: models.TracksResourceType,
+			})
+		}
+
+		if pageIdx < numPages-1 {
+			pages[pageIdx].Links.Next = fmt.Sprintf("/playlists/%s/relationships/items?countryCode=US&page%%5Bcursor%%5D=cursor%d", playlistID, pageIdx+1)
+		}
+	}
+
+	return pages
+}
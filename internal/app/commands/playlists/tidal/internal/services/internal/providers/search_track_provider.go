@@ -0,0 +1,210 @@
+package providers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+
+	"github.com/jbenzshawel/playlist-generator/internal/app/commands/playlists/internal/matching"
+	"github.com/jbenzshawel/playlist-generator/internal/app/commands/playlists/tidal/models"
+	"github.com/jbenzshawel/playlist-generator/internal/domain"
+)
+
+const (
+	// maxSearchResults is the number of search results looked up and scored
+	maxSearchResults = 20
+
+	trackURLFormat = "https://tidal.com/browse/track/%s"
+)
+
+type TrackSearcher interface {
+	GetTracksByISRC(ctx context.Context, isrc string) (models.TrackDocument, error)
+	GetTracks(ctx context.Context, trackIDs []string) (models.TrackDocument, error)
+	SearchTracks(ctx context.Context, query string) (models.RelationshipDocument, error)
+	GetAlbumsByBarcodeID(ctx context.Context, barcodeID string) (models.AlbumDocument, error)
+}
+
+type SearchTrackProvider interface {
+	// SearchTrack returns the Tidal track that best matches a song. The ISRC is from the
+	// song's match on another provider, or empty when the song hasn't been matched.
+	SearchTrack(ctx context.Context, song domain.Song, isrc string) (domain.Track, error)
+}
+
+func NewSearchTrackProvider(s TrackSearcher, scoring domain.MatchScoring) SearchTrackProvider {
+	return &searchTrackProvider{
+		searcher: s,
+		scoring:  scoring,
+	}
+}
+
+type searchTrackProvider struct {
+	searcher TrackSearcher
+	scoring  domain.MatchScoring
+}
+
+func (s *searchTrackProvider) SearchTrack(ctx context.Context, song domain.Song, isrc string) (domain.Track, error) {
+	// Identifiers are more reliable than names, fuzzy search is only used as a fallback
+	track, err := s.searchISRC(ctx, song, isrc)
+	if !errors.Is(err, matching.ErrTrackNotFound) {
+		return track, err
+	}
+
+	track, err = s.searchBarcode(ctx, song)
+	if !errors.Is(err, matching.ErrTrackNotFound) {
+		return track, err
+	}
+
+	results, err := s.searcher.SearchTracks(ctx, song.Artist()+" "+song.Track())
+	if err != nil {
+		return domain.Track{}, err
+	}
+
+	ids := trackIDs(results.Data[:min(len(results.Data), maxSearchResults)])
+	if len(ids) == 0 {
+		return domain.Track{}, matching.ErrTrackNotFound
+	}
+
+	doc, err := s.searcher.GetTracks(ctx, ids)
+	if err != nil {
+		return domain.Track{}, err
+	}
+
+	if len(doc.Data) == 0 {
+		return domain.Track{}, matching.ErrTrackNotFound
+	}
+
+	slog.Debug("tidal search tracks found", slog.Int("count", len(doc.Data)))
+
+	return matching.FindTrackMatch(domain.TidalPlaylistType, s.scoring, newCandidates(doc), song)
+}
+
+// searchISRC returns the track of the recording with the ISRC on the album closest to
+// the song's album.
+func (s *searchTrackProvider) searchISRC(ctx context.Context, song domain.Song, isrc string) (domain.Track, error) {
+	if isrc == "" {
+		return domain.Track{}, matching.ErrTrackNotFound
+	}
+
+	doc, err := s.searcher.GetTracksByISRC(ctx, isrc)
+	if err != nil {
+		return domain.Track{}, err
+	}
+
+	if len(doc.Data) == 0 {
+		return domain.Track{}, matching.ErrTrackNotFound
+	}
+
+	best := matching.RankMatches(s.scoring, newCandidates(doc), song)[0]
+
+	slog.Debug("isrc match track found", slog.Any("match", best.Candidate))
+
+	return best.Track(domain.TidalPlaylistType, song, domain.ISRCMatchMethod), nil
+}
+
+// searchBarcode searches for the tracks on the album with the song's UPC and returns the
+// track with the closest matching name.
+func (s *searchTrackProvider) searchBarcode(ctx context.Context, song domain.Song) (domain.Track, error) {
+	if song.UPC() == "" {
+		return domain.Track{}, matching.ErrTrackNotFound
+	}
+
+	albums, err := s.searcher.GetAlbumsByBarcodeID(ctx, song.UPC())
+	if err != nil {
+		return domain.Track{}, err
+	}
+
+	titles := make(map[string]string, len(albums.Included))
+	for _, r := range albums.Included {
+		if r.Type == models.TracksResourceType {
+			titles[r.ID] = r.Attributes.Title
+		}
+	}
+
+	var (
+		bestID      string
+		bestPercent float64
+	)
+	for _, album := range albums.Data {
+		for _, item := range album.Relationships.Items.Data {
+			// albums also include videos
+			if item.Type != models.TracksResourceType {
+				continue
+			}
+
+			percent := matching.TitleSimilarity(s.scoring, song.Track(), titles[item.ID])
+			if percent > bestPercent {
+				bestID, bestPercent = item.ID, percent
+			}
+		}
+	}
+
+	if bestPercent < matching.MinMatchPercent {
+		return domain.Track{}, matching.ErrTrackNotFound
+	}
+
+	doc, err := s.searcher.GetTracks(ctx, []string{bestID})
+	if err != nil {
+		return domain.Track{}, err
+	}
+
+	candidates := newCandidates(doc)
+	if len(candidates) == 0 {
+		return domain.Track{}, matching.ErrTrackNotFound
+	}
+
+	slog.Debug("upc match track found", slog.Any("match", candidates[0]))
+
+	return candidates[0].Track(domain.TidalPlaylistType, song, domain.UPCMatchMethod, bestPercent), nil
+}
+
+// newCandidates returns the tracks with the names of their included artists and album.
+func newCandidates(doc models.TrackDocument) []matching.Candidate {
+	included := make(map[models.ResourceIdentifier]models.Resource, len(doc.Included))
+	for _, r := range doc.Included {
+		included[models.ResourceIdentifier{ID: r.ID, Type: r.Type}] = r
+	}
+
+	candidates := make([]matching.Candidate, 0, len(doc.Data))
+	for _, t := range doc.Data {
+		c := matching.Candidate{
+			ID:    t.ID,
+			URI:   fmt.Sprintf(trackURLFormat, t.ID),
+			Title: t.Attributes.Title,
+			ISRC:  t.Attributes.ISRC,
+		}
+
+		// the version is shown after the title, for example "Never There (Live)"
+		if t.Attributes.Version != "" {
+			c.Title = fmt.Sprintf("%s (%s)", c.Title, t.Attributes.Version)
+		}
+
+		for _, a := range t.Relationships.Artists.Data {
+			if artist, ok := included[a]; ok {
+				c.Artists = append(c.Artists, artist.Attributes.Name)
+			}
+		}
+
+		for _, a := range t.Relationships.Albums.Data {
+			if album, ok := included[a]; ok {
+				c.Album = album.Attributes.Title
+				break
+			}
+		}
+
+		candidates = append(candidates, c)
+	}
+
+	return candidates
+}
+
+func trackIDs(identifiers []models.ResourceIdentifier) []string {
+	ids := make([]string, 0, len(identifiers))
+	for _, i := range identifiers {
+		if i.Type == models.TracksResourceType {
+			ids = append(ids, i.ID)
+		}
+	}
+
+	return ids
+}
@@ -0,0 +1,300 @@
+package providers
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/jbenzshawel/playlist-generator/internal/app/commands/playlists/internal/matching"
+	"github.com/jbenzshawel/playlist-generator/internal/app/commands/playlists/tidal/models"
+	"github.com/jbenzshawel/playlist-generator/internal/domain"
+)
+
+type testTrack struct {
+	id      string
+	title   string
+	version string
+	artist  string
+	album   string
+}
+
+// newTestTrackDocument returns a track document with the artists and albums of the
+// tracks included.
+func newTestTrackDocument(tracks ...testTrack) models.TrackDocument {
+	var doc models.TrackDocument
+	for _, tt := range tracks {
+		artist := models.ResourceIdentifier{ID: "artist-" + tt.id, Type: "artists"}
+		album := models.ResourceIdentifier{ID: "album-" + tt.id, Type: "albums"}
+
+		t := models.Track{
+			ID:   tt.id,
+			Type: models.TracksResourceType,
+		}
+		t.Attributes.Title = tt.title
+		t.Attributes.Version = tt.version
+		t.Attributes.ISRC = "USTD1" + tt.id
+		t.Relationships.Artists.Data = []models.ResourceIdentifier{artist}
+		t.Relationships.Albums.Data = []models.ResourceIdentifier{album}
+		doc.Data = append(doc.Data, t)
+
+		a := models.Resource{ID: artist.ID, Type: artist.Type}
+		a.Attributes.Name = tt.artist
+		b := models.Resource{ID: album.ID, Type: album.Type}
+		b.Attributes.Title = tt.album
+		doc.Included = append(doc.Included, a, b)
+	}
+
+	return doc
+}
+
+func newTestSearchResults(trackIDs ...string) models.RelationshipDocument {
+	var doc models.RelationshipDocument
+	for _, id := range trackIDs {
+		doc.Data = append(doc.Data, models.ResourceIdentifier{ID: id, Type: models.TracksResourceType})
+	}
+
+	return doc
+}
+
+func TestSearchTrackProvider_SearchTrack(t *testing.T) {
+	const (
+		artist = "Cake"
+		track  = "Never There"
+		album  = "Prolonging The Magic"
+
+		trackID = "1234567"
+		url     = "https://tidal.com/browse/track/" + trackID
+	)
+
+	song, err := domain.NewSong(artist, track, album, "")
+	require.NoError(t, err)
+
+	testCases := []struct {
+		name          string
+		searchResults []testTrack
+		expectedTrack domain.Track
+		expectedErr   error
+	}{
+		{
+			name: "single result",
+			searchResults: []testTrack{
+				{id: trackID, title: track, artist: artist, album: album},
+			},
+			expectedTrack: domain.NewTrack(domain.TidalPlaylistType, song.ID(), trackID, url, domain.SingleResultMatchMethod, 100, artist, track, album, "USTD1"+trackID),
+		},
+		{
+			name: "single result below threshold",
+			searchResults: []testTrack{
+				{id: trackID, title: "Satan Is My Motor", artist: artist, album: "Fashion Nugget"},
+			},
+			expectedErr: matching.ErrMatchBelowThreshold,
+		},
+		{
+			name: "exact match",
+			searchResults: []testTrack{
+				{id: "live", title: track, version: "Live", artist: artist, album: "Live at the Fillmore"},
+				{id: trackID, title: track, artist: artist, album: album},
+			},
+			expectedTrack: domain.NewTrack(domain.TidalPlaylistType, song.ID(), trackID, url, domain.ExactMatchMethod, 100, artist, track, album, "USTD1"+trackID),
+		},
+		{
+			name: "fuzzy match",
+			searchResults: []testTrack{
+				{id: "other", title: "Satan Is My Motor", artist: artist, album: album},
+				{id: trackID, title: track, artist: "CAKE", album: "Prolonging Magic"},
+			},
+			expectedTrack: domain.NewTrack(domain.TidalPlaylistType, song.ID(), trackID, url, domain.FuzzyMatchMethod, 95, "CAKE", track, "Prolonging Magic", "USTD1"+trackID),
+		},
+		{
+			name: "fuzzy match below threshold",
+			searchResults: []testTrack{
+				{id: "other", title: "Satan Is My Motor", artist: artist, album: "Fashion Nugget"},
+				{id: "another", title: "The Distance", artist: artist, album: "Fashion Nugget"},
+			},
+			expectedErr: matching.ErrMatchBelowThreshold,
+		},
+		{
+			name:        "no results",
+			expectedErr: matching.ErrTrackNotFound,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := context.Background()
+
+			var ids []string
+			for _, r := range tc.searchResults {
+				ids = append(ids, r.id)
+			}
+
+			searcher := NewMockTrackSearcher(t)
+			searcher.EXPECT().SearchTracks(ctx, artist+" "+track).Return(newTestSearchResults(ids...), nil)
+			if len(ids) > 0 {
+				searcher.EXPECT().GetTracks(ctx, ids).Return(newTestTrackDocument(tc.searchResults...), nil)
+			}
+
+			provider := searchTrackProvider{
+				searcher: searcher,
+			}
+
+			actualTrack, err := provider.SearchTrack(ctx, song, "")
+			if tc.expectedErr != nil {
+				assert.ErrorIs(t, err, tc.expectedErr)
+				return
+			}
+
+			require.NoError(t, err)
+			assertTrack(t, tc.expectedTrack, actualTrack)
+		})
+	}
+}
+
+func TestSearchTrackProvider_SearchTrack_ISRC(t *testing.T) {
+	const (
+		artist = "Cake"
+		track  = "Never There"
+		album  = "Prolonging The Magic"
+		isrc   = "USTD1album"
+	)
+
+	song, err := domain.NewSong(artist, track, album, "")
+	require.NoError(t, err)
+
+	t.Run("track on the closest album", func(t *testing.T) {
+		ctx := context.Background()
+
+		searcher := NewMockTrackSearcher(t)
+		searcher.EXPECT().GetTracksByISRC(ctx, isrc).Return(newTestTrackDocument(
+			testTrack{id: "compilation", title: track, artist: artist, album: "The Best Of Cake"},
+			testTrack{id: "album", title: track, artist: artist, album: album},
+		), nil)
+
+		provider := searchTrackProvider{
+			searcher: searcher,
+		}
+
+		actualTrack, err := provider.SearchTrack(ctx, song, isrc)
+		require.NoError(t, err)
+		assertTrack(t,
+			domain.NewTrack(domain.TidalPlaylistType, song.ID(), "album", "https://tidal.com/browse/track/album", domain.ISRCMatchMethod, 100, artist, track, album, isrc),
+			actualTrack,
+		)
+	})
+
+	t.Run("isrc not found falls back to search", func(t *testing.T) {
+		ctx := context.Background()
+
+		searcher := NewMockTrackSearcher(t)
+		searcher.EXPECT().GetTracksByISRC(ctx, isrc).Return(models.TrackDocument{}, nil)
+		searcher.EXPECT().SearchTracks(ctx, artist+" "+track).Return(newTestSearchResults("fuzzy"), nil)
+		searcher.EXPECT().GetTracks(ctx, []string{"fuzzy"}).Return(newTestTrackDocument(
+			testTrack{id: "fuzzy", title: track, artist: artist, album: album},
+		), nil)
+
+		provider := searchTrackProvider{
+			searcher: searcher,
+		}
+
+		actualTrack, err := provider.SearchTrack(ctx, song, isrc)
+		require.NoError(t, err)
+		assert.Equal(t, domain.SingleResultMatchMethod, actualTrack.MatchMethod())
+		assert.Equal(t, "fuzzy", actualTrack.TrackID())
+	})
+}
+
+func TestSearchTrackProvider_SearchTrack_Barcode(t *testing.T) {
+	const (
+		artist = "Cake"
+		track  = "Never There"
+		album  = "Prolonging The Magic"
+		upc    = "008811121520"
+
+		trackID = "1234567"
+	)
+
+	song, err := domain.NewSong(artist, track, album, upc)
+	require.NoError(t, err)
+
+	newAlbumDocument := func(items ...models.Resource) models.AlbumDocument {
+		a := models.Album{ID: "album", Type: "albums"}
+		a.Attributes.Title = album
+		a.Attributes.BarcodeID = upc
+		for _, item := range items {
+			a.Relationships.Items.Data = append(a.Relationships.Items.Data, models.ResourceIdentifier{ID: item.ID, Type: item.Type})
+		}
+
+		return models.AlbumDocument{Data: []models.Album{a}, Included: items}
+	}
+
+	newItem := func(id, itemType, title string) models.Resource {
+		r := models.Resource{ID: id, Type: itemType}
+		r.Attributes.Title = title
+		return r
+	}
+
+	t.Run("barcode match", func(t *testing.T) {
+		ctx := context.Background()
+
+		searcher := NewMockTrackSearcher(t)
+		searcher.EXPECT().GetAlbumsByBarcodeID(ctx, upc).Return(newAlbumDocument(
+			newItem("video", "videos", track),
+			newItem("other", models.TracksResourceType, "Satan Is My Motor"),
+			newItem(trackID, models.TracksResourceType, track),
+		), nil)
+		searcher.EXPECT().GetTracks(ctx, []string{trackID}).Return(newTestTrackDocument(
+			testTrack{id: trackID, title: track, artist: artist, album: album},
+		), nil)
+
+		provider := searchTrackProvider{
+			searcher: searcher,
+		}
+
+		actualTrack, err := provider.SearchTrack(ctx, song, "")
+		require.NoError(t, err)
+		assertTrack(t,
+			domain.NewTrack(domain.TidalPlaylistType, song.ID(), trackID, "https://tidal.com/browse/track/"+trackID, domain.UPCMatchMethod, 100, artist, track, album, "USTD1"+trackID),
+			actualTrack,
+		)
+	})
+
+	t.Run("track name mismatch falls back to search", func(t *testing.T) {
+		ctx := context.Background()
+
+		searcher := NewMockTrackSearcher(t)
+		searcher.EXPECT().GetAlbumsByBarcodeID(ctx, upc).Return(newAlbumDocument(
+			newItem(trackID, models.TracksResourceType, "Satan Is My Motor"),
+		), nil)
+		searcher.EXPECT().SearchTracks(ctx, artist+" "+track).Return(newTestSearchResults("fuzzy"), nil)
+		searcher.EXPECT().GetTracks(ctx, []string{"fuzzy"}).Return(newTestTrackDocument(
+			testTrack{id: "fuzzy", title: track, artist: artist, album: album},
+		), nil)
+
+		provider := searchTrackProvider{
+			searcher: searcher,
+		}
+
+		actualTrack, err := provider.SearchTrack(ctx, song, "")
+		require.NoError(t, err)
+		assert.Equal(t, domain.SingleResultMatchMethod, actualTrack.MatchMethod())
+		assert.Equal(t, "fuzzy", actualTrack.TrackID())
+	})
+}
+
+// assertTrack compares tracks ignoring when the tracks were matched.
+func assertTrack(t *testing.T, expected, actual domain.Track) {
+	t.Helper()
+
+	assert.Equal(t, expected.PlaylistType(), actual.PlaylistType())
+	assert.Equal(t, expected.SongID(), actual.SongID())
+	assert.Equal(t, expected.TrackID(), actual.TrackID())
+	assert.Equal(t, expected.URI(), actual.URI())
+	assert.Equal(t, expected.MatchFound(), actual.MatchFound())
+	assert.Equal(t, expected.MatchMethod(), actual.MatchMethod())
+	assert.InDelta(t, expected.Confidence(), actual.Confidence(), 0.01)
+	assert.Equal(t, expected.MatchedArtist(), actual.MatchedArtist())
+	assert.Equal(t, expected.MatchedTrack(), actual.MatchedTrack())
+	assert.Equal(t, expected.MatchedAlbum(), actual.MatchedAlbum())
+	assert.Equal(t, expected.ISRC(), actual.ISRC())
+}
@@ -0,0 +1,122 @@
+package models
+
+// The Tidal API follows JSON:API, so related resources are referenced by a resource
+// identifier and the resources themselves are in the document's included resources.
+
+const (
+	TracksResourceType    = "tracks"
+	PlaylistsResourceType = "playlists"
+)
+
+// PlaylistAccessType controls who can find a playlist.
+type PlaylistAccessType string
+
+var (
+	PublicPlaylistAccessType   PlaylistAccessType = "PUBLIC"
+	UnlistedPlaylistAccessType PlaylistAccessType = "UNLISTED"
+)
+
+// ResourceIdentifier identifies a related resource.
+type ResourceIdentifier struct {
+	ID   string `json:"id"`
+	Type string `json:"type"`
+}
+
+type Relationship struct {
+	Data []ResourceIdentifier `json:"data"`
+}
+
+type Links struct {
+	Self string `json:"self"`
+	// Next is the link to the next page, empty on the last page
+	Next string `json:"next"`
+}
+
+// Resource is an included artist, album, or track. Only the attributes used to match
+// songs are decoded.
+type Resource struct {
+	ID         string             `json:"id"`
+	Type       string             `json:"type"`
+	Attributes ResourceAttributes `json:"attributes"`
+}
+
+type ResourceAttributes struct {
+	// Name is the name of an artist
+	Name string `json:"name"`
+	// Title is the title of an album or track
+	Title string `json:"title"`
+}
+
+type TrackDocument struct {
+	Data     []Track    `json:"data"`
+	Included []Resource `json:"included"`
+}
+
+type Track struct {
+	ID            string             `json:"id"`
+	Type          string             `json:"type"`
+	Attributes    TrackAttributes    `json:"attributes"`
+	Relationships TrackRelationships `json:"relationships"`
+}
+
+type TrackAttributes struct {
+	Title string `json:"title"`
+	// Version is the track's version, such as "Live" or "2011 Remaster"
+	Version string `json:"version"`
+	ISRC    string `json:"isrc"`
+}
+
+type TrackRelationships struct {
+	Artists Relationship `json:"artists"`
+	Albums  Relationship `json:"albums"`
+}
+
+type AlbumDocument struct {
+	Data     []Album    `json:"data"`
+	Included []Resource `json:"included"`
+}
+
+type Album struct {
+	ID            string             `json:"id"`
+	Type          string             `json:"type"`
+	Attributes    AlbumAttributes    `json:"attributes"`
+	Relationships AlbumRelationships `json:"relationships"`
+}
+
+type AlbumAttributes struct {
+	Title     string `json:"title"`
+	BarcodeID string `json:"barcodeId"`
+}
+
+type AlbumRelationships struct {
+	// Items are the album's tracks and videos
+	Items Relationship `json:"items"`
+}
+
+// RelationshipDocument is a page of the resources related to another resource, such
+// as the tracks found by a search or the items in a playlist.
+type RelationshipDocument struct {
+	Data  []ResourceIdentifier `json:"data"`
+	Links Links                `json:"links"`
+}
+
+type PlaylistDocument struct {
+	Data Playlist `json:"data"`
+}
+
+type Playlist struct {
+	// ID is empty when creating a playlist
+	ID         string             `json:"id,omitempty"`
+	Type       string             `json:"type"`
+	Attributes PlaylistAttributes `json:"attributes"`
+}
+
+type PlaylistAttributes struct {
+	Name        string             `json:"name"`
+	Description string             `json:"description,omitempty"`
+	AccessType  PlaylistAccessType `json:"accessType,omitempty"`
+}
+
+type AddPlaylistItemsRequest struct {
+	Data []ResourceIdentifier `json:"data"`
+}
@@ -0,0 +1,19 @@
+package tidal
+
+import (
+	"github.com/jbenzshawel/playlist-generator/internal/app/commands/playlists/internal/tracks"
+	"github.com/jbenzshawel/playlist-generator/internal/app/commands/playlists/tidal/internal/services"
+	"github.com/jbenzshawel/playlist-generator/internal/domain"
+)
+
+// SearchTracksCommand searches Tidal for the songs that haven't been searched for. Songs
+// matched on other providers are searched for by the match's ISRC first.
+type SearchTracksCommand = tracks.SearchTracksCommand
+
+type SearchTracksCommandResult = tracks.SearchTracksCommandResult
+
+type SearchTracksCommandHandler = tracks.SearchTracksCommandHandler
+
+func NewSearchTracksCommand(searchService services.SearchService, repository domain.Repository) SearchTracksCommandHandler {
+	return tracks.NewSearchTracksCommand(domain.TidalPlaylistType, searchService, repository)
+}
@@ -0,0 +1,16 @@
+package tidal
+
+import (
+	"github.com/jbenzshawel/playlist-generator/internal/app/commands/playlists/internal/tracks"
+	"github.com/jbenzshawel/playlist-generator/internal/domain"
+)
+
+type SongPlaylistsCommand = tracks.SongPlaylistsCommand
+
+type SongPlaylistsCommandResult = tracks.SongPlaylistsCommandResult
+
+type SongPlaylistsCommandHandler = tracks.SongPlaylistsCommandHandler
+
+func NewSongPlaylistsCommand(repository domain.Repository) SongPlaylistsCommandHandler {
+	return tracks.NewSongPlaylistsCommand(domain.TidalPlaylistType, repository)
+}
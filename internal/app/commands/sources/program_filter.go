@@ -0,0 +1,120 @@
+package sources
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+	"unicode/utf8"
+)
+
+// ProgramFilter decides which programs songs are kept from. Allow and deny entries are
+// glob patterns matched against the full program name. '*' matches any run of characters,
+// including '/', '?' matches a single character, '[...]' matches a character class, and
+// '\' escapes the next character.
+type ProgramFilter struct {
+	allow []*regexp.Regexp
+	deny  []*regexp.Regexp
+}
+
+func NewProgramFilter(allow, deny []string) (ProgramFilter, error) {
+	allowPatterns, err := compilePatterns(allow)
+	if err != nil {
+		return ProgramFilter{}, err
+	}
+
+	denyPatterns, err := compilePatterns(deny)
+	if err != nil {
+		return ProgramFilter{}, err
+	}
+
+	return ProgramFilter{allow: allowPatterns, deny: denyPatterns}, nil
+}
+
+// Keep returns whether songs played during a program should be kept. A program is kept
+// when it matches an allow pattern, or no allow patterns are configured, and it doesn't
+// match a deny pattern.
+func (f ProgramFilter) Keep(program string) bool {
+	if matchAny(f.deny, program) {
+		return false
+	}
+
+	return len(f.allow) == 0 || matchAny(f.allow, program)
+}
+
+func matchAny(patterns []*regexp.Regexp, program string) bool {
+	for _, pattern := range patterns {
+		if pattern.MatchString(program) {
+			return true
+		}
+	}
+	return false
+}
+
+func compilePatterns(patterns []string) ([]*regexp.Regexp, error) {
+	var compiled []*regexp.Regexp
+	for _, pattern := range patterns {
+		re, err := compileGlob(pattern)
+		if err != nil {
+			return nil, fmt.Errorf("invalid program pattern %q: %w", pattern, err)
+		}
+		compiled = append(compiled, re)
+	}
+	return compiled, nil
+}
+
+// compileGlob translates a glob pattern to a regular expression matching the whole
+// program name. Program names aren't paths, so unlike path.Match no character is a
+// separator.
+func compileGlob(pattern string) (*regexp.Regexp, error) {
+	var b strings.Builder
+	b.WriteString("^")
+
+	for i := 0; i < len(pattern); i++ {
+		switch pattern[i] {
+		case '*':
+			b.WriteString(".*")
+		case '?':
+			b.WriteString(".")
+		case '\\':
+			i++
+			if i == len(pattern) {
+				return nil, errors.New("trailing escape")
+			}
+			_, size := utf8.DecodeRuneInString(pattern[i:])
+			b.WriteString(regexp.QuoteMeta(pattern[i : i+size]))
+			i += size - 1
+		case '[':
+			end := strings.IndexByte(pattern[i+1:], ']')
+			if end < 0 {
+				return nil, errors.New("unclosed character class")
+			}
+			class := pattern[i+1 : i+1+end]
+			if strings.HasPrefix(class, "^") {
+				class = "^" + escapeClass(class[1:])
+			} else {
+				class = escapeClass(class)
+			}
+			b.WriteString("[" + class + "]")
+			i += end + 1
+		default:
+			b.WriteString(regexp.QuoteMeta(pattern[i : i+1]))
+		}
+	}
+
+	b.WriteString("$")
+	return regexp.Compile(b.String())
+}
+
+// escapeClass escapes the characters of a character class that are special inside a
+// regular expression class but not in a glob, keeping '-' ranges.
+func escapeClass(class string) string {
+	return strings.NewReplacer(`\`, `\\`, `[`, `\[`).Replace(class)
+}
+
+// Program summarizes the songs played during a program in a source's raw feed.
+type Program struct {
+	Name     string
+	NumSongs int
+	Kept     bool
+}
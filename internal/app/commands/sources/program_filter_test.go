@@ -0,0 +1,113 @@
+package sources
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestProgramFilter_Keep(t *testing.T) {
+	testCases := []struct {
+		name     string
+		allow    []string
+		deny     []string
+		program  string
+		expected bool
+	}{
+		{
+			name:     "no patterns keeps everything",
+			program:  "Morning Edition",
+			expected: true,
+		},
+		{
+			name:     "exact allow",
+			allow:    []string{"World Cafe"},
+			program:  "World Cafe",
+			expected: true,
+		},
+		{
+			name:     "not allowed",
+			allow:    []string{"World Cafe"},
+			program:  "Morning Edition",
+			expected: false,
+		},
+		{
+			name:     "glob allow",
+			allow:    []string{"Studio One*"},
+			program:  "Studio One All Access",
+			expected: true,
+		},
+		{
+			name:     "deny wins over allow",
+			allow:    []string{"Studio One*"},
+			deny:     []string{"Studio One All Access"},
+			program:  "Studio One All Access",
+			expected: false,
+		},
+		{
+			name:     "deny only",
+			deny:     []string{"*News*"},
+			program:  "Weekend News Hour",
+			expected: false,
+		},
+		{
+			name:     "glob matches across slashes",
+			allow:    []string{"Studio One*"},
+			program:  "Studio One Tracks/Live",
+			expected: true,
+		},
+		{
+			name:     "deny with slash in name",
+			deny:     []string{"*Jazz*"},
+			program:  "Blues/Jazz Hour",
+			expected: false,
+		},
+		{
+			name:     "single character",
+			allow:    []string{"Studio On?"},
+			program:  "Studio One",
+			expected: true,
+		},
+		{
+			name:     "character class",
+			allow:    []string{"[^W]*"},
+			program:  "World Cafe",
+			expected: false,
+		},
+		{
+			name:     "escaped star is literal",
+			allow:    []string{`Top \*`},
+			program:  "Top 40",
+			expected: false,
+		},
+		{
+			name:     "regexp characters are literal",
+			allow:    []string{"Live (Local)"},
+			program:  "Live (Local)",
+			expected: true,
+		},
+		{
+			name:     "empty program name",
+			allow:    []string{"Studio One*"},
+			program:  "",
+			expected: false,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			f, err := NewProgramFilter(tc.allow, tc.deny)
+			require.NoError(t, err)
+
+			assert.Equal(t, tc.expected, f.Keep(tc.program))
+		})
+	}
+
+	t.Run("invalid pattern", func(t *testing.T) {
+		_, err := NewProgramFilter([]string{"Studio One["}, nil)
+		assert.Error(t, err)
+
+		_, err = NewProgramFilter(nil, []string{`Studio One\`})
+		assert.Error(t, err)
+	})
+}
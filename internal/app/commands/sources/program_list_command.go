@@ -0,0 +1,65 @@
+package sources
+
+import (
+	"context"
+
+	"github.com/jbenzshawel/playlist-generator/internal/common/decorator"
+)
+
+type ProgramListCommand struct {
+	Date string
+}
+
+type ProgramListCommandResult struct {
+	Programs []Program
+}
+
+type ProgramListCommandHandler decorator.CommandWithResultHandler[ProgramListCommand, ProgramListCommandResult]
+
+// ProgramSongs is the number of songs played during a program in a source's raw feed.
+type ProgramSongs struct {
+	Name     string
+	NumSongs int
+}
+
+// ProgramSongsGetter returns the programs in a source's raw feed for a date in YYYY-MM-DD,
+// in the order they aired. A program that aired more than once is returned each time.
+type ProgramSongsGetter func(ctx context.Context, date string) ([]ProgramSongs, error)
+
+func NewProgramListCommand(getter ProgramSongsGetter, programs ProgramFilter) ProgramListCommandHandler {
+	return &programListCommand{
+		getter:   getter,
+		programs: programs,
+	}
+}
+
+type programListCommand struct {
+	getter   ProgramSongsGetter
+	programs ProgramFilter
+}
+
+func (p *programListCommand) Execute(ctx context.Context, cmd ProgramListCommand) (ProgramListCommandResult, error) {
+	programSongs, err := p.getter(ctx, cmd.Date)
+	if err != nil {
+		return ProgramListCommandResult{}, err
+	}
+
+	var programs []Program
+	lookup := make(map[string]int)
+
+	for _, s := range programSongs {
+		idx, ok := lookup[s.Name]
+		if !ok {
+			idx = len(programs)
+			lookup[s.Name] = idx
+			programs = append(programs, Program{
+				Name: s.Name,
+				Kept: p.programs.Keep(s.Name),
+			})
+		}
+
+		programs[idx].NumSongs += s.NumSongs
+	}
+
+	return ProgramListCommandResult{Programs: programs}, nil
+}
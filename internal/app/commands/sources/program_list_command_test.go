@@ -0,0 +1,32 @@
+package sources
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestProgramListCommand(t *testing.T) {
+	programs, err := NewProgramFilter([]string{"Studio One*"}, nil)
+	require.NoError(t, err)
+
+	getter := func(_ context.Context, date string) ([]ProgramSongs, error) {
+		assert.Equal(t, "2025-10-01", date)
+		return []ProgramSongs{
+			{Name: "Studio One Tracks", NumSongs: 3},
+			{Name: "Morning Edition", NumSongs: 0},
+			// a program that aired again is counted once, in the order it first aired
+			{Name: "Studio One Tracks", NumSongs: 2},
+		}, nil
+	}
+
+	result, err := NewProgramListCommand(getter, programs).Execute(t.Context(), ProgramListCommand{Date: "2025-10-01"})
+	require.NoError(t, err)
+
+	assert.Equal(t, []Program{
+		{Name: "Studio One Tracks", NumSongs: 5, Kept: true},
+		{Name: "Morning Edition", NumSongs: 0, Kept: false},
+	}, result.Programs)
+}
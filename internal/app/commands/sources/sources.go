@@ -1,20 +1,41 @@
 package sources
 
 import (
-	"github.com/jbenzshawel/playlist-generator/internal/app/commands/sources/studioone"
+	"context"
+
 	"github.com/jbenzshawel/playlist-generator/internal/domain"
 )
 
-type client interface {
-	studioone.Client
+// Source represents a station that publishes the list of songs played each day.
+type Source interface {
+	// Name is the display name used when naming playlists for the source
+	Name() string
+	SourceType() domain.SourceType
+	// FetchDay downloads the songs played on a date in YYYY-MM-DD and stores
+	// them as songs and song sources.
+	FetchDay(ctx context.Context, date string) error
+	// ListPrograms returns every program in the source's raw feed for a date in
+	// YYYY-MM-DD and whether songs from the program are kept.
+	ListPrograms(ctx context.Context, date string) ([]Program, error)
 }
 
-type Commands struct {
-	StudioOne studioone.Commands
+// Registry holds the configured sources in the order they were registered.
+type Registry struct {
+	sources []Source
 }
 
-func NewCommands(client client, repository domain.Repository) Commands {
-	return Commands{
-		StudioOne: studioone.NewCommands(client, repository),
+func NewRegistry(sources ...Source) *Registry {
+	r := &Registry{}
+	for _, s := range sources {
+		r.Register(s)
 	}
+	return r
+}
+
+func (r *Registry) Register(s Source) {
+	r.sources = append(r.sources, s)
+}
+
+func (r *Registry) All() []Source {
+	return r.sources
 }
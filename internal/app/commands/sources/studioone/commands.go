@@ -1,17 +1,22 @@
 package studioone
 
-import "github.com/jbenzshawel/playlist-generator/internal/domain"
+import (
+	"github.com/jbenzshawel/playlist-generator/internal/app/commands/sources"
+	"github.com/jbenzshawel/playlist-generator/internal/domain"
+)
 
 type Commands struct {
-	ListSongs SongListCommandHandler
+	ListSongs    SongListCommandHandler
+	ListPrograms ProgramListCommandHandler
 }
 
 type Client interface {
 	songGetter
 }
 
-func NewCommands(client Client, repository domain.Repository) Commands {
+func NewCommands(client Client, repository domain.Repository, programs sources.ProgramFilter) Commands {
 	return Commands{
-		ListSongs: NewSongListCommand(client, repository),
+		ListSongs:    NewSongListCommand(client, repository, programs),
+		ListPrograms: NewProgramListCommand(client, programs),
 	}
 }
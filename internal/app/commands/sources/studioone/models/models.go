@@ -14,6 +14,15 @@ type Item struct {
 	Playlist  []Song   `json:"playlist"`
 }
 
+// ProgramName returns the name of the program the item aired on, or an empty
+// string when the item has no program.
+func (i Item) ProgramName() string {
+	if i.Program == nil {
+		return ""
+	}
+	return i.Program.Name
+}
+
 type Program struct {
 	ProgramID string `json:"program_id"`
 	Name      string `json:"name"`
@@ -0,0 +1,31 @@
+package studioone
+
+import (
+	"context"
+
+	"github.com/jbenzshawel/playlist-generator/internal/app/commands/sources"
+)
+
+type ProgramListCommand = sources.ProgramListCommand
+
+type ProgramListCommandResult = sources.ProgramListCommandResult
+
+type ProgramListCommandHandler = sources.ProgramListCommandHandler
+
+func NewProgramListCommand(provider songGetter, programs sources.ProgramFilter) ProgramListCommandHandler {
+	return sources.NewProgramListCommand(func(ctx context.Context, date string) ([]sources.ProgramSongs, error) {
+		collection, err := provider.GetSongs(ctx, date)
+		if err != nil {
+			return nil, err
+		}
+
+		var programSongs []sources.ProgramSongs
+		for _, item := range collection.Items {
+			programSongs = append(programSongs, sources.ProgramSongs{
+				Name:     item.ProgramName(),
+				NumSongs: len(item.Playlist),
+			})
+		}
+		return programSongs, nil
+	}, programs)
+}
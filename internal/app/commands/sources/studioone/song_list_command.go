@@ -6,22 +6,24 @@ import (
 	"strings"
 	"time"
 
+	"github.com/jbenzshawel/playlist-generator/internal/app/commands/sources"
 	"github.com/jbenzshawel/playlist-generator/internal/app/commands/sources/studioone/models"
 	"github.com/jbenzshawel/playlist-generator/internal/common/dateformat"
 	"github.com/jbenzshawel/playlist-generator/internal/common/decorator"
 	"github.com/jbenzshawel/playlist-generator/internal/domain"
 )
 
-var supportedPrograms = map[string]struct{}{
-	"Blue Avenue":           {},
-	"Blues Before Sunrise":  {},
-	"Jazz Department":       {},
-	"Studio One":            {},
-	"Studio One Tracks":     {},
-	"Studio One All Access": {},
-	"Tiny Desk Radio":       {},
-	"UnderCurrents":         {},
-	"World Cafe":            {},
+// DefaultPrograms are the programs songs are kept from when no allow list is configured
+var DefaultPrograms = []string{
+	"Blue Avenue",
+	"Blues Before Sunrise",
+	"Jazz Department",
+	"Studio One",
+	"Studio One Tracks",
+	"Studio One All Access",
+	"Tiny Desk Radio",
+	"UnderCurrents",
+	"World Cafe",
 }
 
 type SongListCommand struct {
@@ -33,10 +35,12 @@ type SongListCommandHandler decorator.CommandHandler[SongListCommand]
 func NewSongListCommand(
 	provider songGetter,
 	repository domain.Repository,
+	programs sources.ProgramFilter,
 ) SongListCommandHandler {
 	return decorator.ApplyDBTransactionDecorator(
 		&songListCommand{
 			queryer:          provider,
+			programs:         programs,
 			songRepository:   repository.Song(),
 			sourceRepository: repository.SongSource(),
 		},
@@ -50,6 +54,7 @@ type songGetter interface {
 
 type songListCommand struct {
 	queryer          songGetter
+	programs         sources.ProgramFilter
 	songRepository   domain.SongRepository
 	sourceRepository domain.SongSourceRepository
 }
@@ -64,13 +69,12 @@ func (d *songListCommand) Execute(ctx context.Context, cmd SongListCommand) (any
 	var songs []domain.Song
 	var pubRadioSongs []domain.SongSource
 
+	skipped := make(map[string]int)
+
 	for _, item := range collection.Items {
-		var programName string
-		if item.Program != nil {
-			programName = item.Program.Name
-		}
-		if _, ok := supportedPrograms[programName]; !ok {
-			slog.Debug("unsupported program", slog.String("program", programName))
+		programName := item.ProgramName()
+		if !d.programs.Keep(programName) {
+			skipped[programName] += len(item.Playlist)
 			continue
 		}
 
@@ -94,6 +98,10 @@ func (d *songListCommand) Execute(ctx context.Context, cmd SongListCommand) (any
 		}
 	}
 
+	for programName, count := range skipped {
+		slog.Info("songs skipped for filtered program", slog.String("program", programName), slog.Int("count", count))
+	}
+
 	slog.Info("found songs", slog.Int("count", len(songs)))
 
 	err = d.songRepository.BulkInsert(ctx, songs)
@@ -111,10 +119,10 @@ func (d *songListCommand) Execute(ctx context.Context, cmd SongListCommand) (any
 
 func tryParseTime(t string) (time.Time, bool) {
 	parsedTime, err := time.Parse(time.DateTime, t)
-	ok := err != nil
+	ok := err == nil
 	if !ok {
 		parsedTime, err = time.Parse(dateformat.MonthDayYearTime, t)
-		ok = err != nil
+		ok = err == nil
 	}
 	return parsedTime, ok
 }
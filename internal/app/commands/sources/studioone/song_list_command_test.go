@@ -0,0 +1,41 @@
+package studioone
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTryParseTime(t *testing.T) {
+	testCases := []struct {
+		name       string
+		value      string
+		expected   time.Time
+		expectedOK bool
+	}{
+		{
+			name:       "date time",
+			value:      "2025-10-01 14:03:27",
+			expected:   time.Date(2025, 10, 1, 14, 3, 27, 0, time.UTC),
+			expectedOK: true,
+		},
+		{
+			name:       "month day year time",
+			value:      "10-01-2025 14:03:27",
+			expected:   time.Date(2025, 10, 1, 14, 3, 27, 0, time.UTC),
+			expectedOK: true,
+		},
+		{
+			name:  "invalid",
+			value: "2:03 PM",
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			actual, ok := tryParseTime(tc.value)
+			assert.Equal(t, tc.expectedOK, ok)
+			assert.Equal(t, tc.expected, actual)
+		})
+	}
+}
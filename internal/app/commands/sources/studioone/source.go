@@ -0,0 +1,41 @@
+package studioone
+
+import (
+	"context"
+
+	"github.com/jbenzshawel/playlist-generator/internal/app/commands/sources"
+	"github.com/jbenzshawel/playlist-generator/internal/domain"
+)
+
+var _ sources.Source = (*source)(nil)
+
+type source struct {
+	commands Commands
+}
+
+func NewSource(client Client, repository domain.Repository, programs sources.ProgramFilter) sources.Source {
+	return &source{
+		commands: NewCommands(client, repository, programs),
+	}
+}
+
+func (s *source) Name() string {
+	return domain.StudioOneSourceType.String()
+}
+
+func (s *source) SourceType() domain.SourceType {
+	return domain.StudioOneSourceType
+}
+
+func (s *source) FetchDay(ctx context.Context, date string) error {
+	_, err := s.commands.ListSongs.Execute(ctx, SongListCommand{Date: date})
+	return err
+}
+
+func (s *source) ListPrograms(ctx context.Context, date string) ([]sources.Program, error) {
+	res, err := s.commands.ListPrograms.Execute(ctx, ProgramListCommand{Date: date})
+	if err != nil {
+		return nil, err
+	}
+	return res.Programs, nil
+}
@@ -0,0 +1,22 @@
+package thecurrent
+
+import (
+	"github.com/jbenzshawel/playlist-generator/internal/app/commands/sources"
+	"github.com/jbenzshawel/playlist-generator/internal/domain"
+)
+
+type Commands struct {
+	ListSongs    SongListCommandHandler
+	ListPrograms ProgramListCommandHandler
+}
+
+type Client interface {
+	songGetter
+}
+
+func NewCommands(client Client, repository domain.Repository, programs sources.ProgramFilter) Commands {
+	return Commands{
+		ListSongs:    NewSongListCommand(client, repository, programs),
+		ListPrograms: NewProgramListCommand(client, programs),
+	}
+}
@@ -0,0 +1,14 @@
+package models
+
+type Playlist struct {
+	Songs []Song `json:"songs"`
+}
+
+type Song struct {
+	ID       string `json:"song_id"`
+	Artist   string `json:"artist"`
+	Track    string `json:"title"`
+	Album    string `json:"album"`
+	PlayedAt string `json:"played_at"`
+	Program  string `json:"program"`
+}
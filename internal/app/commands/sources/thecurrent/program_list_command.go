@@ -0,0 +1,32 @@
+package thecurrent
+
+import (
+	"context"
+	"strings"
+
+	"github.com/jbenzshawel/playlist-generator/internal/app/commands/sources"
+)
+
+type ProgramListCommand = sources.ProgramListCommand
+
+type ProgramListCommandResult = sources.ProgramListCommandResult
+
+type ProgramListCommandHandler = sources.ProgramListCommandHandler
+
+func NewProgramListCommand(provider songGetter, programs sources.ProgramFilter) ProgramListCommandHandler {
+	return sources.NewProgramListCommand(func(ctx context.Context, date string) ([]sources.ProgramSongs, error) {
+		playlist, err := provider.GetSongs(ctx, date)
+		if err != nil {
+			return nil, err
+		}
+
+		var programSongs []sources.ProgramSongs
+		for _, s := range playlist.Songs {
+			programSongs = append(programSongs, sources.ProgramSongs{
+				Name:     strings.TrimSpace(s.Program),
+				NumSongs: 1,
+			})
+		}
+		return programSongs, nil
+	}, programs)
+}
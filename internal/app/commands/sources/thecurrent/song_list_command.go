@@ -0,0 +1,100 @@
+package thecurrent
+
+import (
+	"context"
+	"log/slog"
+	"strings"
+	"time"
+
+	"github.com/jbenzshawel/playlist-generator/internal/app/commands/sources"
+	"github.com/jbenzshawel/playlist-generator/internal/app/commands/sources/thecurrent/models"
+	"github.com/jbenzshawel/playlist-generator/internal/common/decorator"
+	"github.com/jbenzshawel/playlist-generator/internal/domain"
+)
+
+type SongListCommand struct {
+	Date string
+}
+
+type SongListCommandHandler decorator.CommandHandler[SongListCommand]
+
+func NewSongListCommand(
+	provider songGetter,
+	repository domain.Repository,
+	programs sources.ProgramFilter,
+) SongListCommandHandler {
+	return decorator.ApplyDBTransactionDecorator(
+		&songListCommand{
+			queryer:          provider,
+			programs:         programs,
+			songRepository:   repository.Song(),
+			sourceRepository: repository.SongSource(),
+		},
+		repository,
+	)
+}
+
+type songGetter interface {
+	GetSongs(ctx context.Context, date string) (models.Playlist, error)
+}
+
+type songListCommand struct {
+	queryer          songGetter
+	programs         sources.ProgramFilter
+	songRepository   domain.SongRepository
+	sourceRepository domain.SongSourceRepository
+}
+
+func (d *songListCommand) Execute(ctx context.Context, cmd SongListCommand) (any, error) {
+	slog.Info("downloading the current songs", slog.Any("date", cmd.Date))
+	playlist, err := d.queryer.GetSongs(ctx, cmd.Date)
+	if err != nil {
+		return nil, err
+	}
+
+	var songs []domain.Song
+	var songSources []domain.SongSource
+
+	skipped := make(map[string]int)
+
+	for _, s := range playlist.Songs {
+		programName := strings.TrimSpace(s.Program)
+		if !d.programs.Keep(programName) {
+			skipped[programName]++
+			continue
+		}
+
+		song, err := domain.NewSong(strings.TrimSpace(s.Artist), strings.TrimSpace(s.Track), strings.TrimSpace(s.Album), "")
+		if err != nil {
+			slog.Warn("song skipped", slog.Any("error", err))
+			continue
+		}
+
+		playedAt, err := time.Parse(time.RFC3339, s.PlayedAt)
+		if err != nil {
+			slog.Warn("song skipped", slog.Any("invalidPlayedAt", s.PlayedAt))
+			continue
+		}
+
+		songs = append(songs, song)
+		songSources = append(songSources, domain.NewSongSource(s.ID, song.SongHash(), domain.TheCurrentSourceType, programName, cmd.Date, playedAt))
+	}
+
+	for programName, count := range skipped {
+		slog.Info("songs skipped for filtered program", slog.String("program", programName), slog.Int("count", count))
+	}
+
+	slog.Info("found songs", slog.Int("count", len(songs)))
+
+	err = d.songRepository.BulkInsert(ctx, songs)
+	if err != nil {
+		return nil, err
+	}
+
+	err = d.sourceRepository.BulkInsert(ctx, songSources)
+	if err != nil {
+		return nil, err
+	}
+
+	return nil, nil
+}
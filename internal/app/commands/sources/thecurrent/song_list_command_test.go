@@ -0,0 +1,66 @@
+package thecurrent
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/jbenzshawel/playlist-generator/internal/app/commands/sources"
+	"github.com/jbenzshawel/playlist-generator/internal/app/commands/sources/thecurrent/models"
+	"github.com/jbenzshawel/playlist-generator/internal/domain"
+	"github.com/jbenzshawel/playlist-generator/internal/infrastructure/storage"
+)
+
+func TestSongListCommand(t *testing.T) {
+	repository := storage.NewRepository(storage.InitTestStorage(t))
+
+	programs, err := sources.NewProgramFilter(nil, []string{"Local Show"})
+	require.NoError(t, err)
+
+	getter := &testSongGetter{
+		playlist: models.Playlist{
+			Songs: []models.Song{
+				{ID: "song1", Artist: " Wednesday ", Track: "Elderberry Wine", Album: "Bleeds", PlayedAt: "2025-10-19T06:04:00-05:00", Program: "Morning Show "},
+				// songs of a filtered program aren't stored
+				{ID: "song2", Artist: "Lizzo", Track: "Good As Hell", Album: "Cuz I Love You", PlayedAt: "2025-10-19T20:12:00-05:00", Program: "Local Show"},
+				// songs without a valid play time are skipped
+				{ID: "song3", Artist: "Big Thief", Track: "Incomprehensible", Album: "Double Infinity", PlayedAt: "6:08 AM", Program: "Morning Show"},
+			},
+		},
+	}
+
+	_, err = NewSongListCommand(getter, repository, programs).Execute(t.Context(), SongListCommand{Date: "2025-10-19"})
+	require.NoError(t, err)
+	assert.Equal(t, "2025-10-19", getter.date)
+
+	require.NoError(t, repository.Begin(t.Context()))
+	t.Cleanup(func() { _ = repository.Rollback() })
+
+	plays, err := repository.SongSource().GetSongsPlayedInRange(t.Context(), domain.TheCurrentSourceType, "2025-10-19", "2025-10-20")
+	require.NoError(t, err)
+	require.Len(t, plays, 1)
+
+	song := plays[0].Song()
+	assert.Equal(t, "Wednesday", song.Artist())
+	assert.Equal(t, "Elderberry Wine", song.Track())
+	assert.Equal(t, "Bleeds", song.Album())
+
+	source := plays[0].Source()
+	assert.Equal(t, "song1", source.SourceID())
+	assert.Equal(t, "Morning Show", source.ProgramName())
+	assert.Equal(t, "2025-10-19", source.Day())
+	assert.True(t, time.Date(2025, 10, 19, 11, 4, 0, 0, time.UTC).Equal(source.EndTime()))
+}
+
+type testSongGetter struct {
+	playlist models.Playlist
+	date     string
+}
+
+func (g *testSongGetter) GetSongs(_ context.Context, date string) (models.Playlist, error) {
+	g.date = date
+	return g.playlist, nil
+}
@@ -6,24 +6,124 @@ import (
 )
 
 type Config struct {
-	Clients `json:"clients"`
+	Clients   `json:"clients"`
+	Playlists Playlists `json:"playlists"`
+	Matching  Matching  `json:"matching"`
+	Server    Server    `json:"server"`
+	Storage   Storage   `json:"storage"`
+}
+
+// Storage configures the database songs, playlists, and matches are stored in.
+type Storage struct {
+	// Path is the SQLite database file, or :memory: for a database that's discarded when
+	// the run ends. Missing directories are created. It defaults to db/app.db in the working
+	// directory.
+	Path string `json:"path"`
+	// DSN is a postgres:// URL for a Postgres database, which lets several runners share
+	// one database, or a SQLite DSN. It's used instead of Path when set.
+	DSN string `json:"dsn"`
+}
+
+// Server configures the HTTP server for the login callback and review page.
+type Server struct {
+	// Address is the address the server listens on. It defaults to the loopback address
+	// on the Spotify redirect port.
+	Address string `json:"address"`
+}
+
+// Matching configures how songs are scored against tracks found by searching a
+// streaming provider.
+type Matching struct {
+	// Strategy is the string similarity algorithm: levenshtein, tokenSet, jaroWinkler,
+	// or combined. Levenshtein is used when omitted.
+	Strategy string       `json:"strategy"`
+	Weights  MatchWeights `json:"weights"`
+	// ReviewBelow queues fuzzy, single result, and UPC matches with a confidence below it,
+	// from 0 to 100, for review instead of matching them. Review is disabled when omitted.
+	ReviewBelow float64 `json:"reviewBelow"`
+}
+
+// MatchWeights are the relative weights of the artist, track, and album similarities.
+// The default weights are used when they are all omitted.
+type MatchWeights struct {
+	Artist float64 `json:"artist"`
+	Track  float64 `json:"track"`
+	Album  float64 `json:"album"`
+}
+
+type Playlists struct {
+	// Programs lists, by source name, the programs that get their own playlist
+	// in addition to the playlist for the whole source.
+	Programs map[string][]string `json:"programs"`
 }
 
 type Clients struct {
-	IowaPublicRadio Client      `json:"ipr"`
-	SpotifyClient   OAuthClient `json:"spotify"`
+	IowaPublicRadio SourceClient `json:"ipr"`
+	TheCurrent      SourceClient `json:"theCurrent"`
+	SpotifyClient   OAuthClient  `json:"spotify"`
+	AppleMusic      AppleMusic   `json:"appleMusic"`
+	Tidal           Tidal        `json:"tidal"`
+	Subsonic        Subsonic     `json:"subsonic"`
 }
 
 type Client struct {
 	BaseURL string `json:"baseURL"`
 }
 
+type SourceClient struct {
+	Client
+	Programs ProgramFilter `json:"programs"`
+}
+
+// ProgramFilter lists glob patterns for the programs songs are kept from. When
+// Allow is omitted the source's default programs are kept.
+type ProgramFilter struct {
+	Allow []string `json:"allow"`
+	Deny  []string `json:"deny"`
+}
+
 type OAuthClient struct {
 	Client
 	ClientID     string `json:"clientID"`
 	ClientSecret string `json:"clientSecret"`
 	AuthURL      string `json:"authURL"`
 	TokenURL     string `json:"tokenURL"`
+	// RedirectHost and RedirectPort are the host and port of the login redirect URL,
+	// http://{host}:{port}/callback. They default to 127.0.0.1 and 3000.
+	RedirectHost string `json:"redirectHost"`
+	RedirectPort int    `json:"redirectPort"`
+}
+
+// AppleMusic configures the Apple Music client. Apple Music playlists are only created
+// when the base URL is set.
+type AppleMusic struct {
+	Client
+	// TeamID, KeyID, and PrivateKeyPath sign the developer token. The private key is the
+	// .p8 file of a MusicKit key.
+	TeamID         string `json:"teamID"`
+	KeyID          string `json:"keyID"`
+	PrivateKeyPath string `json:"privateKeyPath"`
+	// MusicUserToken authorizes changes to the user's library.
+	MusicUserToken string `json:"musicUserToken"`
+	// Storefront is the country code of the catalog searched. It defaults to us.
+	Storefront string `json:"storefront"`
+}
+
+// Tidal configures the Tidal client. Tidal playlists are only created when the base URL
+// is set. The login redirect URL is http://{host}:{port}/tidal/callback.
+type Tidal struct {
+	OAuthClient
+	// CountryCode is the country code of the catalog searched. It defaults to US.
+	CountryCode string `json:"countryCode"`
+}
+
+// Subsonic configures a Subsonic API server such as Navidrome. Subsonic playlists are only
+// created when the base URL, the server's address without the /rest path, is set.
+type Subsonic struct {
+	Client
+	Username string `json:"username"`
+	// Password is only used to sign requests, it isn't sent to the server.
+	Password string `json:"password"`
 }
 
 func Load() (Config, error) {
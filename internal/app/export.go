@@ -0,0 +1,120 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"strings"
+
+	"github.com/jbenzshawel/playlist-generator/internal/app/commands/exports"
+	"github.com/jbenzshawel/playlist-generator/internal/app/commands/sources"
+	"github.com/jbenzshawel/playlist-generator/internal/app/config"
+	"github.com/jbenzshawel/playlist-generator/internal/domain"
+	"github.com/jbenzshawel/playlist-generator/internal/infrastructure/storage"
+)
+
+type ExportConfig struct {
+	// Source is the case-insensitive name of the source to export. It can be empty when
+	// only one source is configured.
+	Source string
+	// Date is any date in YYYY-MM-DD within the period to export
+	Date   string
+	Scope  string
+	Format string
+	// Path is the file the playlist is written to. The playlist is written to out when
+	// it is empty.
+	Path string
+}
+
+// Export writes the songs played at a source during a day, month, or year to a playlist
+// file. Exports only read the database, so no streaming provider login is required. The
+// database is selected by the storage config and storageFlags.
+func Export(ctx context.Context, storageFlags config.Storage, exportCfg ExportConfig, out io.Writer) (err error) {
+	scope, err := domain.ParsePlaylistDateScope(exportCfg.Scope)
+	if err != nil {
+		return fmt.Errorf("invalid scope - day, month, or year expected: %w", err)
+	}
+
+	format, err := exports.ParseFormat(exportCfg.Format)
+	if err != nil {
+		return fmt.Errorf("invalid format - m3u8, xspf, csv, or json expected: %w", err)
+	}
+
+	cfg, err := config.Load()
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+
+	store, err := storage.Initialize(ctx, storageDSN(cfg.Storage, storageFlags))
+	if err != nil {
+		return fmt.Errorf("failed to initialize database: %w", err)
+	}
+	defer store.Close()
+
+	repository := storage.NewRepository(store)
+
+	source, err := findSource(newSourceRegistry(cfg.Clients, repository), exportCfg.Source)
+	if err != nil {
+		return err
+	}
+
+	if exportCfg.Path != "" {
+		var f *os.File
+		f, err = os.Create(exportCfg.Path)
+		if err != nil {
+			return fmt.Errorf("failed to create export file: %w", err)
+		}
+		defer func() {
+			err = errors.Join(err, f.Close())
+		}()
+		out = f
+	}
+
+	res, err := exports.NewCommands(repository).ExportSongs.Execute(ctx, exports.ExportSongsCommand{
+		Date:       exportCfg.Date,
+		DateScope:  scope,
+		SourceName: source.Name(),
+		SourceType: source.SourceType(),
+		Format:     format,
+		Writer:     out,
+	})
+	if err != nil {
+		return fmt.Errorf("%s: export songs error: %w", source.Name(), err)
+	}
+
+	if exportCfg.Path != "" {
+		slog.Info("playlist exported", slog.String("title", res.Title), slog.String("path", exportCfg.Path))
+	}
+
+	return nil
+}
+
+// findSource returns the registered source with a case-insensitive name. An empty name
+// selects the only registered source.
+func findSource(registry *sources.Registry, name string) (sources.Source, error) {
+	all := registry.All()
+	if len(all) == 0 {
+		return nil, errors.New("no sources are configured")
+	}
+
+	names := make([]string, 0, len(all))
+	for _, source := range all {
+		if strings.EqualFold(source.Name(), name) {
+			return source, nil
+		}
+		names = append(names, source.Name())
+	}
+
+	if name == "" && len(all) == 1 {
+		return all[0], nil
+	}
+
+	if name == "" {
+		return nil, fmt.Errorf("a source is required - one of %s", strings.Join(names, ", "))
+	}
+
+	return nil, fmt.Errorf("unknown source %q - one of %s expected", name, strings.Join(names, ", "))
+}
@@ -0,0 +1,65 @@
+package app
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/jbenzshawel/playlist-generator/internal/app/commands/sources"
+	"github.com/jbenzshawel/playlist-generator/internal/domain"
+)
+
+func TestFindSource(t *testing.T) {
+	studioOne := testSource{name: "Studio One", sourceType: domain.StudioOneSourceType}
+	theCurrent := testSource{name: "The Current", sourceType: domain.TheCurrentSourceType}
+
+	t.Run("source found by name", func(t *testing.T) {
+		source, err := findSource(sources.NewRegistry(studioOne, theCurrent), "the current")
+		require.NoError(t, err)
+		assert.Equal(t, theCurrent, source)
+	})
+
+	t.Run("only source selected without a name", func(t *testing.T) {
+		source, err := findSource(sources.NewRegistry(studioOne), "")
+		require.NoError(t, err)
+		assert.Equal(t, studioOne, source)
+	})
+
+	t.Run("name required with several sources", func(t *testing.T) {
+		_, err := findSource(sources.NewRegistry(studioOne, theCurrent), "")
+		assert.EqualError(t, err, "a source is required - one of Studio One, The Current")
+	})
+
+	t.Run("unknown source", func(t *testing.T) {
+		_, err := findSource(sources.NewRegistry(studioOne), "KEXP")
+		assert.EqualError(t, err, `unknown source "KEXP" - one of Studio One expected`)
+	})
+
+	t.Run("no sources", func(t *testing.T) {
+		_, err := findSource(sources.NewRegistry(), "")
+		assert.EqualError(t, err, "no sources are configured")
+	})
+}
+
+type testSource struct {
+	name       string
+	sourceType domain.SourceType
+}
+
+func (s testSource) Name() string {
+	return s.name
+}
+
+func (s testSource) SourceType() domain.SourceType {
+	return s.sourceType
+}
+
+func (s testSource) FetchDay(context.Context, string) error {
+	return nil
+}
+
+func (s testSource) ListPrograms(context.Context, string) ([]sources.Program, error) {
+	return nil, nil
+}
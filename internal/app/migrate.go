@@ -0,0 +1,65 @@
+package app
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strconv"
+	"text/tabwriter"
+	"time"
+
+	"github.com/jbenzshawel/playlist-generator/internal/app/config"
+	"github.com/jbenzshawel/playlist-generator/internal/infrastructure/storage"
+)
+
+// Migrate applies pending database migrations, which also happens whenever the database
+// is opened, and writes the status of every migration to out. With the status command
+// the status is written without applying any migrations. The database is selected by the
+// storage config and storageFlags.
+func Migrate(ctx context.Context, storageFlags config.Storage, command string, out io.Writer) error {
+	cfg, err := config.Load()
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+	dsn := storageDSN(cfg.Storage, storageFlags)
+
+	var statuses []storage.MigrationStatus
+
+	switch command {
+	case "status":
+		statuses, err = storage.MigrationStatuses(ctx, dsn)
+		if err != nil {
+			return fmt.Errorf("failed to get migration status: %w", err)
+		}
+	case "", "up":
+		store, err := storage.Initialize(ctx, dsn)
+		if err != nil {
+			return fmt.Errorf("failed to initialize database: %w", err)
+		}
+		defer store.Close()
+
+		statuses, err = store.MigrationStatuses(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to get migration status: %w", err)
+		}
+	default:
+		return fmt.Errorf("unknown migrate command %q - status or up expected", command)
+	}
+
+	return writeMigrationStatuses(out, statuses)
+}
+
+func writeMigrationStatuses(out io.Writer, statuses []storage.MigrationStatus) error {
+	w := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+	_, _ = fmt.Fprintln(w, "VERSION\tDESCRIPTION\tAPPLIED")
+
+	for _, status := range statuses {
+		applied := "pending"
+		if !status.Applied.IsZero() {
+			applied = status.Applied.Local().Format(time.DateTime)
+		}
+		_, _ = fmt.Fprintf(w, "%s\t%s\t%s\n", strconv.Itoa(status.Version), status.Description, applied)
+	}
+
+	return w.Flush()
+}
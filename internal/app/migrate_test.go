@@ -0,0 +1,27 @@
+package app
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/jbenzshawel/playlist-generator/internal/infrastructure/storage"
+)
+
+func TestWriteMigrationStatuses(t *testing.T) {
+	applied := time.Date(2025, 1, 2, 3, 4, 5, 0, time.Local)
+
+	var out bytes.Buffer
+	err := writeMigrationStatuses(&out, []storage.MigrationStatus{
+		{Version: 1, Description: "create initial schema", Applied: applied},
+		{Version: 2, Description: "add playlist date scopes"},
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, "VERSION  DESCRIPTION               APPLIED\n"+
+		"1        create initial schema     2025-01-02 03:04:05\n"+
+		"2        add playlist date scopes  pending\n", out.String())
+}
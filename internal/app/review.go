@@ -0,0 +1,142 @@
+package app
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/subtle"
+	_ "embed"
+	"fmt"
+	"html/template"
+	"log/slog"
+	"net/http"
+	"strconv"
+
+	"github.com/jbenzshawel/playlist-generator/internal/app/commands/playlists/spotify"
+	"github.com/jbenzshawel/playlist-generator/internal/domain"
+)
+
+const reviewPath = "/review"
+
+//go:embed templates/review.html
+var reviewHTML string
+
+var reviewTemplate = template.Must(template.New("review").Parse(reviewHTML))
+
+// reviewMatches serves the match review page until the context is done.
+func (a Application) reviewMatches(ctx context.Context) error {
+	err := a.server.Handle(ctx, reviewPath, newReviewHandler(
+		a.Playlists.Spotify.MatchReviews,
+		a.Playlists.Spotify.ReviewMatch,
+		func(ctx context.Context, song domain.Song) error {
+			return a.syncSongPlaylists(ctx, []domain.Song{song})
+		},
+		rand.Text(),
+	))
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("Review matches at %s\n", a.server.URL(reviewPath))
+
+	<-ctx.Done()
+
+	return nil
+}
+
+type reviewHandler struct {
+	reviews spotify.MatchReviewsCommandHandler
+	review  spotify.ReviewMatchCommandHandler
+	// matched is called after a song is matched to a candidate
+	matched func(ctx context.Context, song domain.Song) error
+	// token is rendered into the review forms and required on every decision, so other
+	// pages open in the browser can't post decisions
+	token string
+}
+
+type reviewPage struct {
+	Token   string
+	Reviews []spotify.MatchReview
+}
+
+func newReviewHandler(
+	reviews spotify.MatchReviewsCommandHandler,
+	review spotify.ReviewMatchCommandHandler,
+	matched func(ctx context.Context, song domain.Song) error,
+	token string,
+) http.Handler {
+	return &reviewHandler{
+		reviews: reviews,
+		review:  review,
+		matched: matched,
+		token:   token,
+	}
+}
+
+func (h *reviewHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodGet:
+		h.list(w, r)
+	case http.MethodPost:
+		h.decide(w, r)
+	default:
+		w.Header().Set("Allow", "GET, POST")
+		http.Error(w, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+	}
+}
+
+func (h *reviewHandler) list(w http.ResponseWriter, r *http.Request) {
+	res, err := h.reviews.Execute(r.Context(), spotify.MatchReviewsCommand{})
+	if err != nil {
+		slog.Error("match reviews error", slog.Any("error", err))
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	err = reviewTemplate.Execute(w, reviewPage{Token: h.token, Reviews: res.Reviews})
+	if err != nil {
+		slog.Error("render match reviews error", slog.Any("error", err))
+	}
+}
+
+func (h *reviewHandler) decide(w http.ResponseWriter, r *http.Request) {
+	if subtle.ConstantTimeCompare([]byte(r.FormValue("token")), []byte(h.token)) != 1 {
+		http.Error(w, "invalid review token", http.StatusForbidden)
+		return
+	}
+
+	cmd := spotify.ReviewMatchCommand{
+		SongHash: r.FormValue("hash"),
+		Decision: spotify.ReviewDecision(r.FormValue("decision")),
+	}
+
+	if cmd.SongHash == "" {
+		http.Error(w, "hash is required", http.StatusBadRequest)
+		return
+	}
+
+	if cmd.Decision == spotify.PickReviewDecision {
+		rank, err := strconv.Atoi(r.FormValue("rank"))
+		if err != nil {
+			http.Error(w, "rank must be a number", http.StatusBadRequest)
+			return
+		}
+		cmd.Rank = rank
+	}
+
+	res, err := h.review.Execute(r.Context(), cmd)
+	if err != nil {
+		slog.Error("review match error", slog.Any("error", err), slog.String("songHash", cmd.SongHash))
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	if res.Matched {
+		err = h.matched(r.Context(), res.Song)
+		if err != nil {
+			slog.Error("sync reviewed song playlists error", slog.Any("error", err), slog.Any("song", res.Song))
+		}
+	}
+
+	http.Redirect(w, r, reviewPath, http.StatusSeeOther)
+}
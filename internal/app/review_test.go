@@ -0,0 +1,159 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/jbenzshawel/playlist-generator/internal/app/commands/playlists/spotify"
+	"github.com/jbenzshawel/playlist-generator/internal/domain"
+)
+
+type commandFunc[TParam, TRes any] func(ctx context.Context, cmd TParam) (TRes, error)
+
+func (f commandFunc[TParam, TRes]) Execute(ctx context.Context, cmd TParam) (TRes, error) {
+	return f(ctx, cmd)
+}
+
+const testReviewToken = "testReviewToken"
+
+func TestReviewHandler(t *testing.T) {
+	song := domain.NewSongFromDB(uuid.New(), "Cake", "Never There", "Prolonging The Magic", "", "songHash", time.Now())
+	candidates := []domain.MatchCandidate{
+		domain.NewMatchCandidateFromDB(song.ID(), 0, "trackID1", "spotify:track:trackID1", domain.FuzzyMatchMethod, 78.25, "CAKE", "Never There", "Prolonging Magic", time.Now()),
+		domain.NewMatchCandidateFromDB(song.ID(), 1, "trackID2", "spotify:track:trackID2", domain.FuzzyMatchMethod, 72, "CAKE", "Never There - Live", "Live at Bonnaroo", time.Now()),
+	}
+
+	reviews := commandFunc[spotify.MatchReviewsCommand, spotify.MatchReviewsCommandResult](
+		func(context.Context, spotify.MatchReviewsCommand) (spotify.MatchReviewsCommandResult, error) {
+			return spotify.MatchReviewsCommandResult{
+				Reviews: []spotify.MatchReview{{Song: song, Candidates: candidates}},
+			}, nil
+		},
+	)
+
+	newHandler := func(review spotify.ReviewMatchCommandHandler, matched *[]domain.Song) http.Handler {
+		return newReviewHandler(reviews, review, func(_ context.Context, s domain.Song) error {
+			*matched = append(*matched, s)
+			return nil
+		}, testReviewToken)
+	}
+
+	t.Run("lists candidates", func(t *testing.T) {
+		rec := httptest.NewRecorder()
+		newHandler(nil, nil).ServeHTTP(rec, httptest.NewRequest(http.MethodGet, reviewPath, nil))
+
+		assert.Equal(t, http.StatusOK, rec.Code)
+		body := rec.Body.String()
+		assert.Contains(t, body, "Cake &ndash; Never There")
+		assert.Contains(t, body, "78.2")
+		assert.Contains(t, body, "https://open.spotify.com/track/trackID2")
+		assert.Contains(t, body, "Never There - Live")
+		assert.Contains(t, body, `value="songHash"`)
+		assert.Contains(t, body, `name="token" value="`+testReviewToken+`"`)
+	})
+
+	testCases := []struct {
+		name            string
+		form            url.Values
+		matched         bool
+		reviewErr       error
+		expectedCode    int
+		expectedCommand *spotify.ReviewMatchCommand
+	}{
+		{
+			name:            "accept",
+			form:            url.Values{"token": {testReviewToken}, "hash": {"songHash"}, "decision": {"accept"}},
+			matched:         true,
+			expectedCode:    http.StatusSeeOther,
+			expectedCommand: &spotify.ReviewMatchCommand{SongHash: "songHash", Decision: spotify.AcceptReviewDecision},
+		},
+		{
+			name:            "pick",
+			form:            url.Values{"token": {testReviewToken}, "hash": {"songHash"}, "decision": {"pick"}, "rank": {"1"}},
+			matched:         true,
+			expectedCode:    http.StatusSeeOther,
+			expectedCommand: &spotify.ReviewMatchCommand{SongHash: "songHash", Decision: spotify.PickReviewDecision, Rank: 1},
+		},
+		{
+			name:            "reject",
+			form:            url.Values{"token": {testReviewToken}, "hash": {"songHash"}, "decision": {"reject"}},
+			expectedCode:    http.StatusSeeOther,
+			expectedCommand: &spotify.ReviewMatchCommand{SongHash: "songHash", Decision: spotify.RejectReviewDecision},
+		},
+		{
+			name:         "missing token",
+			form:         url.Values{"hash": {"songHash"}, "decision": {"reject"}},
+			expectedCode: http.StatusForbidden,
+		},
+		{
+			name:         "wrong token",
+			form:         url.Values{"token": {"guessed"}, "hash": {"songHash"}, "decision": {"pick"}, "rank": {"1"}},
+			expectedCode: http.StatusForbidden,
+		},
+		{
+			name:         "missing hash",
+			form:         url.Values{"token": {testReviewToken}, "decision": {"accept"}},
+			expectedCode: http.StatusBadRequest,
+		},
+		{
+			name:         "invalid rank",
+			form:         url.Values{"token": {testReviewToken}, "hash": {"songHash"}, "decision": {"pick"}, "rank": {"first"}},
+			expectedCode: http.StatusBadRequest,
+		},
+		{
+			name:            "review error",
+			form:            url.Values{"token": {testReviewToken}, "hash": {"songHash"}, "decision": {"accept"}},
+			reviewErr:       errors.New("song isn't waiting for review"),
+			expectedCode:    http.StatusBadRequest,
+			expectedCommand: &spotify.ReviewMatchCommand{SongHash: "songHash", Decision: spotify.AcceptReviewDecision},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			var (
+				actualCommand *spotify.ReviewMatchCommand
+				matched       []domain.Song
+			)
+
+			review := commandFunc[spotify.ReviewMatchCommand, spotify.ReviewMatchCommandResult](
+				func(_ context.Context, cmd spotify.ReviewMatchCommand) (spotify.ReviewMatchCommandResult, error) {
+					actualCommand = &cmd
+					return spotify.ReviewMatchCommandResult{Song: song, Matched: tc.matched}, tc.reviewErr
+				},
+			)
+
+			req := httptest.NewRequest(http.MethodPost, reviewPath, strings.NewReader(tc.form.Encode()))
+			req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+			rec := httptest.NewRecorder()
+			newHandler(review, &matched).ServeHTTP(rec, req)
+
+			require.Equal(t, tc.expectedCode, rec.Code)
+			assert.Equal(t, tc.expectedCommand, actualCommand)
+
+			if tc.matched && tc.reviewErr == nil {
+				assert.Equal(t, []domain.Song{song}, matched)
+				assert.Equal(t, reviewPath, rec.Header().Get("Location"))
+			} else {
+				assert.Empty(t, matched)
+			}
+		})
+	}
+
+	t.Run("method not allowed", func(t *testing.T) {
+		rec := httptest.NewRecorder()
+		newHandler(nil, nil).ServeHTTP(rec, httptest.NewRequest(http.MethodDelete, reviewPath, nil))
+
+		assert.Equal(t, http.StatusMethodNotAllowed, rec.Code)
+	})
+}
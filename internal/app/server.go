@@ -0,0 +1,146 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+)
+
+const (
+	serverReadHeaderTimeout = 10 * time.Second
+	serverShutdownTimeout   = 5 * time.Second
+)
+
+// server serves the login callback and review page. It only listens once a handler
+// is added, so runs that don't need it can run side by side. A server that was shut
+// down starts again, without its earlier handlers, when a handler is added.
+type server struct {
+	addr string
+	mux  *http.ServeMux
+
+	mu      sync.Mutex
+	httpSrv *http.Server
+	baseURL string
+}
+
+func newServer(addr string) *server {
+	return &server{
+		addr: addr,
+		mux:  http.NewServeMux(),
+	}
+}
+
+// Handle registers the handler for pattern, starting the server the first time it's
+// called. The server shuts down when the context is done.
+func (s *server) Handle(ctx context.Context, pattern string, handler http.Handler) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.httpSrv == nil {
+		err := s.start(ctx)
+		if err != nil {
+			return err
+		}
+	}
+
+	s.mux.Handle(pattern, handler)
+
+	return nil
+}
+
+func (s *server) start(ctx context.Context) error {
+	ln, err := net.Listen("tcp", s.addr)
+	if err != nil {
+		return fmt.Errorf("failed to listen on %s: %w", s.addr, err)
+	}
+
+	httpSrv := &http.Server{
+		Handler:           s.mux,
+		ReadHeaderTimeout: serverReadHeaderTimeout,
+	}
+	s.httpSrv = httpSrv
+	s.baseURL = baseURL(s.addr, ln.Addr())
+
+	go func() {
+		err := httpSrv.Serve(ln)
+		if !errors.Is(err, http.ErrServerClosed) {
+			slog.Error("http server error", slog.Any("error", err))
+		}
+	}()
+
+	go func() {
+		<-ctx.Done()
+		s.shutdown(httpSrv)
+	}()
+
+	slog.Debug("http server started", slog.String("address", ln.Addr().String()))
+
+	return nil
+}
+
+// URL returns the URL of path on the server, or an empty string when the server
+// hasn't started.
+func (s *server) URL(path string) string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.baseURL == "" {
+		return ""
+	}
+	return s.baseURL + path
+}
+
+// Shutdown gracefully stops the server, waiting for open requests to finish.
+func (s *server) Shutdown() {
+	s.mu.Lock()
+	httpSrv := s.httpSrv
+	s.mu.Unlock()
+
+	if httpSrv == nil {
+		return
+	}
+
+	s.shutdown(httpSrv)
+}
+
+// shutdown stops httpSrv and, when it's still the running server, resets the server so
+// the next handler added starts it again.
+func (s *server) shutdown(httpSrv *http.Server) {
+	s.mu.Lock()
+	if s.httpSrv == httpSrv {
+		s.httpSrv = nil
+		s.baseURL = ""
+		s.mux = http.NewServeMux()
+	}
+	s.mu.Unlock()
+
+	ctx, cancel := context.WithTimeout(context.Background(), serverShutdownTimeout)
+	defer cancel()
+
+	err := httpSrv.Shutdown(ctx)
+	if err != nil {
+		slog.Warn("http server shutdown error", slog.Any("error", err))
+	}
+}
+
+// baseURL is the URL of the server listening on addr. A server listening on every
+// interface is reached on the loopback address.
+func baseURL(configuredAddr string, addr net.Addr) string {
+	host, _, _ := net.SplitHostPort(configuredAddr)
+	if ip := net.ParseIP(host); host == "" || (ip != nil && ip.IsUnspecified()) {
+		host = "127.0.0.1"
+	}
+
+	port := 0
+	if tcpAddr, ok := addr.(*net.TCPAddr); ok {
+		port = tcpAddr.Port
+	}
+
+	return "http://" + net.JoinHostPort(host, strconv.Itoa(port))
+}
@@ -0,0 +1,108 @@
+package app
+
+import (
+	"context"
+	"io"
+	"net"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestServer(t *testing.T) {
+	t.Run("starts on first handler", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(t.Context())
+		defer cancel()
+
+		srv := newServer("127.0.0.1:0")
+		assert.Empty(t, srv.URL("/ping"))
+
+		require.NoError(t, srv.Handle(ctx, "/ping", pingHandler("ping")))
+		require.NoError(t, srv.Handle(ctx, "/pong", pingHandler("pong")))
+
+		assert.Equal(t, "ping", get(t, srv.URL("/ping")))
+		assert.Equal(t, "pong", get(t, srv.URL("/pong")))
+	})
+
+	t.Run("shuts down when context is done", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(t.Context())
+
+		srv := newServer("127.0.0.1:0")
+		require.NoError(t, srv.Handle(ctx, "/ping", pingHandler("ping")))
+		assert.Equal(t, "ping", get(t, srv.URL("/ping")))
+
+		cancel()
+
+		assert.Eventually(t, func() bool {
+			resp, err := http.Get(srv.URL("/ping"))
+			if err == nil {
+				_ = resp.Body.Close()
+			}
+			return err != nil
+		}, serverShutdownTimeout, serverShutdownTimeout/100)
+	})
+
+	t.Run("starts again after shutdown", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(t.Context())
+		defer cancel()
+
+		srv := newServer("127.0.0.1:0")
+		require.NoError(t, srv.Handle(ctx, "/callback", pingHandler("callback")))
+		assert.Equal(t, "callback", get(t, srv.URL("/callback")))
+
+		srv.Shutdown()
+		assert.Empty(t, srv.URL("/callback"))
+
+		require.NoError(t, srv.Handle(ctx, "/review", pingHandler("review")))
+		assert.Equal(t, "review", get(t, srv.URL("/review")))
+
+		// earlier handlers aren't served after a restart
+		resp, err := http.Get(srv.URL("/callback"))
+		require.NoError(t, err)
+		_ = resp.Body.Close()
+		assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+	})
+
+	t.Run("address in use", func(t *testing.T) {
+		ln, err := net.Listen("tcp", "127.0.0.1:0")
+		require.NoError(t, err)
+		defer ln.Close()
+
+		srv := newServer(ln.Addr().String())
+		err = srv.Handle(t.Context(), "/ping", pingHandler("ping"))
+		assert.Error(t, err)
+		assert.Empty(t, srv.URL("/ping"))
+	})
+
+	t.Run("shutdown before start", func(t *testing.T) {
+		newServer("127.0.0.1:0").Shutdown()
+	})
+}
+
+func TestBaseURL(t *testing.T) {
+	addr := &net.TCPAddr{IP: net.IPv4zero, Port: 3000}
+
+	assert.Equal(t, "http://127.0.0.1:3000", baseURL(":3000", addr))
+	assert.Equal(t, "http://127.0.0.1:3000", baseURL("0.0.0.0:3000", addr))
+	assert.Equal(t, "http://playlists.local:3000", baseURL("playlists.local:3000", addr))
+	assert.Equal(t, "http://[::1]:3000", baseURL("[::1]:3000", addr))
+}
+
+func pingHandler(body string) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = io.WriteString(w, body)
+	})
+}
+
+func get(t *testing.T, url string) string {
+	resp, err := http.Get(url)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+
+	return string(body)
+}
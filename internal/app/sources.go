@@ -0,0 +1,65 @@
+package app
+
+import (
+	"fmt"
+	"net/url"
+
+	"github.com/jbenzshawel/playlist-generator/internal/app/commands/sources"
+	"github.com/jbenzshawel/playlist-generator/internal/app/commands/sources/studioone"
+	"github.com/jbenzshawel/playlist-generator/internal/app/commands/sources/thecurrent"
+	"github.com/jbenzshawel/playlist-generator/internal/app/config"
+	"github.com/jbenzshawel/playlist-generator/internal/domain"
+	"github.com/jbenzshawel/playlist-generator/internal/infrastructure/clients/studiooneclient"
+	"github.com/jbenzshawel/playlist-generator/internal/infrastructure/clients/thecurrentclient"
+)
+
+// newSourceRegistry registers every source with a configured client. Playlists are
+// generated for sources in the order they are registered.
+func newSourceRegistry(cfg config.Clients, repository domain.Repository) *sources.Registry {
+	registry := sources.NewRegistry()
+
+	if cfg.IowaPublicRadio.BaseURL != "" {
+		registry.Register(studioone.NewSource(
+			studiooneclient.New(studiooneclient.Config{
+				BaseURL: mustParseURL("IowaPublicRadio.BaseURL", cfg.IowaPublicRadio.BaseURL),
+			}),
+			repository,
+			mustProgramFilter("IowaPublicRadio.Programs", cfg.IowaPublicRadio.Programs, studioone.DefaultPrograms),
+		))
+	}
+
+	if cfg.TheCurrent.BaseURL != "" {
+		registry.Register(thecurrent.NewSource(
+			thecurrentclient.New(thecurrentclient.Config{
+				BaseURL: mustParseURL("TheCurrent.BaseURL", cfg.TheCurrent.BaseURL),
+			}),
+			repository,
+			mustProgramFilter("TheCurrent.Programs", cfg.TheCurrent.Programs, nil),
+		))
+	}
+
+	return registry
+}
+
+// mustProgramFilter builds the program filter for a source, falling back to the
+// source's default programs when no allow list is configured.
+func mustProgramFilter(name string, cfg config.ProgramFilter, defaultAllow []string) sources.ProgramFilter {
+	allow := cfg.Allow
+	if allow == nil {
+		allow = defaultAllow
+	}
+
+	f, err := sources.NewProgramFilter(allow, cfg.Deny)
+	if err != nil {
+		panic(fmt.Errorf("failed to parse %s: %w", name, err))
+	}
+	return f
+}
+
+func mustParseURL(name, rawURL string) *url.URL {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		panic(fmt.Errorf("failed to parse %s: %w", name, err))
+	}
+	return u
+}
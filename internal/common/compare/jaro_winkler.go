@@ -0,0 +1,77 @@
+package compare
+
+// winklerPrefixScale is how much a shared prefix raises the Jaro similarity
+const winklerPrefixScale = 0.1
+
+// JaroWinkler calculates the Jaro-Winkler similarity between two strings as a percentage.
+// It favors strings that share a prefix, which suits short names with small typos or
+// trailing differences.
+func JaroWinkler(s1, s2 string) float64 {
+	r1 := []rune(s1)
+	r2 := []rune(s2)
+
+	jaro := jaroSimilarity(r1, r2)
+
+	// The common prefix is limited to 4 characters
+	prefix := 0
+	for prefix < min(len(r1), len(r2), 4) && r1[prefix] == r2[prefix] {
+		prefix++
+	}
+
+	return (jaro + float64(prefix)*winklerPrefixScale*(1-jaro)) * 100.0
+}
+
+// jaroSimilarity calculates the Jaro similarity between two rune slices from 0 to 1.
+func jaroSimilarity(r1, r2 []rune) float64 {
+	if len(r1) == 0 && len(r2) == 0 {
+		return 1
+	}
+	if len(r1) == 0 || len(r2) == 0 {
+		return 0
+	}
+
+	// Characters only match when they are no further apart than the match distance
+	matchDistance := max(len(r1), len(r2))/2 - 1
+	matchDistance = max(matchDistance, 0)
+
+	matched1 := make([]bool, len(r1))
+	matched2 := make([]bool, len(r2))
+
+	matches := 0
+	for i := range r1 {
+		start := max(0, i-matchDistance)
+		end := min(len(r2), i+matchDistance+1)
+
+		for j := start; j < end; j++ {
+			if matched2[j] || r1[i] != r2[j] {
+				continue
+			}
+			matched1[i], matched2[j] = true, true
+			matches++
+			break
+		}
+	}
+
+	if matches == 0 {
+		return 0
+	}
+
+	// Count the matched characters that are out of order
+	transpositions := 0
+	j := 0
+	for i := range r1 {
+		if !matched1[i] {
+			continue
+		}
+		for !matched2[j] {
+			j++
+		}
+		if r1[i] != r2[j] {
+			transpositions++
+		}
+		j++
+	}
+
+	m := float64(matches)
+	return (m/float64(len(r1)) + m/float64(len(r2)) + (m-float64(transpositions)/2)/m) / 3
+}
@@ -0,0 +1,57 @@
+package compare
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestJaroWinkler(t *testing.T) {
+	testCases := []struct {
+		s1       string
+		s2       string
+		expected float64
+	}{
+		{
+			s1:       "martha",
+			s2:       "marhta",
+			expected: 96.11,
+		},
+		{
+			s1:       "dwayne",
+			s2:       "duane",
+			expected: 84,
+		},
+		{
+			s1:       "dixon",
+			s2:       "dicksonx",
+			expected: 81.33,
+		},
+		{
+			s1:       "match",
+			s2:       "match",
+			expected: 100,
+		},
+		{
+			s1:       "abc",
+			s2:       "xyz",
+			expected: 0,
+		},
+		{
+			s1:       "not empty",
+			s2:       "",
+			expected: 0,
+		},
+		{
+			s1:       "",
+			s2:       "",
+			expected: 100,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(fmt.Sprintf("%s/%s", tc.s1, tc.s2), func(t *testing.T) {
+			assert.InDelta(t, tc.expected, JaroWinkler(tc.s1, tc.s2), 0.01)
+		})
+	}
+}
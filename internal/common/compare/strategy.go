@@ -0,0 +1,58 @@
+package compare
+
+import "fmt"
+
+// Scorer calculates the similarity between two strings as a percentage.
+type Scorer func(s1, s2 string) float64
+
+// Strategy names a string similarity algorithm.
+type Strategy string
+
+const (
+	// LevenshteinStrategy scores the edit distance between the full strings
+	LevenshteinStrategy Strategy = "levenshtein"
+	// TokenSetStrategy scores the words in the strings, ignoring order
+	TokenSetStrategy Strategy = "tokenSet"
+	// JaroWinklerStrategy scores matching characters, favoring a shared prefix
+	JaroWinklerStrategy Strategy = "jaroWinkler"
+	// CombinedStrategy averages the levenshtein, token set, and jaro-winkler scores
+	CombinedStrategy Strategy = "combined"
+)
+
+var scorers = map[Strategy]Scorer{
+	LevenshteinStrategy: StringSimilarity,
+	TokenSetStrategy:    TokenSetRatio,
+	JaroWinklerStrategy: JaroWinkler,
+	CombinedStrategy:    Combined,
+}
+
+// AllStrategies returns the supported similarity strategies.
+func AllStrategies() []Strategy {
+	return []Strategy{
+		LevenshteinStrategy,
+		TokenSetStrategy,
+		JaroWinklerStrategy,
+		CombinedStrategy,
+	}
+}
+
+// NewScorer returns the Scorer for a strategy. The levenshtein scorer is returned
+// when the strategy is empty.
+func NewScorer(strategy Strategy) (Scorer, error) {
+	if strategy == "" {
+		return StringSimilarity, nil
+	}
+
+	scorer, ok := scorers[strategy]
+	if !ok {
+		return nil, fmt.Errorf("unknown similarity strategy %q", strategy)
+	}
+	return scorer, nil
+}
+
+// Combined calculates the similarity between two strings as the average of the
+// levenshtein, token set, and jaro-winkler similarities. Each algorithm has its own
+// blind spot, such as word order or trailing words, so averaging evens them out.
+func Combined(s1, s2 string) float64 {
+	return (StringSimilarity(s1, s2) + TokenSetRatio(s1, s2) + JaroWinkler(s1, s2)) / 3
+}
@@ -0,0 +1,36 @@
+package compare
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewScorer(t *testing.T) {
+	t.Run("supported strategies", func(t *testing.T) {
+		for _, strategy := range AllStrategies() {
+			scorer, err := NewScorer(strategy)
+			require.NoError(t, err, strategy)
+			assert.Equal(t, 100.0, scorer("never there", "never there"), strategy)
+		}
+	})
+
+	t.Run("empty strategy is levenshtein", func(t *testing.T) {
+		scorer, err := NewScorer("")
+		require.NoError(t, err)
+		assert.Equal(t, StringSimilarity("kitten", "sitting"), scorer("kitten", "sitting"))
+	})
+
+	t.Run("unknown strategy", func(t *testing.T) {
+		_, err := NewScorer("soundex")
+		assert.EqualError(t, err, `unknown similarity strategy "soundex"`)
+	})
+}
+
+func TestCombined(t *testing.T) {
+	expected := (StringSimilarity("there never", "never there") + 100 + JaroWinkler("there never", "never there")) / 3
+
+	assert.InDelta(t, expected, Combined("there never", "never there"), 0.001)
+	assert.Greater(t, Combined("there never", "never there"), StringSimilarity("there never", "never there"))
+}
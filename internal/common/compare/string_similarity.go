@@ -38,31 +38,31 @@ func StringSimilarity(s1, s2 string) float64 {
 }
 
 // levenshteinDistance calculates the edit distance between two rune slices.
-// This is the classic dynamic programming implementation.
+// Only the previous and current rows of the dynamic programming matrix are kept,
+// so the distance is calculated with two allocations regardless of string length.
 func levenshteinDistance(s1, s2 []rune) int {
+	// Use the shorter string for the columns to keep the rows small
+	if len(s1) < len(s2) {
+		s1, s2 = s2, s1
+	}
+
 	n := len(s1)
 	m := len(s2)
 
-	// Create a 2D slice (matrix) to store distances.
-	// dp[i][j] will hold the distance between the first i runes of s1
-	// and the first j runes of s2.
-	dp := make([][]int, n+1)
-	for i := range dp {
-		dp[i] = make([]int, m+1)
-	}
+	// prev[j] holds the distance between the first i-1 runes of s1 and the
+	// first j runes of s2, curr[j] the distance for the first i runes of s1.
+	prev := make([]int, m+1)
+	curr := make([]int, m+1)
 
-	// Initialize the first row and column.
 	// Cost of transforming an empty string to a non-empty string is
 	// just the number of insertions.
-	for i := 0; i <= n; i++ {
-		dp[i][0] = i // Deletions
-	}
 	for j := 0; j <= m; j++ {
-		dp[0][j] = j // Insertions
+		prev[j] = j
 	}
 
-	// Fill the rest of the matrix
 	for i := 1; i <= n; i++ {
+		curr[0] = i // Deletions
+
 		for j := 1; j <= m; j++ {
 			// Cost of substitution (0 if characters are equal, 1 otherwise)
 			cost := 0
@@ -71,28 +71,16 @@ func levenshteinDistance(s1, s2 []rune) int {
 			}
 
 			// Find the minimum cost from the three possible operations:
-			deletion := dp[i-1][j] + 1          // Cost of deleting char from s1
-			insertion := dp[i][j-1] + 1         // Cost of inserting char into s1
-			substitution := dp[i-1][j-1] + cost // Cost of substituting char
+			deletion := prev[j] + 1          // Cost of deleting char from s1
+			insertion := curr[j-1] + 1       // Cost of inserting char into s1
+			substitution := prev[j-1] + cost // Cost of substituting char
 
-			dp[i][j] = min(deletion, insertion, substitution)
+			curr[j] = min(deletion, insertion, substitution)
 		}
-	}
 
-	// The final distance is in the bottom-right cell
-	return dp[n][m]
-}
-
-// min helper function to find the minimum of three integers
-func min(a, b, c int) int {
-	if a < b {
-		if a < c {
-			return a
-		}
-	} else {
-		if b < c {
-			return b
-		}
+		prev, curr = curr, prev
 	}
-	return c
+
+	// After the last swap the final row is in prev
+	return prev[m]
 }
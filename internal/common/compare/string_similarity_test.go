@@ -45,3 +45,10 @@ func TestStringSimilarity(t *testing.T) {
 		})
 	}
 }
+
+func BenchmarkStringSimilarity(b *testing.B) {
+	b.ReportAllocs()
+	for b.Loop() {
+		StringSimilarity("prolonging the magic", "prolonging the magic (deluxe edition)")
+	}
+}
@@ -0,0 +1,48 @@
+package compare
+
+import (
+	"slices"
+	"strings"
+)
+
+// TokenSetRatio calculates the similarity between two strings as a percentage while
+// ignoring word order and repeated words. The words shared by both strings are compared
+// with each string's full set of words, so "Never There" and "There Never" are 100%
+// similar and a string that only adds words to the other scores highly.
+func TokenSetRatio(s1, s2 string) float64 {
+	tokens1 := tokenSet(s1)
+	tokens2 := tokenSet(s2)
+
+	var intersection, diff1, diff2 []string
+	for _, t := range tokens1 {
+		if _, found := slices.BinarySearch(tokens2, t); found {
+			intersection = append(intersection, t)
+		} else {
+			diff1 = append(diff1, t)
+		}
+	}
+	for _, t := range tokens2 {
+		if _, found := slices.BinarySearch(tokens1, t); !found {
+			diff2 = append(diff2, t)
+		}
+	}
+
+	shared := strings.Join(intersection, " ")
+	combined1 := strings.TrimSpace(shared + " " + strings.Join(diff1, " "))
+	combined2 := strings.TrimSpace(shared + " " + strings.Join(diff2, " "))
+
+	similarity := StringSimilarity(combined1, combined2)
+	if shared != "" {
+		similarity = max(similarity, StringSimilarity(shared, combined1), StringSimilarity(shared, combined2))
+	}
+
+	return similarity
+}
+
+// tokenSet returns the sorted, unique, whitespace separated words in a string
+func tokenSet(s string) []string {
+	tokens := strings.Fields(s)
+	slices.Sort(tokens)
+
+	return slices.Compact(tokens)
+}
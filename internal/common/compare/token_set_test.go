@@ -0,0 +1,52 @@
+package compare
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTokenSetRatio(t *testing.T) {
+	testCases := []struct {
+		s1       string
+		s2       string
+		expected float64
+	}{
+		{
+			s1:       "never there",
+			s2:       "there never",
+			expected: 100,
+		},
+		{
+			s1:       "never there",
+			s2:       "never there never",
+			expected: 100,
+		},
+		{
+			s1:       "prolonging the magic",
+			s2:       "prolonging the magic deluxe edition",
+			expected: 100,
+		},
+		{
+			s1:       "kind of blue",
+			s2:       "kind of red",
+			expected: 66.67,
+		},
+		{
+			s1:       "golang",
+			s2:       "java",
+			expected: 16.67,
+		},
+		{
+			s1:       "not empty",
+			s2:       "",
+			expected: 0,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(fmt.Sprintf("%s/%s", tc.s1, tc.s2), func(t *testing.T) {
+			assert.InDelta(t, tc.expected, TokenSetRatio(tc.s1, tc.s2), 0.01)
+		})
+	}
+}
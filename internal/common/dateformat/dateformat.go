@@ -1,6 +1,7 @@
 package dateformat
 
 const (
+	Year             = "2006"
 	YearMonth        = "2006-01"
 	MonthDayYearTime = "01-02-2006 15:04:05"
 )
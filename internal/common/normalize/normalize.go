@@ -0,0 +1,146 @@
+// Package normalize canonicalizes the artist, track, and album names found in radio
+// playlist metadata so the same recording is named the same way across sources.
+package normalize
+
+import (
+	"regexp"
+	"slices"
+	"strings"
+	"unicode"
+
+	"golang.org/x/text/runes"
+	"golang.org/x/text/transform"
+	"golang.org/x/text/unicode/norm"
+)
+
+var (
+	// featuredRe matches a featured artist credit and everything after it
+	featuredRe = regexp.MustCompile(`(?:^|[\s(\[])(?:feat\.?|ft\.?|featuring)\s.*$`)
+
+	// creditSeparatorRe matches the separators between credits of a multi-artist credit
+	creditSeparatorRe = regexp.MustCompile(`\s*(?:[,;/]|[\s(\[](?:feat\.?|ft\.?|featuring|with|x|vs\.?)\s)\s*`)
+
+	// jointCreditRe matches "and" between joint credits once "&" has been replaced
+	jointCreditRe = regexp.MustCompile(`\s+and\s+`)
+
+	// bracketRe matches trailing bracketed notes such as "(Live at The Fillmore)" or "[Radio Edit]"
+	bracketRe = regexp.MustCompile(`(?:\s*[(\[{][^)\]}]*[)\]}])+\s*$`)
+
+	// versionSuffixRe matches a version note after a dash such as "- 2011 Remaster" or "- Live"
+	versionSuffixRe = regexp.MustCompile(`\s+-\s+[^-]*\b(?:remaster(?:ed)?|live|edit|version|mix|mono|stereo|demo|acoustic|single|bonus|instrumental|deluxe|edition|recorded|session)\b.*$`)
+
+	// ampersandRe matches "&" and "+" used in place of "and"
+	ampersandRe = regexp.MustCompile(`\s*[&+]\s*`)
+
+	// apostropheRe matches apostrophes, which are dropped so "don't" becomes "dont"
+	apostropheRe = regexp.MustCompile(`['’‘` + "`" + `]`)
+
+	// punctuationRe matches everything that isn't a letter, number, or space
+	punctuationRe = regexp.MustCompile(`[^\p{L}\p{N}\s]+`)
+
+	whitespaceRe = regexp.MustCompile(`\s+`)
+)
+
+// letters that don't decompose into a base letter and a diacritic
+var foldedLetters = strings.NewReplacer(
+	"ø", "o",
+	"æ", "ae",
+	"œ", "oe",
+	"ß", "ss",
+	"đ", "d",
+	"ł", "l",
+)
+
+// Artist returns the canonical form of an artist credit. Featured artists are
+// dropped, so "Khruangbin feat. Leon Bridges" and "Khruangbin" are the same artist.
+func Artist(s string) string {
+	folded := fold(s)
+	return canonical(featuredRe.ReplaceAllString(folded, ""), folded)
+}
+
+// Artists splits a multi-artist credit into the canonical form of each artist. Credits
+// joined with "&" or "and" aren't split since they are usually a single act, for
+// example "Simon & Garfunkel".
+func Artists(s string) []string {
+	var artists []string
+	for _, credit := range creditSeparatorRe.Split(fold(s), -1) {
+		if a := canonical(credit, ""); a != "" {
+			artists = append(artists, a)
+		}
+	}
+
+	if len(artists) == 0 {
+		return []string{Artist(s)}
+	}
+	return artists
+}
+
+// ArtistCredits returns the possible readings of an artist credit as sets of canonical
+// artists, from the primary artist alone to every artist when joint credits such as
+// "Norah Jones & Mavis Staples" are split. A credit joined with "&" can be a single act
+// or several artists, so matching should use the reading that fits best.
+func ArtistCredits(s string) [][]string {
+	artists := Artists(s)
+
+	var joint []string
+	for _, a := range artists {
+		joint = append(joint, jointCreditRe.Split(a, -1)...)
+	}
+
+	credits := [][]string{{Artist(s)}}
+	for _, credit := range [][]string{artists, joint} {
+		if !slices.ContainsFunc(credits, func(c []string) bool { return slices.Equal(c, credit) }) {
+			credits = append(credits, credit)
+		}
+	}
+
+	return credits
+}
+
+// Title returns the canonical form of a track or album title. Bracketed notes,
+// version notes such as "- 2011 Remaster", and featured artists are dropped.
+func Title(s string) string {
+	folded := fold(s)
+
+	title := versionSuffixRe.ReplaceAllString(folded, "")
+	title = bracketRe.ReplaceAllString(title, "")
+	title = featuredRe.ReplaceAllString(title, "")
+
+	return canonical(title, folded)
+}
+
+// fold lowercases a string and removes diacritics.
+func fold(s string) string {
+	t := transform.Chain(norm.NFD, runes.Remove(runes.In(unicode.Mn)), norm.NFC)
+	folded, _, err := transform.String(t, strings.ToLower(s))
+	if err != nil {
+		folded = strings.ToLower(s)
+	}
+
+	return foldedLetters.Replace(folded)
+}
+
+// canonical replaces "&" with "and", removes punctuation, and collapses whitespace. The
+// fallback is used when nothing is left, for example when a band is named "!!!".
+func canonical(s, fallback string) string {
+	if c := clean(s); c != "" {
+		return c
+	}
+	if c := clean(fallback); c != "" {
+		return c
+	}
+
+	return collapse(fallback)
+}
+
+func clean(s string) string {
+	s = ampersandRe.ReplaceAllString(s, " and ")
+	s = apostropheRe.ReplaceAllString(s, "")
+	s = punctuationRe.ReplaceAllString(s, " ")
+
+	return collapse(s)
+}
+
+func collapse(s string) string {
+	return strings.TrimSpace(whitespaceRe.ReplaceAllString(s, " "))
+}
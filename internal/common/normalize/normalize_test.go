@@ -0,0 +1,288 @@
+package normalize
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestArtist(t *testing.T) {
+	testCases := []struct {
+		name     string
+		artist   string
+		expected string
+	}{
+		{
+			name:     "plain artist",
+			artist:   "Khruangbin",
+			expected: "khruangbin",
+		},
+		{
+			name:     "featured artist",
+			artist:   "Khruangbin feat. Leon Bridges",
+			expected: "khruangbin",
+		},
+		{
+			name:     "featured artist abbreviated",
+			artist:   "Mavis Staples ft. Jeff Tweedy",
+			expected: "mavis staples",
+		},
+		{
+			name:     "featured artist in brackets",
+			artist:   "Gorillaz (featuring Tame Impala & Bootie Brown)",
+			expected: "gorillaz",
+		},
+		{
+			name:     "ampersand",
+			artist:   "Simon & Garfunkel",
+			expected: "simon and garfunkel",
+		},
+		{
+			name:     "and",
+			artist:   "Simon and Garfunkel",
+			expected: "simon and garfunkel",
+		},
+		{
+			name:     "plus",
+			artist:   "Florence + The Machine",
+			expected: "florence and the machine",
+		},
+		{
+			name:     "diacritics",
+			artist:   "Beyoncé",
+			expected: "beyonce",
+		},
+		{
+			name:     "letters without diacritics",
+			artist:   "Sigur Rós & Mø",
+			expected: "sigur ros and mo",
+		},
+		{
+			name:     "punctuation",
+			artist:   "Guns N' Roses",
+			expected: "guns n roses",
+		},
+		{
+			name:     "extra whitespace",
+			artist:   "  The   National ",
+			expected: "the national",
+		},
+		{
+			name:     "only punctuation",
+			artist:   "!!!",
+			expected: "!!!",
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, Artist(tc.artist))
+		})
+	}
+}
+
+func TestArtists(t *testing.T) {
+	testCases := []struct {
+		name     string
+		artist   string
+		expected []string
+	}{
+		{
+			name:     "single artist",
+			artist:   "Waxahatchee",
+			expected: []string{"waxahatchee"},
+		},
+		{
+			name:     "featured artist",
+			artist:   "Khruangbin feat. Leon Bridges",
+			expected: []string{"khruangbin", "leon bridges"},
+		},
+		{
+			name:     "featured artist in brackets",
+			artist:   "Gorillaz (featuring Tame Impala & Bootie Brown)",
+			expected: []string{"gorillaz", "tame impala and bootie brown"},
+		},
+		{
+			name:     "comma separated",
+			artist:   "Bon Iver, St. Vincent",
+			expected: []string{"bon iver", "st vincent"},
+		},
+		{
+			name:     "with",
+			artist:   "Norah Jones with Mavis Staples",
+			expected: []string{"norah jones", "mavis staples"},
+		},
+		{
+			name:     "x",
+			artist:   "Kali Uchis x Tyler, The Creator",
+			expected: []string{"kali uchis", "tyler", "the creator"},
+		},
+		{
+			name:     "vs",
+			artist:   "Nina Simone vs. Felix Da Housecat",
+			expected: []string{"nina simone", "felix da housecat"},
+		},
+		{
+			name:     "slash",
+			artist:   "Lucius/Brandi Carlile",
+			expected: []string{"lucius", "brandi carlile"},
+		},
+		{
+			name:     "ampersand is not split",
+			artist:   "Simon & Garfunkel",
+			expected: []string{"simon and garfunkel"},
+		},
+		{
+			name:     "only punctuation",
+			artist:   "!!!",
+			expected: []string{"!!!"},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, Artists(tc.artist))
+		})
+	}
+}
+
+func TestArtistCredits(t *testing.T) {
+	testCases := []struct {
+		name     string
+		artist   string
+		expected [][]string
+	}{
+		{
+			name:     "single artist",
+			artist:   "Waxahatchee",
+			expected: [][]string{{"waxahatchee"}},
+		},
+		{
+			name:   "featured artist",
+			artist: "Khruangbin feat. Leon Bridges",
+			expected: [][]string{
+				{"khruangbin"},
+				{"khruangbin", "leon bridges"},
+			},
+		},
+		{
+			name:   "joint credit",
+			artist: "Norah Jones & Mavis Staples",
+			expected: [][]string{
+				{"norah jones and mavis staples"},
+				{"norah jones", "mavis staples"},
+			},
+		},
+		{
+			name:   "joint credit with featured artist",
+			artist: "Simon & Garfunkel feat. Paul Desmond",
+			expected: [][]string{
+				{"simon and garfunkel"},
+				{"simon and garfunkel", "paul desmond"},
+				{"simon", "garfunkel", "paul desmond"},
+			},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, ArtistCredits(tc.artist))
+		})
+	}
+}
+
+func TestTitle(t *testing.T) {
+	testCases := []struct {
+		name     string
+		title    string
+		expected string
+	}{
+		{
+			name:     "plain title",
+			title:    "So What",
+			expected: "so what",
+		},
+		{
+			name:     "remaster suffix",
+			title:    "Here Comes The Sun - 2019 Mix",
+			expected: "here comes the sun",
+		},
+		{
+			name:     "remastered suffix",
+			title:    "Heroes - 2017 Remastered Version",
+			expected: "heroes",
+		},
+		{
+			name:     "live suffix",
+			title:    "Harvest Moon - Live",
+			expected: "harvest moon",
+		},
+		{
+			name:     "dash that is part of the title",
+			title:    "Hey Ya! - Hey Ya!",
+			expected: "hey ya hey ya",
+		},
+		{
+			name:     "live note in brackets",
+			title:    "Cortez the Killer (Live at the Fillmore East)",
+			expected: "cortez the killer",
+		},
+		{
+			name:     "edit note in square brackets",
+			title:    "Nightcall [Radio Edit]",
+			expected: "nightcall",
+		},
+		{
+			name:     "featured artist",
+			title:    "Texas Sun feat. Leon Bridges",
+			expected: "texas sun",
+		},
+		{
+			name:     "featured artist in brackets",
+			title:    "Humility (feat. George Benson)",
+			expected: "humility",
+		},
+		{
+			name:     "ampersand",
+			title:    "Rock & Roll",
+			expected: "rock and roll",
+		},
+		{
+			name:     "diacritics",
+			title:    "Déjà Vu",
+			expected: "deja vu",
+		},
+		{
+			name:     "apostrophes",
+			title:    "Don’t Stop Believin'",
+			expected: "dont stop believin",
+		},
+		{
+			name:     "deluxe album",
+			title:    "Rumours (Super Deluxe)",
+			expected: "rumours",
+		},
+		{
+			name:     "edition album suffix",
+			title:    "Kind of Blue - Legacy Edition",
+			expected: "kind of blue",
+		},
+		{
+			name:     "only brackets",
+			title:    "(What's the Story)",
+			expected: "whats the story",
+		},
+		{
+			name:     "leading brackets",
+			title:    "(What's the Story) Morning Glory?",
+			expected: "whats the story morning glory",
+		},
+		{
+			name:     "multiple notes",
+			title:    "Sweet Jane (Full Length Version) [2015 Remaster]",
+			expected: "sweet jane",
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, Title(tc.title))
+		})
+	}
+}
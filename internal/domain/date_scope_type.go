@@ -1,5 +1,13 @@
 package domain
 
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/jbenzshawel/playlist-generator/internal/common/dateformat"
+)
+
 type PlaylistDateScope int
 
 const (
@@ -7,6 +15,9 @@ const (
 	DayPlaylistDateScope     PlaylistDateScope = 1
 	MonthPlaylistDateScope   PlaylistDateScope = 2
 	YearPlaylistDateScope    PlaylistDateScope = 3
+	// RollingPlaylistDateScope is used for playlists that hold the songs played
+	// over the last N days instead of a calendar period.
+	RollingPlaylistDateScope PlaylistDateScope = 4
 )
 
 var PlaylistDateScopes = map[PlaylistDateScope]string{
@@ -14,6 +25,7 @@ var PlaylistDateScopes = map[PlaylistDateScope]string{
 	DayPlaylistDateScope:     "Day",
 	MonthPlaylistDateScope:   "Month",
 	YearPlaylistDateScope:    "Year",
+	RollingPlaylistDateScope: "Rolling",
 }
 
 func (t PlaylistDateScope) String() string {
@@ -34,5 +46,60 @@ func AllPlaylistDateScopes() []PlaylistDateScope {
 		DayPlaylistDateScope,
 		MonthPlaylistDateScope,
 		YearPlaylistDateScope,
+		RollingPlaylistDateScope,
+	}
+}
+
+// ParsePlaylistDateScope returns the calendar scope matching a case-insensitive
+// scope name such as "day", "month", or "year".
+func ParsePlaylistDateScope(s string) (PlaylistDateScope, error) {
+	for _, scope := range AllPlaylistDateScopes() {
+		if scope != RollingPlaylistDateScope && strings.EqualFold(scope.String(), s) {
+			return scope, nil
+		}
+	}
+	return UnknownPlaylistDateScope, fmt.Errorf("unknown playlist date scope %q", s)
+}
+
+// Layout returns the time layout playlist dates are stored in for the scope.
+func (t PlaylistDateScope) Layout() string {
+	switch t {
+	case DayPlaylistDateScope:
+		return time.DateOnly
+	case YearPlaylistDateScope:
+		return dateformat.Year
+	default:
+		return dateformat.YearMonth
+	}
+}
+
+// Format returns the playlist date containing t for the scope.
+func (t PlaylistDateScope) Format(date time.Time) string {
+	return date.Format(t.Layout())
+}
+
+// Period returns the inclusive start and exclusive end of the scope period containing
+// date, such as the first day of its month and of the following month.
+func (t PlaylistDateScope) Period(date time.Time) (time.Time, time.Time) {
+	start := time.Date(date.Year(), date.Month(), date.Day(), 0, 0, 0, 0, time.UTC)
+	switch t {
+	case MonthPlaylistDateScope:
+		start = start.AddDate(0, 0, 1-date.Day())
+	case YearPlaylistDateScope:
+		start = time.Date(date.Year(), time.January, 1, 0, 0, 0, 0, time.UTC)
+	}
+
+	return start, t.next(start)
+}
+
+// next returns the start of the scope period following start.
+func (t PlaylistDateScope) next(start time.Time) time.Time {
+	switch t {
+	case DayPlaylistDateScope:
+		return start.AddDate(0, 0, 1)
+	case YearPlaylistDateScope:
+		return start.AddDate(1, 0, 0)
+	default:
+		return start.AddDate(0, 1, 0)
 	}
 }
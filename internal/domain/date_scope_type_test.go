@@ -0,0 +1,46 @@
+package domain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPlaylistDateScope_Period(t *testing.T) {
+	date := time.Date(2026, 10, 17, 22, 30, 0, 0, time.Local)
+
+	testCases := []struct {
+		name          string
+		dateScope     PlaylistDateScope
+		expectedStart string
+		expectedEnd   string
+	}{
+		{
+			name:          "day",
+			dateScope:     DayPlaylistDateScope,
+			expectedStart: "2026-10-17",
+			expectedEnd:   "2026-10-18",
+		},
+		{
+			name:          "month",
+			dateScope:     MonthPlaylistDateScope,
+			expectedStart: "2026-10-01",
+			expectedEnd:   "2026-11-01",
+		},
+		{
+			name:          "year",
+			dateScope:     YearPlaylistDateScope,
+			expectedStart: "2026-01-01",
+			expectedEnd:   "2027-01-01",
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			start, end := tc.dateScope.Period(date)
+
+			assert.Equal(t, tc.expectedStart, start.Format(time.DateOnly))
+			assert.Equal(t, tc.expectedEnd, end.Format(time.DateOnly))
+		})
+	}
+}
@@ -0,0 +1,127 @@
+package domain
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type MatchCandidateRepository interface {
+	// GetReviewSongs returns the songs with candidates waiting for review
+	GetReviewSongs(ctx context.Context) ([]Song, error)
+
+	// GetCandidates returns the candidates for a song ordered by rank
+	GetCandidates(ctx context.Context, songID uuid.UUID) ([]MatchCandidate, error)
+
+	// Replace replaces the candidates for a song
+	Replace(ctx context.Context, songID uuid.UUID, candidates []MatchCandidate) error
+
+	// Delete deletes the candidates for a song
+	Delete(ctx context.Context, songID uuid.UUID) error
+}
+
+// MatchCandidate is a track found for a song whose best match needs review before
+// it can be added to playlists. Candidates are ranked from the best match at rank 0.
+type MatchCandidate struct {
+	songID        uuid.UUID
+	rank          int
+	trackID       string
+	uri           string
+	matchMethod   MatchMethod
+	confidence    float64
+	matchedArtist string
+	matchedTrack  string
+	matchedAlbum  string
+	created       time.Time
+}
+
+// NewMatchCandidate returns a candidate ranked rank for the track a search matched.
+func NewMatchCandidate(rank int, track Track) MatchCandidate {
+	return MatchCandidate{
+		songID:        track.SongID(),
+		rank:          rank,
+		trackID:       track.TrackID(),
+		uri:           track.URI(),
+		matchMethod:   track.MatchMethod(),
+		confidence:    track.Confidence(),
+		matchedArtist: track.MatchedArtist(),
+		matchedTrack:  track.MatchedTrack(),
+		matchedAlbum:  track.MatchedAlbum(),
+		created:       time.Now(),
+	}
+}
+
+func NewMatchCandidateFromDB(
+	songID uuid.UUID,
+	rank int,
+	trackID string,
+	uri string,
+	matchMethod MatchMethod,
+	confidence float64,
+	matchedArtist string,
+	matchedTrack string,
+	matchedAlbum string,
+	created time.Time,
+) MatchCandidate {
+	return MatchCandidate{
+		songID:        songID,
+		rank:          rank,
+		trackID:       trackID,
+		uri:           uri,
+		matchMethod:   matchMethod,
+		confidence:    confidence,
+		matchedArtist: matchedArtist,
+		matchedTrack:  matchedTrack,
+		matchedAlbum:  matchedAlbum,
+		created:       created,
+	}
+}
+
+func (c MatchCandidate) SongID() uuid.UUID {
+	return c.songID
+}
+
+func (c MatchCandidate) Rank() int {
+	return c.rank
+}
+
+func (c MatchCandidate) TrackID() string {
+	return c.trackID
+}
+
+func (c MatchCandidate) URI() string {
+	return c.uri
+}
+
+// MatchMethod returns how the search matched the candidate
+func (c MatchCandidate) MatchMethod() MatchMethod {
+	return c.matchMethod
+}
+
+// Confidence returns the search's confidence in the candidate from 0 to 100
+func (c MatchCandidate) Confidence() float64 {
+	return c.confidence
+}
+
+func (c MatchCandidate) MatchedArtist() string {
+	return c.matchedArtist
+}
+
+func (c MatchCandidate) MatchedTrack() string {
+	return c.matchedTrack
+}
+
+func (c MatchCandidate) MatchedAlbum() string {
+	return c.matchedAlbum
+}
+
+func (c MatchCandidate) Created() time.Time {
+	return c.created
+}
+
+// Accept returns the Spotify track for the song once the candidate is accepted. A
+// reviewed match has full confidence so it isn't searched for again by a rematch.
+func (c MatchCandidate) Accept() Track {
+	return NewTrack(SpotifyPlaylistType, c.songID, c.trackID, c.uri, ReviewedMatchMethod, 100, c.matchedArtist, c.matchedTrack, c.matchedAlbum, "")
+}
@@ -0,0 +1,60 @@
+package domain
+
+// MatchMethod describes how a song was matched to a track in a playlist provider.
+type MatchMethod int
+
+const (
+	UnknownMatchMethod MatchMethod = 0
+	// ISRCMatchMethod is used when a track's ISRC matched the song's ISRC.
+	ISRCMatchMethod MatchMethod = 1
+	// UPCMatchMethod is used when a track on the album with the song's UPC
+	// matched the song title.
+	UPCMatchMethod MatchMethod = 2
+	// ExactMatchMethod is used when the artist, track, and album names matched exactly.
+	ExactMatchMethod MatchMethod = 3
+	// SingleResultMatchMethod is used when a search returned a single track.
+	SingleResultMatchMethod MatchMethod = 4
+	// FuzzyMatchMethod is used when the best scoring search result was above
+	// the minimum match threshold.
+	FuzzyMatchMethod MatchMethod = 5
+	// OverrideMatchMethod is used when the match was set by a match override.
+	OverrideMatchMethod MatchMethod = 6
+	// ReviewedMatchMethod is used when a candidate was accepted in the match review queue.
+	ReviewedMatchMethod MatchMethod = 7
+)
+
+var matchMethods = map[MatchMethod]string{
+	UnknownMatchMethod:      "Unknown",
+	ISRCMatchMethod:         "ISRC",
+	UPCMatchMethod:          "UPC",
+	ExactMatchMethod:        "Exact",
+	SingleResultMatchMethod: "Single Result",
+	FuzzyMatchMethod:        "Fuzzy",
+	OverrideMatchMethod:     "Override",
+	ReviewedMatchMethod:     "Reviewed",
+}
+
+func (m MatchMethod) String() string {
+	s, ok := matchMethods[m]
+	if !ok {
+		return "Unknown"
+	}
+	return s
+}
+
+func (m MatchMethod) IsValid() bool {
+	_, ok := matchMethods[m]
+	return ok
+}
+
+func AllMatchMethods() []MatchMethod {
+	return []MatchMethod{
+		ISRCMatchMethod,
+		UPCMatchMethod,
+		ExactMatchMethod,
+		SingleResultMatchMethod,
+		FuzzyMatchMethod,
+		OverrideMatchMethod,
+		ReviewedMatchMethod,
+	}
+}
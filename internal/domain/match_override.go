@@ -0,0 +1,97 @@
+package domain
+
+import (
+	"context"
+	"time"
+)
+
+type MatchOverrideRepository interface {
+	// GetMatchOverrides returns every match override
+	GetMatchOverrides(ctx context.Context) ([]MatchOverride, error)
+
+	// Upsert inserts an override or replaces the existing override for the song
+	Upsert(ctx context.Context, override MatchOverride) error
+}
+
+// MatchOverride pins a song to a Spotify track, or marks it as never matching any
+// track, instead of using the track found by searching Spotify.
+type MatchOverride struct {
+	songHash    string
+	trackID     string
+	uri         string
+	previousURI string
+	created     time.Time
+}
+
+// NewMatchOverride pins the song with songHash to a Spotify track URI. The track can be
+// a Spotify URI (spotify:track:<id>) or URL (https://open.spotify.com/track/<id>). The
+// previous URI is the URI the song was matched to before the override.
+func NewMatchOverride(songHash, track, previousURI string) (MatchOverride, error) {
+	trackID, uri, err := ParseSpotifyTrackURI(track)
+	if err != nil {
+		return MatchOverride{}, err
+	}
+
+	return MatchOverride{
+		songHash:    songHash,
+		trackID:     trackID,
+		uri:         uri,
+		previousURI: previousURI,
+		created:     time.Now(),
+	}, nil
+}
+
+// NewNeverMatchOverride marks the song with songHash as never matching a Spotify track.
+func NewNeverMatchOverride(songHash, previousURI string) MatchOverride {
+	return MatchOverride{
+		songHash:    songHash,
+		previousURI: previousURI,
+		created:     time.Now(),
+	}
+}
+
+func NewMatchOverrideFromDB(songHash, trackID, uri, previousURI string, created time.Time) MatchOverride {
+	return MatchOverride{
+		songHash:    songHash,
+		trackID:     trackID,
+		uri:         uri,
+		previousURI: previousURI,
+		created:     created,
+	}
+}
+
+func (o MatchOverride) SongHash() string {
+	return o.songHash
+}
+
+func (o MatchOverride) TrackID() string {
+	return o.trackID
+}
+
+func (o MatchOverride) URI() string {
+	return o.uri
+}
+
+// PreviousURI returns the URI the song was matched to before the override. It is empty
+// when the song wasn't matched to a track.
+func (o MatchOverride) PreviousURI() string {
+	return o.previousURI
+}
+
+// NeverMatch returns true when the song should never be matched to a Spotify track.
+func (o MatchOverride) NeverMatch() bool {
+	return o.uri == ""
+}
+
+func (o MatchOverride) Created() time.Time {
+	return o.created
+}
+
+// Track returns the Spotify track the override matches the song to.
+func (o MatchOverride) Track(song Song) Track {
+	if o.NeverMatch() {
+		return NewNeverMatchTrack(SpotifyPlaylistType, song.ID())
+	}
+
+	return NewTrack(SpotifyPlaylistType, song.ID(), o.trackID, o.uri, OverrideMatchMethod, 100, "", "", "", "")
+}
@@ -0,0 +1,95 @@
+package domain
+
+import (
+	"fmt"
+
+	"github.com/jbenzshawel/playlist-generator/internal/common/cerror"
+	"github.com/jbenzshawel/playlist-generator/internal/common/compare"
+)
+
+const (
+	defaultArtistWeight = 0.35
+	defaultTrackWeight  = 0.40
+	defaultAlbumWeight  = 0.25
+)
+
+// MatchScoring configures how a song is scored against a track found by searching a
+// streaming provider. The zero value uses levenshtein similarity and the default weights,
+// and never queues matches for review.
+type MatchScoring struct {
+	similarity   compare.Scorer
+	artistWeight float64
+	trackWeight  float64
+	albumWeight  float64
+	reviewBelow  float64
+}
+
+// NewMatchScoring returns the scoring for a similarity strategy and the weights of the
+// artist, track, and album similarities. The weights are relative to each other, and the
+// default weights are used when they are all zero. Fuzzy, single result, and UPC matches
+// with a confidence below reviewBelow need review, review is disabled when it is zero.
+func NewMatchScoring(strategy compare.Strategy, artistWeight, trackWeight, albumWeight, reviewBelow float64) (MatchScoring, error) {
+	fieldErrors := make(map[string]string)
+
+	similarity, err := compare.NewScorer(strategy)
+	if err != nil {
+		fieldErrors["strategy"] = fmt.Sprintf("must be one of %v", compare.AllStrategies())
+	}
+
+	weights := map[string]float64{"artist": artistWeight, "track": trackWeight, "album": albumWeight}
+	for name, weight := range weights {
+		if weight < 0 {
+			fieldErrors[name] = "weight cannot be negative"
+		}
+	}
+
+	if reviewBelow < 0 || reviewBelow > 100 {
+		fieldErrors["reviewBelow"] = "must be from 0 to 100"
+	}
+
+	if len(fieldErrors) > 0 {
+		return MatchScoring{}, cerror.NewValidationError("invalid match scoring", fieldErrors)
+	}
+
+	total := artistWeight + trackWeight + albumWeight
+	if total == 0 {
+		return MatchScoring{similarity: similarity, reviewBelow: reviewBelow}, nil
+	}
+
+	return MatchScoring{
+		similarity:   similarity,
+		artistWeight: artistWeight / total,
+		trackWeight:  trackWeight / total,
+		albumWeight:  albumWeight / total,
+		reviewBelow:  reviewBelow,
+	}, nil
+}
+
+// Similarity returns the similarity of two strings as a percentage
+func (m MatchScoring) Similarity(s1, s2 string) float64 {
+	if m.similarity == nil {
+		return compare.StringSimilarity(s1, s2)
+	}
+	return m.similarity(s1, s2)
+}
+
+// WeightedAverage returns the confidence, from 0 to 100, of a match from the artist,
+// track, and album similarities.
+func (m MatchScoring) WeightedAverage(artist, track, album float64) float64 {
+	if m.artistWeight == 0 && m.trackWeight == 0 && m.albumWeight == 0 {
+		return artist*defaultArtistWeight + track*defaultTrackWeight + album*defaultAlbumWeight
+	}
+	return artist*m.artistWeight + track*m.trackWeight + album*m.albumWeight
+}
+
+// NeedsReview returns true when a track was matched by scoring search results, or by
+// the name of a track on the album with the song's UPC, with a confidence too low to
+// trust without review.
+func (m MatchScoring) NeedsReview(track Track) bool {
+	switch track.MatchMethod() {
+	case FuzzyMatchMethod, SingleResultMatchMethod, UPCMatchMethod:
+	default:
+		return false
+	}
+	return track.Confidence() < m.reviewBelow
+}
@@ -0,0 +1,59 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/jbenzshawel/playlist-generator/internal/common/cerror"
+	"github.com/jbenzshawel/playlist-generator/internal/common/compare"
+)
+
+func TestNewMatchScoring(t *testing.T) {
+	t.Run("default weights", func(t *testing.T) {
+		scoring, err := NewMatchScoring("", 0, 0, 0, 0)
+		require.NoError(t, err)
+
+		assert.InDelta(t, 35+40+25*0.5, scoring.WeightedAverage(100, 100, 50), 0.001)
+		assert.Equal(t, compare.StringSimilarity("kitten", "sitting"), scoring.Similarity("kitten", "sitting"))
+	})
+
+	t.Run("zero value uses defaults", func(t *testing.T) {
+		scoring := MatchScoring{}
+
+		assert.InDelta(t, 35+40+25*0.5, scoring.WeightedAverage(100, 100, 50), 0.001)
+		assert.Equal(t, compare.StringSimilarity("kitten", "sitting"), scoring.Similarity("kitten", "sitting"))
+	})
+
+	t.Run("weights are relative", func(t *testing.T) {
+		scoring, err := NewMatchScoring(compare.TokenSetStrategy, 1, 2, 1, 0)
+		require.NoError(t, err)
+
+		assert.InDelta(t, 25+50, scoring.WeightedAverage(100, 100, 0), 0.001)
+		assert.Equal(t, 100.0, scoring.Similarity("never there", "there never"))
+	})
+
+	t.Run("invalid scoring", func(t *testing.T) {
+		_, err := NewMatchScoring("soundex", -1, 1, 1, 101)
+		assert.Equal(t, cerror.NewValidationError("invalid match scoring", map[string]string{
+			"strategy":    "must be one of [levenshtein tokenSet jaroWinkler combined]",
+			"artist":      "weight cannot be negative",
+			"reviewBelow": "must be from 0 to 100",
+		}), err)
+	})
+
+	t.Run("needs review", func(t *testing.T) {
+		scoring, err := NewMatchScoring("", 0, 0, 0, 85)
+		require.NoError(t, err)
+
+		songID := uuid.New()
+		assert.True(t, scoring.NeedsReview(NewTrack(SpotifyPlaylistType, songID, "id", "uri", FuzzyMatchMethod, 84.9, "", "", "", "")))
+		assert.True(t, scoring.NeedsReview(NewTrack(SpotifyPlaylistType, songID, "id", "uri", SingleResultMatchMethod, 70, "", "", "", "")))
+		assert.False(t, scoring.NeedsReview(NewTrack(SpotifyPlaylistType, songID, "id", "uri", FuzzyMatchMethod, 85, "", "", "", "")))
+		assert.True(t, scoring.NeedsReview(NewTrack(SpotifyPlaylistType, songID, "id", "uri", UPCMatchMethod, 72, "", "", "", "")))
+		assert.False(t, scoring.NeedsReview(NewTrack(SpotifyPlaylistType, songID, "id", "uri", ISRCMatchMethod, 72, "", "", "", "")))
+		assert.False(t, MatchScoring{}.NeedsReview(NewTrack(SpotifyPlaylistType, songID, "id", "uri", FuzzyMatchMethod, 72, "", "", "", "")))
+	})
+}
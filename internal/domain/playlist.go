@@ -5,20 +5,25 @@ import (
 	"fmt"
 	"log/slog"
 	"time"
-
-	"github.com/jbenzshawel/playlist-generator/internal/common/dateformat"
 )
 
 type PlaylistRepository interface {
 	GetPlaylistByID(ctx context.Context, id string) (Playlist, error)
-	// GetPlaylistByDate returns a playlist that matches a type and date. Note playlists could
-	// eventually be scoped to month, day, or year so date is intentionally a string. Follow YYYY-MM-DD convention.
-	GetPlaylistByDate(ctx context.Context, playlistType PlaylistType, date string) (Playlist, error)
+	// GetPlaylistByDate returns a playlist that matches a type, source, program, date scope, and date. An
+	// empty program name matches playlists for every program played at a source. The date format depends
+	// on the scope (YYYY-MM-DD, YYYY-MM, or YYYY), see PlaylistDateScope.Layout.
+	GetPlaylistByDate(ctx context.Context, playlistType PlaylistType, sourceType SourceType, programName string, dateScope PlaylistDateScope, date string) (Playlist, error)
 
 	Insert(ctx context.Context, playlist Playlist) error
 	SetLastDaySynced(ctx context.Context, id, lastDaySynced string) error
+	// SetRollingWindow renames a rolling playlist and changes the number of days in its window
+	SetRollingWindow(ctx context.Context, id, name string, days int) error
 }
 
+// RollingPlaylistDate is the date stored for rolling playlists. A source or program has
+// one rolling playlist whatever the size of its window, which is stored separately.
+const RollingPlaylistDate = "rolling"
+
 // Playlist represents a playlist created from the generator.
 type Playlist struct {
 	id            string
@@ -28,36 +33,51 @@ type Playlist struct {
 	dateScope     PlaylistDateScope
 	playlistType  PlaylistType
 	sourceType    SourceType
+	programName   string
 	lastDaySynced string
-	created       time.Time
+	// rollingDays is the number of days in a rolling playlist's window
+	rollingDays int
+	created     time.Time
 }
 
-func NewPlaylist(id, uri, name, date string, playlistType PlaylistType, sourceType SourceType) Playlist {
+func NewPlaylist(id, uri, name, date string, dateScope PlaylistDateScope, playlistType PlaylistType, sourceType SourceType, programName string) Playlist {
 	return Playlist{
 		id:           id,
 		uri:          uri,
 		name:         name,
 		date:         date,
-		dateScope:    MonthPlaylistDateScope, // TODO: persist and support other scopes
+		dateScope:    dateScope,
 		playlistType: playlistType,
 		sourceType:   sourceType,
+		programName:  programName,
 		created:      time.Now(),
 	}
 }
 
-func NewPlaylistFromDB(id, uri, date, name string, playlistType PlaylistType, sourceType SourceType, lastDaySynced string, created time.Time) Playlist {
+func NewPlaylistFromDB(id, uri, date, name string, dateScope PlaylistDateScope, playlistType PlaylistType, sourceType SourceType, programName, lastDaySynced string, rollingDays int, created time.Time) Playlist {
 	return Playlist{
 		id:            id,
 		uri:           uri,
 		name:          name,
 		date:          date,
-		dateScope:     MonthPlaylistDateScope,
+		dateScope:     dateScope,
 		playlistType:  playlistType,
 		sourceType:    sourceType,
+		programName:   programName,
 		lastDaySynced: lastDaySynced,
+		rollingDays:   rollingDays,
 		created:       created,
 	}
 }
+
+// WithRollingWindow returns the rolling playlist with a new name and number of days in
+// its window.
+func (p Playlist) WithRollingWindow(name string, days int) Playlist {
+	p.name = name
+	p.rollingDays = days
+	return p
+}
+
 func (p Playlist) IsZero() bool {
 	return p == Playlist{}
 }
@@ -82,20 +102,57 @@ func (p Playlist) DateScope() PlaylistDateScope {
 	return p.dateScope
 }
 
-// StartDate returns the inclusive playlist start date
-func (p Playlist) StartDate() string {
-	return fmt.Sprintf("%s-01", p.Date()) // Date is in YYYY-MM format
+// StartDate returns the inclusive playlist start date in YYYY-MM-DD. For rolling
+// playlists the start date is N-1 days before the current date.
+func (p Playlist) StartDate() (string, error) {
+	start, err := p.start()
+	if err != nil {
+		return "", err
+	}
+
+	return start.Format(time.DateOnly), nil
 }
 
-// EndDate returns the exclusive playlist end date
+// EndDate returns the exclusive playlist end date in YYYY-MM-DD. For rolling
+// playlists the end date is the day after the current date.
 func (p Playlist) EndDate() (string, error) {
-	t, err := time.Parse(dateformat.YearMonth, p.Date())
+	if p.DateScope() == RollingPlaylistDateScope {
+		return today().AddDate(0, 0, 1).Format(time.DateOnly), nil
+	}
+
+	start, err := p.start()
 	if err != nil {
 		return "", err
 	}
 
-	end := t.AddDate(0, 1, 0)
-	return fmt.Sprintf("%s-%s", end.Format(dateformat.YearMonth), "01"), nil
+	return p.DateScope().next(start).Format(time.DateOnly), nil
+}
+
+// RollingDays returns the number of days in a rolling playlist's window
+func (p Playlist) RollingDays() (int, error) {
+	if p.rollingDays < 1 {
+		return 0, fmt.Errorf("invalid rolling playlist days %d", p.rollingDays)
+	}
+
+	return p.rollingDays, nil
+}
+
+func (p Playlist) start() (time.Time, error) {
+	if p.DateScope() == RollingPlaylistDateScope {
+		days, err := p.RollingDays()
+		if err != nil {
+			return time.Time{}, err
+		}
+
+		return today().AddDate(0, 0, 1-days), nil
+	}
+
+	t, err := time.Parse(p.DateScope().Layout(), p.Date())
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid %s playlist date %q: %w", p.DateScope(), p.Date(), err)
+	}
+
+	return t, nil
 }
 
 func (p Playlist) PlaylistType() PlaylistType {
@@ -106,6 +163,12 @@ func (p Playlist) SourceType() SourceType {
 	return p.sourceType
 }
 
+// ProgramName returns the source program the playlist is limited to. Playlists
+// with an empty program name include songs from every program.
+func (p Playlist) ProgramName() string {
+	return p.programName
+}
+
 func (p Playlist) LastDaySynced() string {
 	return p.lastDaySynced
 }
@@ -117,8 +180,16 @@ func (p Playlist) Created() time.Time {
 func (p Playlist) LogValue() slog.Value {
 	return slog.GroupValue(
 		slog.String("date", p.Date()),
+		slog.String("dateScope", p.DateScope().String()),
 		slog.String("lastDaySynced", p.LastDaySynced()),
 		slog.String("name", p.Name()),
+		slog.String("programName", p.ProgramName()),
+		slog.Int("rollingDays", p.rollingDays),
 		slog.String("uri", p.URI()),
 	)
 }
+
+func today() time.Time {
+	now := time.Now()
+	return time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+}
@@ -0,0 +1,105 @@
+package domain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPlaylist_DateRange(t *testing.T) {
+	testCases := []struct {
+		name          string
+		date          string
+		dateScope     PlaylistDateScope
+		expectedStart string
+		expectedEnd   string
+	}{
+		{
+			name:          "day",
+			date:          "2026-10-17",
+			dateScope:     DayPlaylistDateScope,
+			expectedStart: "2026-10-17",
+			expectedEnd:   "2026-10-18",
+		},
+		{
+			name:          "month",
+			date:          "2026-10",
+			dateScope:     MonthPlaylistDateScope,
+			expectedStart: "2026-10-01",
+			expectedEnd:   "2026-11-01",
+		},
+		{
+			name:          "year",
+			date:          "2026",
+			dateScope:     YearPlaylistDateScope,
+			expectedStart: "2026-01-01",
+			expectedEnd:   "2027-01-01",
+		},
+		{
+			name:          "end of year day",
+			date:          "2026-12-31",
+			dateScope:     DayPlaylistDateScope,
+			expectedStart: "2026-12-31",
+			expectedEnd:   "2027-01-01",
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			p := NewPlaylist("id", "uri", "name", tc.date, tc.dateScope, SpotifyPlaylistType, StudioOneSourceType, "")
+
+			start, err := p.StartDate()
+			require.NoError(t, err)
+			assert.Equal(t, tc.expectedStart, start)
+
+			end, err := p.EndDate()
+			require.NoError(t, err)
+			assert.Equal(t, tc.expectedEnd, end)
+		})
+	}
+
+	t.Run("rolling", func(t *testing.T) {
+		p := NewPlaylist("id", "uri", "name", RollingPlaylistDate, RollingPlaylistDateScope, SpotifyPlaylistType, StudioOneSourceType, "").WithRollingWindow("name", 7)
+
+		days, err := p.RollingDays()
+		require.NoError(t, err)
+		assert.Equal(t, 7, days)
+
+		start, err := p.StartDate()
+		require.NoError(t, err)
+		assert.Equal(t, time.Now().AddDate(0, 0, -6).Format(time.DateOnly), start)
+
+		end, err := p.EndDate()
+		require.NoError(t, err)
+		assert.Equal(t, time.Now().AddDate(0, 0, 1).Format(time.DateOnly), end)
+	})
+
+	t.Run("date does not match scope", func(t *testing.T) {
+		p := NewPlaylist("id", "uri", "name", "2026-10", DayPlaylistDateScope, SpotifyPlaylistType, StudioOneSourceType, "")
+
+		_, err := p.StartDate()
+		assert.Error(t, err)
+
+		_, err = p.EndDate()
+		assert.Error(t, err)
+	})
+}
+
+func TestParsePlaylistDateScope(t *testing.T) {
+	for _, scope := range []PlaylistDateScope{DayPlaylistDateScope, MonthPlaylistDateScope, YearPlaylistDateScope} {
+		actual, err := ParsePlaylistDateScope(scope.String())
+		require.NoError(t, err)
+		assert.Equal(t, scope, actual)
+	}
+
+	actual, err := ParsePlaylistDateScope("day")
+	require.NoError(t, err)
+	assert.Equal(t, DayPlaylistDateScope, actual)
+
+	_, err = ParsePlaylistDateScope("week")
+	assert.Error(t, err)
+
+	_, err = ParsePlaylistDateScope("rolling")
+	assert.Error(t, err)
+}
@@ -4,13 +4,20 @@ package domain
 type PlaylistType int
 
 const (
-	UnknownPlaylistType PlaylistType = 0
-	SpotifyPlaylistType PlaylistType = 1
+	UnknownPlaylistType    PlaylistType = 0
+	SpotifyPlaylistType    PlaylistType = 1
+	AppleMusicPlaylistType PlaylistType = 2
+	TidalPlaylistType      PlaylistType = 3
+	// SubsonicPlaylistType is a self-hosted Subsonic API server such as Navidrome
+	SubsonicPlaylistType PlaylistType = 4
 )
 
 var playlistTypes = map[PlaylistType]string{
-	UnknownPlaylistType: "Unknown",
-	SpotifyPlaylistType: "Spotify",
+	UnknownPlaylistType:    "Unknown",
+	SpotifyPlaylistType:    "Spotify",
+	AppleMusicPlaylistType: "Apple Music",
+	TidalPlaylistType:      "Tidal",
+	SubsonicPlaylistType:   "Subsonic",
 }
 
 func (t PlaylistType) String() string {
@@ -29,5 +36,8 @@ func (t PlaylistType) IsValid() bool {
 func AllPlaylistTypes() []PlaylistType {
 	return []PlaylistType{
 		SpotifyPlaylistType,
+		AppleMusicPlaylistType,
+		TidalPlaylistType,
+		SubsonicPlaylistType,
 	}
 }
@@ -6,7 +6,11 @@ type Repository interface {
 	Playlist() PlaylistRepository
 	Song() SongRepository
 	SongSource() SongSourceRepository
-	SpotifyTrack() SpotifyTrackRepository
+	// Track returns the repository of the tracks songs were matched to on a provider,
+	// which is one of AllPlaylistTypes.
+	Track(playlistType PlaylistType) TrackRepository
+	MatchOverride() MatchOverrideRepository
+	MatchCandidate() MatchCandidateRepository
 
 	Begin(ctx context.Context) error
 	Rollback() error
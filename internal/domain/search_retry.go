@@ -0,0 +1,29 @@
+package domain
+
+import "time"
+
+const (
+	// MaxSearchAttempts is the number of times a song that isn't found on Spotify is
+	// searched for before giving up.
+	MaxSearchAttempts = 10
+
+	// searchRetryBaseDelay is the delay before the second search for a song. The
+	// delay doubles after every attempt up to searchRetryMaxDelay.
+	searchRetryBaseDelay = 24 * time.Hour
+	searchRetryMaxDelay  = 32 * 24 * time.Hour
+)
+
+// nextSearchAttempt returns when a song that wasn't found after attemptCount searches
+// should be searched for again, or the zero time once MaxSearchAttempts is reached.
+func nextSearchAttempt(lastAttempt time.Time, attemptCount int) time.Time {
+	if attemptCount >= MaxSearchAttempts {
+		return time.Time{}
+	}
+
+	delay := searchRetryBaseDelay
+	for i := 1; i < attemptCount && delay < searchRetryMaxDelay; i++ {
+		delay *= 2
+	}
+
+	return lastAttempt.Add(min(delay, searchRetryMaxDelay))
+}
@@ -0,0 +1,39 @@
+package domain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNextSearchAttempt(t *testing.T) {
+	lastAttempt := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	testCases := []struct {
+		attemptCount int
+		expected     time.Time
+	}{
+		{attemptCount: 1, expected: lastAttempt.AddDate(0, 0, 1)},
+		{attemptCount: 2, expected: lastAttempt.AddDate(0, 0, 2)},
+		{attemptCount: 3, expected: lastAttempt.AddDate(0, 0, 4)},
+		{attemptCount: 6, expected: lastAttempt.AddDate(0, 0, 32)},
+		{attemptCount: 7, expected: lastAttempt.AddDate(0, 0, 32)},
+		{attemptCount: MaxSearchAttempts - 1, expected: lastAttempt.AddDate(0, 0, 32)},
+		{attemptCount: MaxSearchAttempts, expected: time.Time{}},
+	}
+	for _, tc := range testCases {
+		assert.Equal(t, tc.expected, nextSearchAttempt(lastAttempt, tc.attemptCount), "attempt %d", tc.attemptCount)
+	}
+}
+
+func TestNewNotFoundTrack(t *testing.T) {
+	track := NewNotFoundTrack(SpotifyPlaylistType, uuid.New(), 2)
+
+	assert.False(t, track.MatchFound())
+	assert.Equal(t, 2, track.AttemptCount())
+	assert.WithinDuration(t, time.Now(), track.LastAttempt(), time.Second)
+	assert.Equal(t, track.LastAttempt().Add(2*searchRetryBaseDelay), track.NextAttempt())
+	assert.True(t, track.MatchedAt().IsZero())
+}
@@ -6,10 +6,24 @@ import (
 	"time"
 
 	"github.com/google/uuid"
+
+	"github.com/jbenzshawel/playlist-generator/internal/common/normalize"
 )
 
 type SongRepository interface {
 	BulkInsert(ctx context.Context, songs []Song) error
+
+	// GetSongByHash returns the song with a song hash. An empty song is returned when
+	// no song has the hash.
+	GetSongByHash(ctx context.Context, songHash string) (Song, error)
+
+	// GetSongsByArtistTrack returns the songs, from any album, with a case-insensitive
+	// artist and track name.
+	GetSongsByArtistTrack(ctx context.Context, artist, track string) ([]Song, error)
+
+	// GetSongsByArtist returns the songs crediting an artist, including songs where the
+	// artist is featured or credited with other artists.
+	GetSongsByArtist(ctx context.Context, artist string) ([]Song, error)
 }
 
 // Song represents a song downloaded from a playlist data source.
@@ -24,7 +38,7 @@ type Song struct {
 }
 
 func NewSong(artist, track, album, upc string) (Song, error) {
-	songHash, err := newSongHash(artist, track, album)
+	songHash, err := NewSongHash(artist, track, album)
 	if err != nil {
 		return Song{}, err
 	}
@@ -64,6 +78,12 @@ func (s Song) Track() string {
 	return s.track
 }
 
+// Artists returns the normalized artists parsed from the artist credit, for example
+// "Khruangbin feat. Leon Bridges" is credited to "khruangbin" and "leon bridges".
+func (s Song) Artists() []string {
+	return normalize.Artists(s.artist)
+}
+
 func (s Song) Album() string {
 	return s.album
 }
@@ -4,18 +4,24 @@ import (
 	"crypto/sha256"
 	"encoding/hex"
 	"fmt"
-	"strings"
 
 	"github.com/jbenzshawel/playlist-generator/internal/common/cerror"
+	"github.com/jbenzshawel/playlist-generator/internal/common/normalize"
 )
 
-func newSongHash(artist, track, album string) (string, error) {
+// SongHashVersion is incremented whenever the way a song hash is calculated changes so
+// stored hashes can be recalculated by a storage migration.
+const SongHashVersion = 2
+
+// NewSongHash returns the hash identifying a song. The artist, track, and album are
+// normalized first so small differences in the playlist metadata map to the same song.
+func NewSongHash(artist, track, album string) (string, error) {
 	if err := validateSongHash(artist, track, album); err != nil {
 		return "", err
 	}
 
-	song := fmt.Sprintf("%s-%s-%s", artist, track, album)
-	hashBytes := sha256.Sum256([]byte(strings.ToLower(song)))
+	song := fmt.Sprintf("%s-%s-%s", normalize.Artist(artist), normalize.Title(track), normalize.Title(album))
+	hashBytes := sha256.Sum256([]byte(song))
 
 	return hex.EncodeToString(hashBytes[:]), nil
 }
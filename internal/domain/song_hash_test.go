@@ -33,6 +33,20 @@ func TestNewHash(t *testing.T) {
 			album:        "kind OF bLUE",
 			expectedHash: soWhatHash,
 		},
+		{
+			name:         "ignores version notes",
+			artist:       "Miles Davis",
+			track:        "So What - 1997 Remaster",
+			album:        "Kind of Blue (Legacy Edition)",
+			expectedHash: soWhatHash,
+		},
+		{
+			name:         "ignores featured artists",
+			artist:       "Miles Davis feat. John Coltrane",
+			track:        "So What",
+			album:        "Kind of Blue",
+			expectedHash: soWhatHash,
+		},
 		{
 			name:  "empty artist",
 			track: "SO WHAT",
@@ -60,7 +74,7 @@ func TestNewHash(t *testing.T) {
 	}
 	for _, tc := range testCases {
 		t.Run(tc.name, func(t *testing.T) {
-			actual, err := newSongHash(tc.artist, tc.track, tc.album)
+			actual, err := NewSongHash(tc.artist, tc.track, tc.album)
 			assert.Equal(t, tc.expectedHash, actual)
 			assert.Equal(t, err, tc.expectedErr)
 		})
@@ -9,6 +9,13 @@ import (
 
 type SongSourceRepository interface {
 	BulkInsert(ctx context.Context, songs []SongSource) error
+
+	// GetSongSources returns every play of the song with a song hash
+	GetSongSources(ctx context.Context, songHash string) ([]SongSource, error)
+
+	// GetSongsPlayedInRange returns every play of a song at a source within a date range,
+	// ordered by when the song was played. Start is inclusive and end date is exclusive.
+	GetSongsPlayedInRange(ctx context.Context, sourceType SourceType, startDate, endDate string) ([]SongPlay, error)
 }
 
 // SongSource represents the source that a playlist's song came from. The source
@@ -82,3 +89,24 @@ func (s SongSource) EndTime() time.Time {
 func (s SongSource) Created() time.Time {
 	return s.created
 }
+
+// SongPlay is a song along with the source that played it.
+type SongPlay struct {
+	song   Song
+	source SongSource
+}
+
+func NewSongPlay(song Song, source SongSource) SongPlay {
+	return SongPlay{
+		song:   song,
+		source: source,
+	}
+}
+
+func (p SongPlay) Song() Song {
+	return p.song
+}
+
+func (p SongPlay) Source() SongSource {
+	return p.source
+}
@@ -4,13 +4,15 @@ package domain
 type SourceType int
 
 const (
-	UnknownSourceType   SourceType = 0
-	StudioOneSourceType SourceType = 1
+	UnknownSourceType    SourceType = 0
+	StudioOneSourceType  SourceType = 1
+	TheCurrentSourceType SourceType = 2
 )
 
 var sourceTypes = map[SourceType]string{
-	UnknownSourceType:   "Unknown",
-	StudioOneSourceType: "Studio One",
+	UnknownSourceType:    "Unknown",
+	StudioOneSourceType:  "Studio One",
+	TheCurrentSourceType: "The Current",
 }
 
 func (t SourceType) String() string {
@@ -29,5 +31,6 @@ func (t SourceType) IsValid() bool {
 func AllSourceTypes() []SourceType {
 	return []SourceType{
 		StudioOneSourceType,
+		TheCurrentSourceType,
 	}
 }
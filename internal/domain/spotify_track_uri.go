@@ -0,0 +1,32 @@
+package domain
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+const spotifyTrackURIPrefix = "spotify:track:"
+
+// ParseSpotifyTrackURI returns the track ID and URI for a Spotify track URI
+// (spotify:track:<id>) or URL (https://open.spotify.com/track/<id>).
+func ParseSpotifyTrackURI(s string) (trackID, uri string, err error) {
+	s = strings.TrimSpace(s)
+
+	trackID, ok := strings.CutPrefix(s, spotifyTrackURIPrefix)
+	if !ok {
+		trackID = ""
+		u, parseErr := url.Parse(s)
+		if parseErr == nil && u.Host == "open.spotify.com" {
+			if id, ok := strings.CutPrefix(u.Path, "/track/"); ok {
+				trackID = id
+			}
+		}
+	}
+
+	if trackID == "" || strings.ContainsAny(trackID, ":/") {
+		return "", "", fmt.Errorf("invalid spotify track %q", s)
+	}
+
+	return trackID, spotifyTrackURIPrefix + trackID, nil
+}
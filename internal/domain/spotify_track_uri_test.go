@@ -0,0 +1,44 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseSpotifyTrackURI(t *testing.T) {
+	const (
+		trackID = "7aKWgpecgLEqisWcXPElDl"
+		uri     = "spotify:track:7aKWgpecgLEqisWcXPElDl"
+	)
+
+	testCases := []struct {
+		name      string
+		track     string
+		expectErr bool
+	}{
+		{name: "uri", track: uri},
+		{name: "url", track: "https://open.spotify.com/track/7aKWgpecgLEqisWcXPElDl"},
+		{name: "url with query", track: "https://open.spotify.com/track/7aKWgpecgLEqisWcXPElDl?si=abc123"},
+		{name: "whitespace", track: " " + uri + "\n"},
+		{name: "track id", track: trackID, expectErr: true},
+		{name: "album uri", track: "spotify:album:7aKWgpecgLEqisWcXPElDl", expectErr: true},
+		{name: "album url", track: "https://open.spotify.com/album/7aKWgpecgLEqisWcXPElDl", expectErr: true},
+		{name: "other host", track: "https://example.com/track/7aKWgpecgLEqisWcXPElDl", expectErr: true},
+		{name: "empty", track: "", expectErr: true},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			actualID, actualURI, err := ParseSpotifyTrackURI(tc.track)
+			if tc.expectErr {
+				assert.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, trackID, actualID)
+			assert.Equal(t, uri, actualURI)
+		})
+	}
+}
@@ -0,0 +1,251 @@
+package domain
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// TrackRepository stores the tracks songs were matched to on one streaming provider,
+// see Repository.Track.
+type TrackRepository interface {
+	// GetUnknownSongs returns all songs that haven't been searched for on the provider
+	GetUnknownSongs(ctx context.Context) ([]Song, error)
+
+	// GetTracksPlayedInRange returns the tracks for a source played within a date range. Start is inclusive and end date is exclusive.
+	// When programName is not empty only tracks played during that program are returned. Tracks are in the order
+	// they were first played.
+	GetTracksPlayedInRange(ctx context.Context, songSourceType SourceType, programName, startDate, endDate string) ([]Track, error)
+
+	// GetRandomTracks returns a random slice of tracks with of the requested size
+	GetRandomTracks(ctx context.Context, numTracks int) ([]Track, error)
+
+	// GetRetryableSongs returns the songs that weren't found whose next search attempt
+	// is at or before now
+	GetRetryableSongs(ctx context.Context, now time.Time) ([]Song, error)
+
+	// GetLowConfidenceSongs returns the songs that were matched to a track with a
+	// confidence below minConfidence
+	GetLowConfidenceSongs(ctx context.Context, minConfidence float64) ([]Song, error)
+
+	Insert(ctx context.Context, track Track) error
+
+	// GetTrackBySongID returns the track matched to a song. An empty track is returned
+	// when the song hasn't been searched for.
+	GetTrackBySongID(ctx context.Context, songID uuid.UUID) (Track, error)
+
+	// Replace replaces the track matched to the track's song. The URI of the replaced match
+	// is recorded so playlists with it can be updated, see GetReplacedURIs.
+	Replace(ctx context.Context, track Track) error
+
+	// GetReplacedURIs returns the URI that replaced a previously matched URI, keyed by the
+	// previous URI. Previous URIs that are still matched to another song are not included.
+	// The URI is empty when the song was marked never match.
+	GetReplacedURIs(ctx context.Context) (map[string]string, error)
+
+	// GetISRC returns the ISRC of a track the song was matched to on another provider, or
+	// an empty string when no other provider's match has an ISRC.
+	GetISRC(ctx context.Context, songID uuid.UUID) (string, error)
+}
+
+// Track includes the song metadata required to add a song to a playlist on a streaming
+// provider along with the details of the search result the song was matched to. A song
+// has at most one track per provider.
+type Track struct {
+	playlistType  PlaylistType
+	id            string
+	uri           string
+	songID        uuid.UUID
+	matchFound    bool
+	matchMethod   MatchMethod
+	confidence    float64
+	matchedArtist string
+	matchedTrack  string
+	matchedAlbum  string
+	isrc          string
+	matchedAt     time.Time
+	attemptCount  int
+	lastAttempt   time.Time
+	nextAttempt   time.Time
+}
+
+// NewTrack returns a track on a provider matched to a song. The confidence is a percent
+// from 0 to 100 and the matched artist, track, and album are the names of the provider's
+// track the song was matched to. The ISRC is empty when the provider doesn't return one.
+func NewTrack(
+	playlistType PlaylistType,
+	songID uuid.UUID,
+	trackID string,
+	uri string,
+	matchMethod MatchMethod,
+	confidence float64,
+	matchedArtist string,
+	matchedTrack string,
+	matchedAlbum string,
+	isrc string,
+) Track {
+	now := time.Now()
+	return Track{
+		playlistType:  playlistType,
+		songID:        songID,
+		id:            trackID,
+		uri:           uri,
+		matchFound:    true,
+		matchMethod:   matchMethod,
+		confidence:    confidence,
+		matchedArtist: matchedArtist,
+		matchedTrack:  matchedTrack,
+		matchedAlbum:  matchedAlbum,
+		isrc:          isrc,
+		matchedAt:     now,
+		attemptCount:  1,
+		lastAttempt:   now,
+	}
+}
+
+// NewNotFoundTrack returns a track for a song that wasn't found on a provider after
+// attemptCount searches. The song can be searched for again after an exponential
+// back-off until MaxSearchAttempts is reached.
+func NewNotFoundTrack(playlistType PlaylistType, songID uuid.UUID, attemptCount int) Track {
+	now := time.Now()
+	return Track{
+		playlistType: playlistType,
+		songID:       songID,
+		attemptCount: attemptCount,
+		lastAttempt:  now,
+		nextAttempt:  nextSearchAttempt(now, attemptCount),
+	}
+}
+
+// NewNeverMatchTrack returns a track for a song that a match override marked as never
+// matching a track on a provider. The song is never searched for again.
+func NewNeverMatchTrack(playlistType PlaylistType, songID uuid.UUID) Track {
+	return Track{
+		playlistType: playlistType,
+		songID:       songID,
+		matchMethod:  OverrideMatchMethod,
+		attemptCount: 1,
+		lastAttempt:  time.Now(),
+	}
+}
+
+// NewReviewTrack returns a track for a song whose best match on a provider needs review
+// after attemptCount searches. The song isn't searched for again automatically while its
+// candidates are waiting for review.
+func NewReviewTrack(playlistType PlaylistType, songID uuid.UUID, attemptCount int) Track {
+	return Track{
+		playlistType: playlistType,
+		songID:       songID,
+		attemptCount: attemptCount,
+		lastAttempt:  time.Now(),
+	}
+}
+
+func NewTrackFromDB(
+	playlistType PlaylistType,
+	id string,
+	uri string,
+	songID uuid.UUID,
+	matchFound bool,
+	matchMethod MatchMethod,
+	confidence float64,
+	matchedArtist string,
+	matchedTrack string,
+	matchedAlbum string,
+	isrc string,
+	matchedAt time.Time,
+	attemptCount int,
+	lastAttempt time.Time,
+	nextAttempt time.Time,
+) Track {
+	return Track{
+		playlistType:  playlistType,
+		id:            id,
+		uri:           uri,
+		songID:        songID,
+		matchFound:    matchFound,
+		matchMethod:   matchMethod,
+		confidence:    confidence,
+		matchedArtist: matchedArtist,
+		matchedTrack:  matchedTrack,
+		matchedAlbum:  matchedAlbum,
+		isrc:          isrc,
+		matchedAt:     matchedAt,
+		attemptCount:  attemptCount,
+		lastAttempt:   lastAttempt,
+		nextAttempt:   nextAttempt,
+	}
+}
+
+// PlaylistType returns the streaming provider the track is on.
+func (t Track) PlaylistType() PlaylistType {
+	return t.playlistType
+}
+
+// TrackID returns the provider's ID for the track.
+func (t Track) TrackID() string {
+	return t.id
+}
+
+func (t Track) URI() string {
+	return t.uri
+}
+
+func (t Track) SongID() uuid.UUID {
+	return t.songID
+}
+
+func (t Track) MatchFound() bool {
+	return t.matchFound
+}
+
+// MatchMethod returns how the song was matched to the track.
+func (t Track) MatchMethod() MatchMethod {
+	return t.matchMethod
+}
+
+// Confidence returns the percent from 0 to 100 of how closely the track matched the song.
+func (t Track) Confidence() float64 {
+	return t.confidence
+}
+
+func (t Track) MatchedArtist() string {
+	return t.matchedArtist
+}
+
+func (t Track) MatchedTrack() string {
+	return t.matchedTrack
+}
+
+func (t Track) MatchedAlbum() string {
+	return t.matchedAlbum
+}
+
+// ISRC returns the recording's International Standard Recording Code, which identifies
+// the same recording on every provider.
+func (t Track) ISRC() string {
+	return t.isrc
+}
+
+// MatchedAt returns when the song was matched to the track. It is the zero time when
+// no track was found or for tracks matched before it was recorded.
+func (t Track) MatchedAt() time.Time {
+	return t.matchedAt
+}
+
+// AttemptCount returns the number of times the song has been searched for.
+func (t Track) AttemptCount() int {
+	return t.attemptCount
+}
+
+// LastAttempt returns when the song was last searched for.
+func (t Track) LastAttempt() time.Time {
+	return t.lastAttempt
+}
+
+// NextAttempt returns when a song that wasn't found can be searched for again. It
+// is the zero time when the song shouldn't be searched for again.
+func (t Track) NextAttempt() time.Time {
+	return t.nextAttempt
+}
@@ -0,0 +1,184 @@
+package applemusicclient
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/jbenzshawel/playlist-generator/internal/app/commands/playlists/applemusic/models"
+	"github.com/jbenzshawel/playlist-generator/internal/infrastructure/clients/httpclient"
+	"github.com/jbenzshawel/playlist-generator/internal/infrastructure/clients/httpclient/decode"
+)
+
+const (
+	defaultStorefront = "us"
+
+	// searchLimit is the max number of songs returned by a catalog search
+	searchLimit = 25
+)
+
+type Config struct {
+	BaseURL *url.URL
+	// Storefront is the catalog country code searched for songs. It defaults to us.
+	Storefront string
+	// TeamID, KeyID, and PrivateKey sign the developer token. The key ID and private key
+	// are from the MusicKit key created in the Apple developer account.
+	TeamID     string
+	KeyID      string
+	PrivateKey *ecdsa.PrivateKey
+	// MusicUserToken authorizes requests to the user's library, it's created when the user
+	// signs in with MusicKit.
+	MusicUserToken string
+}
+
+type Client struct {
+	httpclient.Client
+	storefront string
+}
+
+func New(cfg Config) *Client {
+	storefront := cfg.Storefront
+	if storefront == "" {
+		storefront = defaultStorefront
+	}
+
+	return &Client{
+		Client: httpclient.NewRetryingClient(httpclient.Config{
+			BaseURL: cfg.BaseURL,
+			Client: &http.Client{
+				Timeout: 10 * time.Second,
+				Transport: &authTransport{
+					base:           http.DefaultTransport,
+					developerToken: newDeveloperToken(cfg.TeamID, cfg.KeyID, cfg.PrivateKey),
+					musicUserToken: cfg.MusicUserToken,
+				},
+			},
+		}),
+		storefront: storefront,
+	}
+}
+
+// SearchSongs searches the catalog for songs matching a term.
+func (c *Client) SearchSongs(ctx context.Context, term string) (models.SearchResponse, error) {
+	resp, err := c.Get(ctx, fmt.Sprintf("/catalog/%s/search", c.storefront), httpclient.WithQuery(map[string]string{
+		"term":  term,
+		"types": models.SongResourceType,
+		"limit": strconv.Itoa(searchLimit),
+	}))
+	if err != nil {
+		return models.SearchResponse{}, err
+	}
+
+	defer resp.Body.Close()
+
+	result, err := decode.JSON[models.SearchResponse](resp)
+	if err != nil {
+		return models.SearchResponse{}, err
+	}
+
+	return result, nil
+}
+
+// GetAlbumsByUPC returns the catalog albums with a UPC including their tracks.
+func (c *Client) GetAlbumsByUPC(ctx context.Context, upc string) (models.AlbumResponse, error) {
+	resp, err := c.Get(ctx, fmt.Sprintf("/catalog/%s/albums", c.storefront), httpclient.WithQuery(map[string]string{
+		"filter[upc]": upc,
+		"include":     "tracks",
+	}))
+	if err != nil {
+		return models.AlbumResponse{}, err
+	}
+
+	defer resp.Body.Close()
+
+	albums, err := decode.JSON[models.AlbumResponse](resp)
+	if err != nil {
+		return models.AlbumResponse{}, err
+	}
+
+	return albums, nil
+}
+
+func (c *Client) CreateLibraryPlaylist(ctx context.Context, request models.CreateLibraryPlaylistRequest) (models.LibraryPlaylist, error) {
+	resp, err := c.Post(ctx, "/me/library/playlists", httpclient.WithJSONBody(request))
+	if err != nil {
+		return models.LibraryPlaylist{}, err
+	}
+
+	defer resp.Body.Close()
+
+	playlists, err := decode.JSON[models.LibraryPlaylistResponse](resp)
+	if err != nil {
+		return models.LibraryPlaylist{}, err
+	}
+
+	if len(playlists.Data) == 0 {
+		return models.LibraryPlaylist{}, errors.New("created playlist missing from response")
+	}
+
+	return playlists.Data[0], nil
+}
+
+// GetLibraryPlaylistTracks returns a page of the songs in a library playlist. An empty page
+// is returned for a playlist without any songs.
+func (c *Client) GetLibraryPlaylistTracks(ctx context.Context, playlistID string, limit, offset int) (models.LibrarySongPage, error) {
+	resp, err := c.Get(ctx, fmt.Sprintf("/me/library/playlists/%s/tracks", playlistID), httpclient.WithQuery(map[string]string{
+		"limit":  strconv.Itoa(limit),
+		"offset": strconv.Itoa(offset),
+	}))
+	if err != nil {
+		return models.LibrarySongPage{}, err
+	}
+
+	defer resp.Body.Close()
+
+	// the tracks of an empty playlist aren't found
+	if resp.StatusCode == http.StatusNotFound {
+		return models.LibrarySongPage{}, nil
+	}
+
+	page, err := decode.JSON[models.LibrarySongPage](resp)
+	if err != nil {
+		return models.LibrarySongPage{}, err
+	}
+
+	return page, nil
+}
+
+func (c *Client) AddTracksToLibraryPlaylist(ctx context.Context, playlistID string, request models.AddTracksToLibraryPlaylistRequest) error {
+	resp, err := c.Post(ctx, fmt.Sprintf("/me/library/playlists/%s/tracks", playlistID), httpclient.WithJSONBody(request))
+	if err != nil {
+		return err
+	}
+
+	defer resp.Body.Close()
+
+	return decode.NoContent(resp)
+}
+
+// authTransport adds the developer token and music user token to every request.
+type authTransport struct {
+	base           http.RoundTripper
+	developerToken *developerToken
+	musicUserToken string
+}
+
+func (t *authTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	token, err := t.developerToken.Token()
+	if err != nil {
+		return nil, err
+	}
+
+	req = req.Clone(req.Context())
+	req.Header.Set("Authorization", "Bearer "+token)
+	if t.musicUserToken != "" {
+		req.Header.Set("Music-User-Token", t.musicUserToken)
+	}
+
+	return t.base.RoundTrip(req)
+}
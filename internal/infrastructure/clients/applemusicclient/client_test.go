@@ -0,0 +1,183 @@
+package applemusicclient
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/jbenzshawel/playlist-generator/internal/app/commands/playlists/applemusic/models"
+)
+
+const testMusicUserToken = "music-user-token"
+
+func TestClient_SearchSongs(t *testing.T) {
+	c, mux := newTestClient(t)
+
+	mux.HandleFunc("GET /v1/catalog/ca/search", func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "Cake Never There", r.URL.Query().Get("term"))
+		assert.Equal(t, "songs", r.URL.Query().Get("types"))
+
+		_, _ = io.WriteString(w, `{"results": {"songs": {"data": [{
+			"id": "1440880779",
+			"type": "songs",
+			"attributes": {
+				"name": "Never There",
+				"artistName": "CAKE",
+				"albumName": "Prolonging the Magic",
+				"isrc": "USMC19838577",
+				"url": "https://music.apple.com/ca/album/never-there/1440880460?i=1440880779"
+			}
+		}]}}}`)
+	})
+
+	resp, err := c.SearchSongs(t.Context(), "Cake Never There")
+	require.NoError(t, err)
+	require.Len(t, resp.Results.Songs.Data, 1)
+
+	song := resp.Results.Songs.Data[0]
+	assert.Equal(t, "1440880779", song.ID)
+	assert.Equal(t, "Never There", song.Attributes.Name)
+	assert.Equal(t, "CAKE", song.Attributes.ArtistName)
+	assert.Equal(t, "Prolonging the Magic", song.Attributes.AlbumName)
+}
+
+func TestClient_GetAlbumsByUPC(t *testing.T) {
+	c, mux := newTestClient(t)
+
+	mux.HandleFunc("GET /v1/catalog/ca/albums", func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "008811199728", r.URL.Query().Get("filter[upc]"))
+		assert.Equal(t, "tracks", r.URL.Query().Get("include"))
+
+		_, _ = io.WriteString(w, `{"data": [{
+			"id": "1440880460",
+			"attributes": {"name": "Prolonging the Magic", "artistName": "CAKE", "upc": "008811199728"},
+			"relationships": {"tracks": {"data": [
+				{"id": "1440880779", "type": "songs", "attributes": {"name": "Never There"}},
+				{"id": "1440880800", "type": "music-videos", "attributes": {"name": "Never There"}}
+			]}}
+		}]}`)
+	})
+
+	resp, err := c.GetAlbumsByUPC(t.Context(), "008811199728")
+	require.NoError(t, err)
+	require.Len(t, resp.Data, 1)
+	require.Len(t, resp.Data[0].Relationships.Tracks.Data, 2)
+	assert.Equal(t, models.SongResourceType, resp.Data[0].Relationships.Tracks.Data[0].Type)
+}
+
+func TestClient_CreateLibraryPlaylist(t *testing.T) {
+	c, mux := newTestClient(t)
+
+	mux.HandleFunc("POST /v1/me/library/playlists", func(w http.ResponseWriter, r *http.Request) {
+		var request models.CreateLibraryPlaylistRequest
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&request))
+		assert.Equal(t, "Studio One 2025-10", request.Attributes.Name)
+
+		w.WriteHeader(http.StatusCreated)
+		_, _ = io.WriteString(w, `{"data": [{
+			"id": "p.eoGxPJ3IYyRNJ",
+			"type": "library-playlists",
+			"href": "/v1/me/library/playlists/p.eoGxPJ3IYyRNJ",
+			"attributes": {"name": "Studio One 2025-10"}
+		}]}`)
+	})
+
+	playlist, err := c.CreateLibraryPlaylist(t.Context(), models.CreateLibraryPlaylistRequest{
+		Attributes: models.CreateLibraryPlaylistAttributes{Name: "Studio One 2025-10"},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "p.eoGxPJ3IYyRNJ", playlist.ID)
+	assert.Equal(t, "/v1/me/library/playlists/p.eoGxPJ3IYyRNJ", playlist.Endpoint)
+}
+
+func TestClient_GetLibraryPlaylistTracks(t *testing.T) {
+	c, mux := newTestClient(t)
+
+	mux.HandleFunc("GET /v1/me/library/playlists/p.full/tracks", func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "100", r.URL.Query().Get("limit"))
+		assert.Equal(t, "0", r.URL.Query().Get("offset"))
+
+		_, _ = io.WriteString(w, `{"data": [{
+			"id": "i.DVQ2xL4Fa4Z3",
+			"type": "library-songs",
+			"attributes": {"name": "Never There", "playParams": {"id": "i.DVQ2xL4Fa4Z3", "kind": "song", "catalogId": "1440880779"}}
+		}], "meta": {"total": 1}}`)
+	})
+	mux.HandleFunc("GET /v1/me/library/playlists/p.empty/tracks", func(w http.ResponseWriter, r *http.Request) {
+		http.NotFound(w, r)
+	})
+
+	t.Run("playlist with tracks", func(t *testing.T) {
+		page, err := c.GetLibraryPlaylistTracks(t.Context(), "p.full", 100, 0)
+		require.NoError(t, err)
+		assert.Equal(t, 1, page.Meta.Total)
+		require.Len(t, page.Data, 1)
+		assert.Equal(t, "1440880779", page.Data[0].Attributes.PlayParams.CatalogID)
+	})
+
+	t.Run("empty playlist", func(t *testing.T) {
+		page, err := c.GetLibraryPlaylistTracks(t.Context(), "p.empty", 100, 0)
+		require.NoError(t, err)
+		assert.Empty(t, page.Data)
+	})
+}
+
+func TestClient_AddTracksToLibraryPlaylist(t *testing.T) {
+	c, mux := newTestClient(t)
+
+	mux.HandleFunc("POST /v1/me/library/playlists/p.eoGxPJ3IYyRNJ/tracks", func(w http.ResponseWriter, r *http.Request) {
+		var request models.AddTracksToLibraryPlaylistRequest
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&request))
+		assert.Equal(t, []models.TrackReference{{ID: "1440880779", Type: "songs"}}, request.Data)
+
+		w.WriteHeader(http.StatusNoContent)
+	})
+	mux.HandleFunc("POST /v1/me/library/playlists/p.missing/tracks", func(w http.ResponseWriter, r *http.Request) {
+		http.NotFound(w, r)
+	})
+
+	request := models.AddTracksToLibraryPlaylistRequest{
+		Data: []models.TrackReference{{ID: "1440880779", Type: "songs"}},
+	}
+
+	require.NoError(t, c.AddTracksToLibraryPlaylist(t.Context(), "p.eoGxPJ3IYyRNJ", request))
+	assert.Error(t, c.AddTracksToLibraryPlaylist(t.Context(), "p.missing", request))
+}
+
+// newTestClient returns a client for an Apple Music stand-in that checks every request is
+// authenticated with a developer token and music user token.
+func newTestClient(t *testing.T) (*Client, *http.ServeMux) {
+	t.Helper()
+
+	key := newTestKey(t)
+	mux := http.NewServeMux()
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		token, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+		assert.True(t, ok, "developer token missing")
+		verifyTestToken(t, &key.PublicKey, token)
+		assert.Equal(t, testMusicUserToken, r.Header.Get("Music-User-Token"))
+
+		mux.ServeHTTP(w, r)
+	}))
+	t.Cleanup(ts.Close)
+
+	baseURL, err := url.Parse(ts.URL + "/v1")
+	require.NoError(t, err)
+
+	return New(Config{
+		BaseURL:        baseURL,
+		Storefront:     "ca",
+		TeamID:         "TEAM123456",
+		KeyID:          "KEY1234567",
+		PrivateKey:     key,
+		MusicUserToken: testMusicUserToken,
+	}), mux
+}
@@ -0,0 +1,126 @@
+package applemusicclient
+
+import (
+	"crypto/ecdsa"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+)
+
+const (
+	// tokenTTL is how long a developer token is valid, Apple rejects tokens valid for
+	// more than six months
+	tokenTTL = 24 * time.Hour
+
+	// tokenRefreshWindow is how long before a token expires it is signed again
+	tokenRefreshWindow = time.Minute
+)
+
+// ParsePrivateKey parses the PEM encoded MusicKit private key, the .p8 file downloaded
+// from the Apple developer account.
+func ParsePrivateKey(pemBytes []byte) (*ecdsa.PrivateKey, error) {
+	block, _ := pem.Decode(pemBytes)
+	if block == nil {
+		return nil, errors.New("private key is not PEM encoded")
+	}
+
+	key, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("invalid private key: %w", err)
+	}
+
+	ecKey, ok := key.(*ecdsa.PrivateKey)
+	if !ok {
+		return nil, errors.New("private key is not an ECDSA key")
+	}
+
+	return ecKey, nil
+}
+
+// developerToken signs the ES256 JSON web tokens that authenticate requests to the Apple
+// Music API. A signed token is reused until it is about to expire.
+type developerToken struct {
+	teamID string
+	keyID  string
+	key    *ecdsa.PrivateKey
+	now    func() time.Time
+
+	mu     sync.Mutex
+	token  string
+	expiry time.Time
+}
+
+func newDeveloperToken(teamID, keyID string, key *ecdsa.PrivateKey) *developerToken {
+	return &developerToken{
+		teamID: teamID,
+		keyID:  keyID,
+		key:    key,
+		now:    time.Now,
+	}
+}
+
+// Token returns a developer token that is valid for at least another minute.
+func (d *developerToken) Token() (string, error) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	now := d.now()
+	if d.token != "" && now.Add(tokenRefreshWindow).Before(d.expiry) {
+		return d.token, nil
+	}
+
+	expiry := now.Add(tokenTTL)
+	token, err := d.sign(now, expiry)
+	if err != nil {
+		return "", err
+	}
+
+	d.token, d.expiry = token, expiry
+
+	return token, nil
+}
+
+func (d *developerToken) sign(issued, expiry time.Time) (string, error) {
+	if d.key == nil {
+		return "", errors.New("developer token private key not set")
+	}
+
+	header, err := json.Marshal(map[string]string{
+		"alg": "ES256",
+		"kid": d.keyID,
+	})
+	if err != nil {
+		return "", err
+	}
+
+	claims, err := json.Marshal(map[string]any{
+		"iss": d.teamID,
+		"iat": issued.Unix(),
+		"exp": expiry.Unix(),
+	})
+	if err != nil {
+		return "", err
+	}
+
+	signingInput := base64.RawURLEncoding.EncodeToString(header) + "." + base64.RawURLEncoding.EncodeToString(claims)
+	digest := sha256.Sum256([]byte(signingInput))
+
+	r, s, err := ecdsa.Sign(rand.Reader, d.key, digest[:])
+	if err != nil {
+		return "", fmt.Errorf("failed to sign developer token: %w", err)
+	}
+
+	// ES256 signatures are the 32 byte big-endian R and S values concatenated
+	signature := make([]byte, 64)
+	r.FillBytes(signature[:32])
+	s.FillBytes(signature[32:])
+
+	return signingInput + "." + base64.RawURLEncoding.EncodeToString(signature), nil
+}
@@ -0,0 +1,116 @@
+package applemusicclient
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"math/big"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParsePrivateKey(t *testing.T) {
+	key := newTestKey(t)
+
+	der, err := x509.MarshalPKCS8PrivateKey(key)
+	require.NoError(t, err)
+
+	t.Run("pkcs8 key", func(t *testing.T) {
+		actual, err := ParsePrivateKey(pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der}))
+		require.NoError(t, err)
+		assert.True(t, key.Equal(actual))
+	})
+
+	t.Run("not pem encoded", func(t *testing.T) {
+		_, err := ParsePrivateKey(der)
+		assert.Error(t, err)
+	})
+}
+
+func TestDeveloperToken_Token(t *testing.T) {
+	key := newTestKey(t)
+	now := time.Unix(1_760_000_000, 0)
+
+	d := newDeveloperToken("TEAM123456", "KEY1234567", key)
+	d.now = func() time.Time { return now }
+
+	token, err := d.Token()
+	require.NoError(t, err)
+
+	header, claims := verifyTestToken(t, &key.PublicKey, token)
+	assert.Equal(t, map[string]any{"alg": "ES256", "kid": "KEY1234567"}, header)
+	assert.Equal(t, map[string]any{
+		"iss": "TEAM123456",
+		"iat": float64(now.Unix()),
+		"exp": float64(now.Add(tokenTTL).Unix()),
+	}, claims)
+
+	t.Run("token reused until it is about to expire", func(t *testing.T) {
+		now = now.Add(tokenTTL - 2*tokenRefreshWindow)
+
+		actual, err := d.Token()
+		require.NoError(t, err)
+		assert.Equal(t, token, actual)
+	})
+
+	t.Run("token signed again before it expires", func(t *testing.T) {
+		now = now.Add(tokenRefreshWindow)
+
+		actual, err := d.Token()
+		require.NoError(t, err)
+		assert.NotEqual(t, token, actual)
+
+		_, claims := verifyTestToken(t, &key.PublicKey, actual)
+		assert.Equal(t, float64(now.Unix()), claims["iat"])
+	})
+
+	t.Run("missing private key", func(t *testing.T) {
+		_, err := newDeveloperToken("TEAM123456", "KEY1234567", nil).Token()
+		assert.Error(t, err)
+	})
+}
+
+func newTestKey(t *testing.T) *ecdsa.PrivateKey {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	return key
+}
+
+// verifyTestToken verifies the signature of a developer token and returns its header and claims
+func verifyTestToken(t *testing.T, key *ecdsa.PublicKey, token string) (map[string]any, map[string]any) {
+	t.Helper()
+
+	parts := strings.Split(token, ".")
+	require.Len(t, parts, 3)
+
+	signature, err := base64.RawURLEncoding.DecodeString(parts[2])
+	require.NoError(t, err)
+	require.Len(t, signature, 64)
+
+	digest := sha256.Sum256([]byte(parts[0] + "." + parts[1]))
+	r, s := new(big.Int).SetBytes(signature[:32]), new(big.Int).SetBytes(signature[32:])
+	require.True(t, ecdsa.Verify(key, digest[:], r, s), "invalid token signature")
+
+	decode := func(part string) map[string]any {
+		b, err := base64.RawURLEncoding.DecodeString(part)
+		require.NoError(t, err)
+
+		var m map[string]any
+		require.NoError(t, json.Unmarshal(b, &m))
+		return m
+	}
+
+	return decode(parts[0]), decode(parts[1])
+}
@@ -25,6 +25,7 @@ const (
 type Client interface {
 	Get(ctx context.Context, endpoint string, options ...RequestOption) (*http.Response, error)
 	Post(ctx context.Context, endpoint string, options ...RequestOption) (*http.Response, error)
+	Put(ctx context.Context, endpoint string, options ...RequestOption) (*http.Response, error)
 	Delete(ctx context.Context, endpoint string, options ...RequestOption) (*http.Response, error)
 	Do(req *http.Request) (*http.Response, error)
 }
@@ -79,6 +80,7 @@ func NewRetryingClient(cfg Config) *retryingClient {
 
 type RequestConfig struct {
 	queryParams map[string]string
+	queryValues url.Values
 	jsonBody    any
 }
 
@@ -90,6 +92,14 @@ func WithQuery(params map[string]string) RequestOption {
 	}
 }
 
+// WithQueryValues adds query parameters that can be repeated, such as a list of IDs
+// sent as one parameter per ID.
+func WithQueryValues(values url.Values) RequestOption {
+	return func(cfg *RequestConfig) {
+		cfg.queryValues = values
+	}
+}
+
 func WithJSONBody(b any) RequestOption {
 	return func(cfg *RequestConfig) {
 		cfg.jsonBody = b
@@ -115,6 +125,12 @@ func (c *retryingClient) Get(ctx context.Context, endpoint string, options ...Re
 		q.Add(k, v)
 	}
 
+	for k, values := range cfg.queryValues {
+		for _, v := range values {
+			q.Add(k, v)
+		}
+	}
+
 	req.URL.RawQuery = q.Encode()
 
 	resp, err := c.Do(req)
@@ -153,6 +169,34 @@ func (c *retryingClient) Post(ctx context.Context, endpoint string, options ...R
 	return resp, nil
 }
 
+func (c *retryingClient) Put(ctx context.Context, endpoint string, options ...RequestOption) (*http.Response, error) {
+	requestURL := c.baseURL.JoinPath(endpoint).String()
+
+	cfg := &RequestConfig{}
+	for _, opt := range options {
+		opt(cfg)
+	}
+
+	bodyJSON, err := json.Marshal(cfg.jsonBody)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPut, requestURL, bytes.NewBuffer(bodyJSON))
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := c.Do(req)
+	if err != nil {
+		return nil, err
+	}
+
+	return resp, nil
+}
+
 func (c *retryingClient) Delete(ctx context.Context, endpoint string, options ...RequestOption) (*http.Response, error) {
 	requestURL := c.baseURL.JoinPath(endpoint).String()
 
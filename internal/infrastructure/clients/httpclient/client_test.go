@@ -4,6 +4,7 @@ import (
 	"context"
 	"net/http"
 	"net/http/httptest"
+	"net/url"
 	"strconv"
 	"sync"
 	"sync/atomic"
@@ -204,3 +205,28 @@ func sleep(ctx context.Context, duration time.Duration) {
 	case <-time.After(duration):
 	}
 }
+
+func TestRetryingClient_Get_QueryValues(t *testing.T) {
+	t.Parallel()
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "p1", r.URL.Query().Get("playlistId"))
+		assert.Equal(t, []string{"s1", "s2"}, r.URL.Query()["songIdToAdd"])
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer ts.Close()
+
+	baseURL, err := url.Parse(ts.URL)
+	require.NoError(t, err)
+
+	rc := NewRetryingClient(Config{BaseURL: baseURL})
+
+	resp, err := rc.Get(t.Context(), "/rest/updatePlaylist",
+		WithQuery(map[string]string{"playlistId": "p1"}),
+		WithQueryValues(url.Values{"songIdToAdd": {"s1", "s2"}}),
+	)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+}
@@ -20,14 +20,28 @@ func JSON[T any](resp *http.Response) (T, error) {
 		return result, nil
 	}
 
+	return zero, statusError(resp)
+}
+
+// NoContent returns an error when a response without a body, such as a 204 No Content
+// response, doesn't have a success status.
+func NoContent(resp *http.Response) error {
+	if resp.StatusCode >= 200 && resp.StatusCode < 300 {
+		return nil
+	}
+
+	return statusError(resp)
+}
+
+func statusError(resp *http.Response) error {
 	if resp.StatusCode >= 400 {
 		bodyBytes, err := io.ReadAll(resp.Body)
 		if err != nil {
-			return zero, fmt.Errorf("error reading response body: %w", err)
+			return fmt.Errorf("error reading response body: %w", err)
 		}
 
 		slog.Warn("http request failed with error", "error", string(bodyBytes), "status", resp.StatusCode)
 	}
 
-	return zero, fmt.Errorf("http request failed with %d status", resp.StatusCode)
+	return fmt.Errorf("http request failed with %d status", resp.StatusCode)
 }
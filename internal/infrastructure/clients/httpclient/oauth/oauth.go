@@ -6,12 +6,31 @@ import (
 	"encoding/base64"
 	"errors"
 	"fmt"
+	"log/slog"
 	"net/http"
+	"net/url"
+	"strings"
+	"sync"
 	"time"
 
 	"golang.org/x/oauth2"
 )
 
+const clientTimeout = 10 * time.Second
+
+// ErrNoToken is returned when no token has been stored yet
+var ErrNoToken = errors.New("no stored token")
+
+// ErrTokenRejected is returned when the token endpoint rejects the stored refresh token
+var ErrTokenRejected = errors.New("stored token rejected")
+
+// TokenStore persists a token between runs so a user only logs in once.
+type TokenStore interface {
+	// Token returns the stored token, or nil when no token is stored
+	Token(ctx context.Context) (*oauth2.Token, error)
+	SaveToken(ctx context.Context, token *oauth2.Token) error
+}
+
 type AuthenticatorConfig struct {
 	ClientID     string
 	ClientSecret string
@@ -19,10 +38,18 @@ type AuthenticatorConfig struct {
 	TokenURL     string
 	RedirectURL  string
 	Scopes       []string
+	// PKCE adds a proof key to the login, which providers such as Tidal require from
+	// clients that can't keep the client secret private.
+	PKCE bool
+	// TokenStore saves the token from a login and every refreshed token. Tokens
+	// aren't persisted when it is nil.
+	TokenStore TokenStore
 }
 
 func NewAuthenticator(cfg AuthenticatorConfig) *authenticator {
 	return &authenticator{
+		store: cfg.TokenStore,
+		pkce:  cfg.PKCE,
 		oauthCfg: &oauth2.Config{
 			ClientID:     cfg.ClientID,
 			ClientSecret: cfg.ClientSecret,
@@ -42,17 +69,74 @@ func (a *authenticator) AuthCodeURL() (string, error) {
 	if err != nil {
 		return "", err
 	}
-	return a.oauthCfg.AuthCodeURL(a.state), nil
+
+	if !a.pkce {
+		return a.oauthCfg.AuthCodeURL(a.state), nil
+	}
+
+	a.verifier = oauth2.GenerateVerifier()
+	return a.oauthCfg.AuthCodeURL(a.state, oauth2.S256ChallengeOption(a.verifier)), nil
 }
 
 type authenticator struct {
 	oauthCfg *oauth2.Config
+	store    TokenStore
+	pkce     bool
 	state    string
+	// verifier is the PKCE code verifier of the login URL, empty when PKCE isn't used
+	verifier string
+}
+
+// StoredClient returns a client authenticated with the stored token. An expired token
+// is refreshed. ErrNoToken is returned when there is no stored token and ErrTokenRejected
+// when the refresh token is rejected, so the caller can fall back to an interactive login.
+// Any other error, such as the token endpoint being unreachable, is returned as is.
+func (a *authenticator) StoredClient(ctx context.Context) (*http.Client, error) {
+	if a.store == nil {
+		return nil, ErrNoToken
+	}
+
+	tok, err := a.store.Token(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load stored token: %w", err)
+	}
+	if tok == nil {
+		return nil, ErrNoToken
+	}
+
+	ts := a.tokenSource(ctx, tok)
+
+	// refreshes an expired token so a rejected refresh token is found before the
+	// client is used
+	_, err = ts.Token()
+	if tokenRejected(err) {
+		return nil, fmt.Errorf("%w: %w", ErrTokenRejected, err)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("stored token refresh failed: %w", err)
+	}
+
+	return newClient(ctx, ts), nil
+}
+
+// tokenRejected reports whether the token endpoint rejected a refresh token, rather than
+// the refresh failing because the endpoint couldn't be reached or had an error.
+func tokenRejected(err error) bool {
+	var retrieveErr *oauth2.RetrieveError
+	if !errors.As(err, &retrieveErr) {
+		return false
+	}
+	if retrieveErr.ErrorCode == "invalid_grant" {
+		return true
+	}
+
+	return retrieveErr.Response != nil &&
+		(retrieveErr.Response.StatusCode == http.StatusBadRequest || retrieveErr.Response.StatusCode == http.StatusUnauthorized)
 }
 
 func (a *authenticator) GetAuthCodeCallbackHandler(ctx context.Context, chOAuthClient chan *http.Client) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
-		tok, err := a.token(ctx, a.state, r)
+		tok, err := a.token(ctx, a.state, r.URL.Query())
 		if err != nil {
 			http.Error(w, "Couldn't get token", http.StatusForbidden)
 			return
@@ -62,27 +146,128 @@ func (a *authenticator) GetAuthCodeCallbackHandler(ctx context.Context, chOAuthC
 			return
 		}
 
-		client := a.oauthCfg.Client(ctx, tok)
+		err = a.saveToken(ctx, tok)
+		if err != nil {
+			// the token still works for this run
+			slog.Warn("failed to save token", slog.Any("error", err))
+		}
+
+		chOAuthClient <- newClient(ctx, a.tokenSource(ctx, tok))
+	}
+}
+
+// Exchange completes a login without the callback endpoint. The redirect is either
+// the URL the browser was redirected to after approving access, or just the code
+// query parameter from it.
+func (a *authenticator) Exchange(ctx context.Context, redirect string) (*http.Client, error) {
+	redirect = strings.TrimSpace(redirect)
+	if redirect == "" {
+		return nil, errors.New("redirect URL or code empty")
+	}
+
+	// a code is pasted without the state, so only a redirect URL's state is checked
+	values := url.Values{"code": {redirect}, "state": {a.state}}
+	if strings.Contains(redirect, "?") {
+		redirectURL, err := url.Parse(redirect)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse redirect URL: %w", err)
+		}
+		values = redirectURL.Query()
+	}
+
+	tok, err := a.token(ctx, a.state, values)
+	if err != nil {
+		return nil, err
+	}
 
-		client.Timeout = 10 * time.Second
-		chOAuthClient <- client
+	err = a.saveToken(ctx, tok)
+	if err != nil {
+		return nil, fmt.Errorf("failed to save token: %w", err)
 	}
+
+	return newClient(ctx, a.tokenSource(ctx, tok)), nil
 }
 
-func (a *authenticator) token(ctx context.Context, state string, r *http.Request) (*oauth2.Token, error) {
-	values := r.URL.Query()
+func (a *authenticator) saveToken(ctx context.Context, tok *oauth2.Token) error {
+	if a.store == nil {
+		return nil
+	}
+	return a.store.SaveToken(ctx, tok)
+}
+
+func (a *authenticator) token(ctx context.Context, state string, values url.Values) (*oauth2.Token, error) {
 	if e := values.Get("error"); e != "" {
-		return nil, fmt.Errorf("spotify auth failed: %v", e)
+		return nil, fmt.Errorf("auth failed: %v", e)
 	}
 	code := values.Get("code")
 	if code == "" {
-		return nil, errors.New("spotify access code empty")
+		return nil, errors.New("access code empty")
 	}
 	actualState := values.Get("state")
 	if actualState != state {
-		return nil, errors.New("spotify redirect state mismatch")
+		return nil, errors.New("redirect state mismatch")
+	}
+
+	var opts []oauth2.AuthCodeOption
+	if a.verifier != "" {
+		opts = append(opts, oauth2.VerifierOption(a.verifier))
+	}
+	return a.oauthCfg.Exchange(ctx, code, opts...)
+}
+
+// tokenSource returns a token source that refreshes tok when it expires and saves
+// every refreshed token to the token store.
+func (a *authenticator) tokenSource(ctx context.Context, tok *oauth2.Token) oauth2.TokenSource {
+	ts := a.oauthCfg.TokenSource(ctx, tok)
+	if a.store == nil {
+		return ts
+	}
+
+	return &persistingTokenSource{
+		ctx:         ctx,
+		base:        ts,
+		store:       a.store,
+		accessToken: tok.AccessToken,
 	}
-	return a.oauthCfg.Exchange(ctx, code)
+}
+
+func newClient(ctx context.Context, ts oauth2.TokenSource) *http.Client {
+	client := oauth2.NewClient(ctx, ts)
+	client.Timeout = clientTimeout
+
+	return client
+}
+
+// persistingTokenSource saves a token to the token store whenever it is refreshed.
+type persistingTokenSource struct {
+	ctx   context.Context
+	base  oauth2.TokenSource
+	store TokenStore
+
+	mu          sync.Mutex
+	accessToken string
+}
+
+func (s *persistingTokenSource) Token() (*oauth2.Token, error) {
+	tok, err := s.base.Token()
+	if err != nil {
+		return nil, err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if tok.AccessToken != s.accessToken {
+		err = s.store.SaveToken(s.ctx, tok)
+		if err != nil {
+			// the refreshed token still works for this run
+			slog.Warn("failed to save refreshed token", slog.Any("error", err))
+			return tok, nil
+		}
+		s.accessToken = tok.AccessToken
+	}
+
+	return tok, nil
 }
 
 func generateState() (string, error) {
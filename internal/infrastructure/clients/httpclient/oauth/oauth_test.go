@@ -0,0 +1,313 @@
+package oauth
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/oauth2"
+)
+
+type memoryTokenStore struct {
+	token *oauth2.Token
+	saves int
+}
+
+func (m *memoryTokenStore) Token(_ context.Context) (*oauth2.Token, error) {
+	return m.token, nil
+}
+
+func (m *memoryTokenStore) SaveToken(_ context.Context, token *oauth2.Token) error {
+	m.token = token
+	m.saves++
+	return nil
+}
+
+const testAuthCode = "valid-code"
+
+// newTokenServer returns a fake token endpoint that accepts the testAuthCode code and
+// refreshToken, and rejects any other code or refresh token.
+func newTokenServer(t *testing.T, refreshToken string) *httptest.Server {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.NoError(t, r.ParseForm())
+
+		w.Header().Set("Content-Type", "application/json")
+
+		var tok map[string]any
+		switch r.PostForm.Get("grant_type") {
+		case "authorization_code":
+			if r.PostForm.Get("code") == testAuthCode {
+				tok = map[string]any{
+					"access_token":  "access",
+					"token_type":    "Bearer",
+					"refresh_token": refreshToken,
+					"expires_in":    3600,
+				}
+			}
+		case "refresh_token":
+			if r.PostForm.Get("refresh_token") == refreshToken {
+				tok = map[string]any{
+					"access_token": "refreshed",
+					"token_type":   "Bearer",
+					"expires_in":   3600,
+				}
+			}
+		}
+
+		if tok == nil {
+			w.WriteHeader(http.StatusBadRequest)
+			_ = json.NewEncoder(w).Encode(map[string]string{"error": "invalid_grant"})
+			return
+		}
+
+		_ = json.NewEncoder(w).Encode(tok)
+	}))
+	t.Cleanup(ts.Close)
+
+	return ts
+}
+
+func newTestAuthenticator(tokenURL string, store TokenStore) *authenticator {
+	return NewAuthenticator(AuthenticatorConfig{
+		ClientID:     "client",
+		ClientSecret: "secret",
+		AuthURL:      tokenURL + "/authorize",
+		TokenURL:     tokenURL,
+		RedirectURL:  "http://127.0.0.1:3000/callback",
+		TokenStore:   store,
+	})
+}
+
+func TestAuthenticator_StoredClient(t *testing.T) {
+	ts := newTokenServer(t, "valid-refresh")
+
+	t.Run("no stored token", func(t *testing.T) {
+		auth := newTestAuthenticator(ts.URL, &memoryTokenStore{})
+
+		_, err := auth.StoredClient(t.Context())
+		assert.ErrorIs(t, err, ErrNoToken)
+	})
+
+	t.Run("no token store", func(t *testing.T) {
+		auth := newTestAuthenticator(ts.URL, nil)
+
+		_, err := auth.StoredClient(t.Context())
+		assert.ErrorIs(t, err, ErrNoToken)
+	})
+
+	t.Run("valid token used without refresh", func(t *testing.T) {
+		store := &memoryTokenStore{token: &oauth2.Token{
+			AccessToken:  "access",
+			TokenType:    "Bearer",
+			RefreshToken: "valid-refresh",
+			Expiry:       time.Now().Add(time.Hour),
+		}}
+		auth := newTestAuthenticator(ts.URL, store)
+
+		client, err := auth.StoredClient(t.Context())
+		require.NoError(t, err)
+		assert.NotNil(t, client)
+		assert.Equal(t, 0, store.saves)
+	})
+
+	t.Run("expired token refreshed and saved", func(t *testing.T) {
+		store := &memoryTokenStore{token: &oauth2.Token{
+			AccessToken:  "access",
+			TokenType:    "Bearer",
+			RefreshToken: "valid-refresh",
+			Expiry:       time.Now().Add(-time.Hour),
+		}}
+		auth := newTestAuthenticator(ts.URL, store)
+
+		client, err := auth.StoredClient(t.Context())
+		require.NoError(t, err)
+		assert.NotNil(t, client)
+		assert.Equal(t, 1, store.saves)
+		assert.Equal(t, "refreshed", store.token.AccessToken)
+		assert.Equal(t, "valid-refresh", store.token.RefreshToken)
+	})
+
+	t.Run("rejected refresh token", func(t *testing.T) {
+		store := &memoryTokenStore{token: &oauth2.Token{
+			AccessToken:  "access",
+			TokenType:    "Bearer",
+			RefreshToken: "revoked-refresh",
+			Expiry:       time.Now().Add(-time.Hour),
+		}}
+		auth := newTestAuthenticator(ts.URL, store)
+
+		_, err := auth.StoredClient(t.Context())
+		assert.ErrorIs(t, err, ErrTokenRejected)
+		assert.NotErrorIs(t, err, ErrNoToken)
+		assert.Equal(t, 0, store.saves)
+	})
+
+	t.Run("token endpoint error", func(t *testing.T) {
+		unavailable := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}))
+		t.Cleanup(unavailable.Close)
+
+		store := &memoryTokenStore{token: &oauth2.Token{
+			AccessToken:  "access",
+			TokenType:    "Bearer",
+			RefreshToken: "valid-refresh",
+			Expiry:       time.Now().Add(-time.Hour),
+		}}
+		auth := newTestAuthenticator(unavailable.URL, store)
+
+		_, err := auth.StoredClient(t.Context())
+		assert.Error(t, err)
+		assert.NotErrorIs(t, err, ErrTokenRejected)
+		assert.NotErrorIs(t, err, ErrNoToken)
+	})
+
+	t.Run("token endpoint unreachable", func(t *testing.T) {
+		unreachable := httptest.NewServer(http.NotFoundHandler())
+		unreachable.Close()
+
+		store := &memoryTokenStore{token: &oauth2.Token{
+			AccessToken:  "access",
+			TokenType:    "Bearer",
+			RefreshToken: "valid-refresh",
+			Expiry:       time.Now().Add(-time.Hour),
+		}}
+		auth := newTestAuthenticator(unreachable.URL, store)
+
+		_, err := auth.StoredClient(t.Context())
+		assert.Error(t, err)
+		assert.NotErrorIs(t, err, ErrTokenRejected)
+	})
+}
+
+func TestAuthenticator_Exchange(t *testing.T) {
+	ts := newTokenServer(t, "valid-refresh")
+
+	testCases := []struct {
+		name     string
+		redirect func(state string) string
+		wantErr  bool
+	}{
+		{
+			name: "redirect URL",
+			redirect: func(state string) string {
+				return "http://127.0.0.1:3000/callback?code=" + testAuthCode + "&state=" + state
+			},
+		},
+		{
+			name: "code",
+			redirect: func(string) string {
+				return "  " + testAuthCode + "\n"
+			},
+		},
+		{
+			name: "state mismatch",
+			redirect: func(string) string {
+				return "http://127.0.0.1:3000/callback?code=" + testAuthCode + "&state=other"
+			},
+			wantErr: true,
+		},
+		{
+			name: "access denied",
+			redirect: func(state string) string {
+				return "http://127.0.0.1:3000/callback?error=access_denied&state=" + state
+			},
+			wantErr: true,
+		},
+		{
+			name: "invalid code",
+			redirect: func(string) string {
+				return "invalid-code"
+			},
+			wantErr: true,
+		},
+		{
+			name: "empty",
+			redirect: func(string) string {
+				return " "
+			},
+			wantErr: true,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			store := &memoryTokenStore{}
+			auth := newTestAuthenticator(ts.URL, store)
+
+			_, err := auth.AuthCodeURL()
+			require.NoError(t, err)
+
+			client, err := auth.Exchange(t.Context(), tc.redirect(auth.state))
+			if tc.wantErr {
+				assert.Error(t, err)
+				assert.Nil(t, store.token)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.NotNil(t, client)
+			require.NotNil(t, store.token)
+			assert.Equal(t, "access", store.token.AccessToken)
+			assert.Equal(t, "valid-refresh", store.token.RefreshToken)
+		})
+	}
+}
+
+func TestAuthenticator_Exchange_PKCE(t *testing.T) {
+	var challenge string
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.NoError(t, r.ParseForm())
+
+		w.Header().Set("Content-Type", "application/json")
+
+		// the challenge is the unpadded base64 SHA-256 of the verifier
+		sum := sha256.Sum256([]byte(r.PostForm.Get("code_verifier")))
+		if r.PostForm.Get("code") != testAuthCode || base64.RawURLEncoding.EncodeToString(sum[:]) != challenge {
+			w.WriteHeader(http.StatusBadRequest)
+			_ = json.NewEncoder(w).Encode(map[string]string{"error": "invalid_grant"})
+			return
+		}
+
+		_ = json.NewEncoder(w).Encode(map[string]any{
+			"access_token":  "access",
+			"token_type":    "Bearer",
+			"refresh_token": "valid-refresh",
+			"expires_in":    3600,
+		})
+	}))
+	t.Cleanup(ts.Close)
+
+	store := &memoryTokenStore{}
+	auth := NewAuthenticator(AuthenticatorConfig{
+		ClientID:    "client",
+		AuthURL:     ts.URL + "/authorize",
+		TokenURL:    ts.URL,
+		RedirectURL: "http://127.0.0.1:3000/callback",
+		PKCE:        true,
+		TokenStore:  store,
+	})
+
+	loginURL, err := auth.AuthCodeURL()
+	require.NoError(t, err)
+
+	parsed, err := url.Parse(loginURL)
+	require.NoError(t, err)
+	assert.Equal(t, "S256", parsed.Query().Get("code_challenge_method"))
+	challenge = parsed.Query().Get("code_challenge")
+	require.NotEmpty(t, challenge)
+
+	client, err := auth.Exchange(t.Context(), testAuthCode)
+	require.NoError(t, err)
+	assert.NotNil(t, client)
+	require.NotNil(t, store.token)
+	assert.Equal(t, "access", store.token.AccessToken)
+}
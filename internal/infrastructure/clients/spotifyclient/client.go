@@ -6,6 +6,7 @@ import (
 	"net/http"
 	"net/url"
 	"strconv"
+	"strings"
 
 	"github.com/jbenzshawel/playlist-generator/internal/app/commands/playlists/spotify/models"
 	"github.com/jbenzshawel/playlist-generator/internal/infrastructure/clients/httpclient"
@@ -57,6 +58,85 @@ func (c *Client) SearchTrack(ctx context.Context, artist, track, album string) (
 	return collection, nil
 }
 
+// SearchAlbumByUPC searches for albums using the upc field filter, which Spotify only
+// supports when searching for albums.
+func (c *Client) SearchAlbumByUPC(ctx context.Context, upc string) (models.SearchAlbumResponse, error) {
+	resp, err := c.Get(ctx, "/search", httpclient.WithQuery(map[string]string{
+		"q":    "upc:" + upc,
+		"type": "album",
+	}))
+	if err != nil {
+		return models.SearchAlbumResponse{}, err
+	}
+
+	defer resp.Body.Close()
+
+	collection, err := decode.JSON[models.SearchAlbumResponse](resp)
+	if err != nil {
+		return models.SearchAlbumResponse{}, err
+	}
+
+	return collection, nil
+}
+
+// SearchTrackByISRC searches for the tracks of a recording with the isrc field filter.
+func (c *Client) SearchTrackByISRC(ctx context.Context, isrc string) (models.SearchTrackResponse, error) {
+	resp, err := c.Get(ctx, "/search", httpclient.WithQuery(map[string]string{
+		"q":    "isrc:" + isrc,
+		"type": "track",
+	}))
+	if err != nil {
+		return models.SearchTrackResponse{}, err
+	}
+
+	defer resp.Body.Close()
+
+	collection, err := decode.JSON[models.SearchTrackResponse](resp)
+	if err != nil {
+		return models.SearchTrackResponse{}, err
+	}
+
+	return collection, nil
+}
+
+// GetTracks returns the tracks with the IDs, up to 50 at a time.
+func (c *Client) GetTracks(ctx context.Context, trackIDs []string) (models.TracksResponse, error) {
+	resp, err := c.Get(ctx, "/tracks", httpclient.WithQuery(map[string]string{
+		"ids": strings.Join(trackIDs, ","),
+	}))
+	if err != nil {
+		return models.TracksResponse{}, err
+	}
+
+	defer resp.Body.Close()
+
+	tracks, err := decode.JSON[models.TracksResponse](resp)
+	if err != nil {
+		return models.TracksResponse{}, err
+	}
+
+	return tracks, nil
+}
+
+func (c *Client) GetAlbumTracks(ctx context.Context, albumID string, limit, offset int) (models.AlbumTrackPage, error) {
+	resp, err := c.Get(ctx, fmt.Sprintf("/albums/%s/tracks", albumID), httpclient.WithQuery(map[string]string{
+		"limit":  strconv.Itoa(limit),
+		"offset": strconv.Itoa(offset),
+	}))
+	if err != nil {
+		return models.AlbumTrackPage{}, err
+	}
+
+	defer resp.Body.Close()
+
+	page, err := decode.JSON[models.AlbumTrackPage](resp)
+	if err != nil {
+		return models.AlbumTrackPage{}, err
+	}
+
+	return page, nil
+}
+
 func (c *Client) CurrentUser(ctx context.Context) (models.User, error) {
 	resp, err := c.Get(ctx, "/me")
 	if err != nil {
@@ -89,6 +169,17 @@ func (c *Client) CreatePlaylist(ctx context.Context, userID string, request mode
 	return playlist, nil
 }
 
+func (c *Client) ChangePlaylistDetails(ctx context.Context, playlistID string, request models.ChangePlaylistDetailsRequest) error {
+	resp, err := c.Put(ctx, fmt.Sprintf("/playlists/%s", playlistID), httpclient.WithJSONBody(request))
+	if err != nil {
+		return err
+	}
+
+	defer resp.Body.Close()
+
+	return decode.NoContent(resp)
+}
+
 func (c *Client) GetPlaylistTracks(ctx context.Context, playlistID string, limit, offset int) (models.PlaylistTrackPage, error) {
 	resp, err := c.Get(ctx, fmt.Sprintf("/playlists/%s/tracks", playlistID), httpclient.WithQuery(map[string]string{
 		"limit":  strconv.Itoa(limit),
@@ -0,0 +1,128 @@
+package spotifyclient
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/jbenzshawel/playlist-generator/internal/app/commands/playlists/spotify/models"
+)
+
+func TestClient_SearchAlbumByUPC(t *testing.T) {
+	c, mux := newTestClient(t)
+
+	mux.HandleFunc("GET /v1/search", func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "upc:008811115621", r.URL.Query().Get("q"))
+		assert.Equal(t, "album", r.URL.Query().Get("type"))
+
+		_, _ = io.WriteString(w, `{"albums": {"total": 1, "items": [
+			{"id": "1GbbnFrgsVPqmlXSQxtSIW", "name": "Prolonging The Magic", "album_type": "album"}
+		]}}`)
+	})
+
+	resp, err := c.SearchAlbumByUPC(t.Context(), "008811115621")
+	require.NoError(t, err)
+	require.Len(t, resp.Albums.Items, 1)
+	assert.Equal(t, "1GbbnFrgsVPqmlXSQxtSIW", resp.Albums.Items[0].ID)
+	assert.Equal(t, "Prolonging The Magic", resp.Albums.Items[0].Name)
+}
+
+func TestClient_GetAlbumTracks(t *testing.T) {
+	c, mux := newTestClient(t)
+
+	mux.HandleFunc("GET /v1/albums/1GbbnFrgsVPqmlXSQxtSIW/tracks", func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "50", r.URL.Query().Get("limit"))
+		assert.Equal(t, "50", r.URL.Query().Get("offset"))
+
+		_, _ = io.WriteString(w, `{"total": 51, "items": [
+			{"id": "7aKWgpecgLEqisWcXPElDl", "name": "Never There", "uri": "spotify:track:7aKWgpecgLEqisWcXPElDl"}
+		]}`)
+	})
+
+	page, err := c.GetAlbumTracks(t.Context(), "1GbbnFrgsVPqmlXSQxtSIW", 50, 50)
+	require.NoError(t, err)
+	assert.Equal(t, 51, page.Total)
+	require.Len(t, page.Items, 1)
+	assert.Equal(t, "Never There", page.Items[0].Name)
+	assert.Equal(t, "spotify:track:7aKWgpecgLEqisWcXPElDl", page.Items[0].URI)
+}
+
+func TestClient_SearchTrackByISRC(t *testing.T) {
+	c, mux := newTestClient(t)
+
+	mux.HandleFunc("GET /v1/search", func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "isrc:USCA29800388", r.URL.Query().Get("q"))
+		assert.Equal(t, "track", r.URL.Query().Get("type"))
+
+		_, _ = io.WriteString(w, `{"tracks": {"total": 1, "items": [
+			{"id": "7aKWgpecgLEqisWcXPElDl", "name": "Never There", "external_ids": {"isrc": "USCA29800388"}}
+		]}}`)
+	})
+
+	resp, err := c.SearchTrackByISRC(t.Context(), "USCA29800388")
+	require.NoError(t, err)
+	require.Len(t, resp.Tracks.Items, 1)
+	assert.Equal(t, "7aKWgpecgLEqisWcXPElDl", resp.Tracks.Items[0].ID)
+	assert.Equal(t, "USCA29800388", resp.Tracks.Items[0].ExternalIDs.ISRC)
+}
+
+func TestClient_GetTracks(t *testing.T) {
+	c, mux := newTestClient(t)
+
+	mux.HandleFunc("GET /v1/tracks", func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "7aKWgpecgLEqisWcXPElDl,missing", r.URL.Query().Get("ids"))
+
+		_, _ = io.WriteString(w, `{"tracks": [
+			{
+				"id": "7aKWgpecgLEqisWcXPElDl",
+				"name": "Never There",
+				"uri": "spotify:track:7aKWgpecgLEqisWcXPElDl",
+				"album": {"id": "1GbbnFrgsVPqmlXSQxtSIW", "name": "Prolonging The Magic", "album_type": "album"},
+				"artists": [{"id": "7dOBabd5O4CvKrg4iriHTM", "name": "CAKE"}],
+				"external_ids": {"isrc": "USCA29800388"}
+			},
+			null
+		]}`)
+	})
+
+	resp, err := c.GetTracks(t.Context(), []string{"7aKWgpecgLEqisWcXPElDl", "missing"})
+	require.NoError(t, err)
+	require.Len(t, resp.Tracks, 2)
+	require.NotNil(t, resp.Tracks[0])
+	assert.Equal(t, "Prolonging The Magic", resp.Tracks[0].Album.Name)
+	assert.Equal(t, "CAKE", resp.Tracks[0].Artists[0].Name)
+	assert.Equal(t, "USCA29800388", resp.Tracks[0].ExternalIDs.ISRC)
+	assert.Nil(t, resp.Tracks[1])
+}
+
+func TestClient_ChangePlaylistDetails(t *testing.T) {
+	c, mux := newTestClient(t)
+
+	mux.HandleFunc("PUT /v1/playlists/playlist1", func(w http.ResponseWriter, r *http.Request) {
+		body, err := io.ReadAll(r.Body)
+		assert.NoError(t, err)
+		assert.JSONEq(t, `{"name": "Studio One Last 7 Days"}`, string(body))
+	})
+
+	err := c.ChangePlaylistDetails(t.Context(), "playlist1", models.ChangePlaylistDetailsRequest{Name: "Studio One Last 7 Days"})
+	require.NoError(t, err)
+}
+
+func newTestClient(t *testing.T) (*Client, *http.ServeMux) {
+	t.Helper()
+
+	mux := http.NewServeMux()
+
+	ts := httptest.NewServer(mux)
+	t.Cleanup(ts.Close)
+
+	baseURL, err := url.Parse(ts.URL + "/v1")
+	require.NoError(t, err)
+
+	return New(Config{BaseURL: baseURL}), mux
+}
@@ -0,0 +1,161 @@
+package subsonicclient
+
+import (
+	"context"
+	"crypto/md5"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/jbenzshawel/playlist-generator/internal/app/commands/playlists/subsonic/models"
+	"github.com/jbenzshawel/playlist-generator/internal/infrastructure/clients/httpclient"
+	"github.com/jbenzshawel/playlist-generator/internal/infrastructure/clients/httpclient/decode"
+)
+
+const (
+	// apiVersion is the Subsonic API version requested. Version 1.13.0 added token
+	// authentication and 1.14.0 returns the playlist created by createPlaylist.
+	apiVersion = "1.16.1"
+	clientName = "playlist-generator"
+
+	// searchLimit is the max number of songs returned by a search
+	searchLimit = 20
+
+	clientTimeout = 10 * time.Second
+)
+
+type Config struct {
+	// BaseURL is the server's address without the /rest path
+	BaseURL  *url.URL
+	Username string
+	Password string
+}
+
+type Client struct {
+	httpclient.Client
+}
+
+func New(cfg Config) *Client {
+	return &Client{
+		Client: httpclient.NewRetryingClient(httpclient.Config{
+			BaseURL: cfg.BaseURL,
+			Client: &http.Client{
+				Timeout: clientTimeout,
+				Transport: &authTransport{
+					base:     http.DefaultTransport,
+					username: cfg.Username,
+					password: cfg.Password,
+				},
+			},
+		}),
+	}
+}
+
+// SearchSongs searches the library for songs matching a query. Artists and albums aren't
+// returned.
+func (c *Client) SearchSongs(ctx context.Context, query string) (models.SearchResult3, error) {
+	body, err := c.get(ctx, "search3", httpclient.WithQuery(map[string]string{
+		"query":       query,
+		"songCount":   strconv.Itoa(searchLimit),
+		"artistCount": "0",
+		"albumCount":  "0",
+	}))
+	if err != nil {
+		return models.SearchResult3{}, err
+	}
+
+	return body.SearchResult3, nil
+}
+
+func (c *Client) CreatePlaylist(ctx context.Context, name string) (models.Playlist, error) {
+	body, err := c.get(ctx, "createPlaylist", httpclient.WithQuery(map[string]string{
+		"name": name,
+	}))
+	if err != nil {
+		return models.Playlist{}, err
+	}
+
+	if body.Playlist.ID == "" {
+		return models.Playlist{}, fmt.Errorf("created playlist missing from response, api version %s", body.Version)
+	}
+
+	return body.Playlist, nil
+}
+
+// GetPlaylist returns a playlist including its songs.
+func (c *Client) GetPlaylist(ctx context.Context, playlistID string) (models.Playlist, error) {
+	body, err := c.get(ctx, "getPlaylist", httpclient.WithQuery(map[string]string{
+		"id": playlistID,
+	}))
+	if err != nil {
+		return models.Playlist{}, err
+	}
+
+	return body.Playlist, nil
+}
+
+// AddPlaylistSongs appends songs to a playlist.
+func (c *Client) AddPlaylistSongs(ctx context.Context, playlistID string, songIDs []string) error {
+	_, err := c.get(ctx, "updatePlaylist",
+		httpclient.WithQuery(map[string]string{"playlistId": playlistID}),
+		httpclient.WithQueryValues(url.Values{"songIdToAdd": songIDs}),
+	)
+	return err
+}
+
+// get calls an API method and returns the response body, or an error when the status
+// of the body is failed.
+func (c *Client) get(ctx context.Context, method string, options ...httpclient.RequestOption) (models.ResponseBody, error) {
+	resp, err := c.Get(ctx, "/rest/"+method, options...)
+	if err != nil {
+		return models.ResponseBody{}, err
+	}
+
+	defer resp.Body.Close()
+
+	result, err := decode.JSON[models.Response](resp)
+	if err != nil {
+		return models.ResponseBody{}, err
+	}
+
+	body := result.SubsonicResponse
+	if body.Status != models.OKStatus {
+		if body.Error != nil {
+			return models.ResponseBody{}, fmt.Errorf("subsonic %s failed with error %d: %s", method, body.Error.Code, body.Error.Message)
+		}
+		return models.ResponseBody{}, fmt.Errorf("subsonic %s failed with status %q", method, body.Status)
+	}
+
+	return body, nil
+}
+
+// authTransport adds the authentication and format parameters to every request. The
+// password is sent as a token, the MD5 hash of the password and a random salt, so it
+// isn't sent in the clear.
+type authTransport struct {
+	base     http.RoundTripper
+	username string
+	password string
+}
+
+func (t *authTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	salt := rand.Text()
+	token := md5.Sum([]byte(t.password + salt))
+
+	req = req.Clone(req.Context())
+
+	q := req.URL.Query()
+	q.Set("u", t.username)
+	q.Set("t", hex.EncodeToString(token[:]))
+	q.Set("s", salt)
+	q.Set("v", apiVersion)
+	q.Set("c", clientName)
+	q.Set("f", "json")
+	req.URL.RawQuery = q.Encode()
+
+	return t.base.RoundTrip(req)
+}
@@ -0,0 +1,184 @@
+package subsonicclient
+
+import (
+	"crypto/md5"
+	"encoding/hex"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/jbenzshawel/playlist-generator/internal/app/commands/playlists/subsonic/models"
+)
+
+const (
+	testUsername = "admin"
+	testPassword = "sesame"
+)
+
+func TestClient_SearchSongs(t *testing.T) {
+	c, mux := newTestClient(t)
+
+	mux.HandleFunc("GET /rest/search3", func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "Cake Never There", r.URL.Query().Get("query"))
+		assert.Equal(t, "20", r.URL.Query().Get("songCount"))
+		assert.Equal(t, "0", r.URL.Query().Get("artistCount"))
+		assert.Equal(t, "0", r.URL.Query().Get("albumCount"))
+
+		_, _ = io.WriteString(w, `{"subsonic-response": {
+			"status": "ok",
+			"version": "1.16.1",
+			"searchResult3": {"song": [{
+				"id": "2b8c6f0e",
+				"title": "Never There",
+				"artist": "CAKE",
+				"album": "Prolonging The Magic",
+				"isrc": ["USMC19838577"]
+			}]}
+		}}`)
+	})
+
+	result, err := c.SearchSongs(t.Context(), "Cake Never There")
+	require.NoError(t, err)
+
+	assert.Equal(t, []models.Song{{
+		ID:     "2b8c6f0e",
+		Title:  "Never There",
+		Artist: "CAKE",
+		Album:  "Prolonging The Magic",
+		ISRC:   []string{"USMC19838577"},
+	}}, result.Song)
+}
+
+func TestClient_SearchSongs_NoResults(t *testing.T) {
+	c, mux := newTestClient(t)
+
+	mux.HandleFunc("GET /rest/search3", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = io.WriteString(w, `{"subsonic-response": {"status": "ok", "version": "1.16.1", "searchResult3": {}}}`)
+	})
+
+	result, err := c.SearchSongs(t.Context(), "Cake Never There")
+	require.NoError(t, err)
+	assert.Empty(t, result.Song)
+}
+
+func TestClient_CreatePlaylist(t *testing.T) {
+	c, mux := newTestClient(t)
+
+	mux.HandleFunc("GET /rest/createPlaylist", func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "Studio One 2025-10", r.URL.Query().Get("name"))
+
+		_, _ = io.WriteString(w, `{"subsonic-response": {
+			"status": "ok",
+			"version": "1.16.1",
+			"playlist": {"id": "pl-1", "name": "Studio One 2025-10", "songCount": 0}
+		}}`)
+	})
+
+	playlist, err := c.CreatePlaylist(t.Context(), "Studio One 2025-10")
+	require.NoError(t, err)
+	assert.Equal(t, "pl-1", playlist.ID)
+	assert.Equal(t, "Studio One 2025-10", playlist.Name)
+}
+
+func TestClient_CreatePlaylist_OldServer(t *testing.T) {
+	c, mux := newTestClient(t)
+
+	// servers before API version 1.14.0 don't return the created playlist
+	mux.HandleFunc("GET /rest/createPlaylist", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = io.WriteString(w, `{"subsonic-response": {"status": "ok", "version": "1.13.0"}}`)
+	})
+
+	_, err := c.CreatePlaylist(t.Context(), "Studio One 2025-10")
+	assert.ErrorContains(t, err, "created playlist missing from response")
+}
+
+func TestClient_GetPlaylist(t *testing.T) {
+	c, mux := newTestClient(t)
+
+	mux.HandleFunc("GET /rest/getPlaylist", func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "pl-1", r.URL.Query().Get("id"))
+
+		_, _ = io.WriteString(w, `{"subsonic-response": {
+			"status": "ok",
+			"version": "1.16.1",
+			"playlist": {"id": "pl-1", "name": "Studio One 2025-10", "songCount": 2, "entry": [
+				{"id": "2b8c6f0e", "title": "Never There"},
+				{"id": "7d1e9a3c", "title": "Sheep Go To Heaven"}
+			]}
+		}}`)
+	})
+
+	playlist, err := c.GetPlaylist(t.Context(), "pl-1")
+	require.NoError(t, err)
+	require.Len(t, playlist.Entry, 2)
+	assert.Equal(t, "2b8c6f0e", playlist.Entry[0].ID)
+	assert.Equal(t, "7d1e9a3c", playlist.Entry[1].ID)
+}
+
+func TestClient_AddPlaylistSongs(t *testing.T) {
+	c, mux := newTestClient(t)
+
+	mux.HandleFunc("GET /rest/updatePlaylist", func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "pl-1", r.URL.Query().Get("playlistId"))
+		assert.Equal(t, []string{"2b8c6f0e", "7d1e9a3c"}, r.URL.Query()["songIdToAdd"])
+
+		_, _ = io.WriteString(w, `{"subsonic-response": {"status": "ok", "version": "1.16.1"}}`)
+	})
+
+	require.NoError(t, c.AddPlaylistSongs(t.Context(), "pl-1", []string{"2b8c6f0e", "7d1e9a3c"}))
+}
+
+func TestClient_FailedStatus(t *testing.T) {
+	c, mux := newTestClient(t)
+
+	// errors are returned with a 200 status
+	mux.HandleFunc("GET /rest/getPlaylist", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = io.WriteString(w, `{"subsonic-response": {
+			"status": "failed",
+			"version": "1.16.1",
+			"error": {"code": 70, "message": "Playlist not found"}
+		}}`)
+	})
+
+	_, err := c.GetPlaylist(t.Context(), "missing")
+	assert.EqualError(t, err, "subsonic getPlaylist failed with error 70: Playlist not found")
+}
+
+// newTestClient returns a client for a Subsonic stand-in that checks every request is
+// signed with a token for the test user and asks for a JSON response.
+func newTestClient(t *testing.T) (*Client, *http.ServeMux) {
+	t.Helper()
+
+	mux := http.NewServeMux()
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query()
+
+		token := md5.Sum([]byte(testPassword + q.Get("s")))
+		assert.Equal(t, testUsername, q.Get("u"))
+		assert.NotEmpty(t, q.Get("s"))
+		assert.Equal(t, hex.EncodeToString(token[:]), q.Get("t"))
+		assert.Empty(t, q.Get("p"), "password sent in the clear")
+		assert.Equal(t, apiVersion, q.Get("v"))
+		assert.Equal(t, clientName, q.Get("c"))
+		assert.Equal(t, "json", q.Get("f"))
+
+		w.Header().Set("Content-Type", "application/json")
+		mux.ServeHTTP(w, r)
+	}))
+	t.Cleanup(ts.Close)
+
+	baseURL, err := url.Parse(ts.URL)
+	require.NoError(t, err)
+
+	return New(Config{
+		BaseURL:  baseURL,
+		Username: testUsername,
+		Password: testPassword,
+	}), mux
+}
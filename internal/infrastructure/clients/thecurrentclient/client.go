@@ -0,0 +1,47 @@
+package thecurrentclient
+
+import (
+	"context"
+	"net/url"
+
+	"github.com/jbenzshawel/playlist-generator/internal/app/commands/sources/thecurrent/models"
+	"github.com/jbenzshawel/playlist-generator/internal/infrastructure/clients/httpclient"
+	"github.com/jbenzshawel/playlist-generator/internal/infrastructure/clients/httpclient/decode"
+)
+
+type Config struct {
+	BaseURL *url.URL
+}
+
+type client struct {
+	httpclient.Client
+}
+
+func New(cfg Config) *client {
+	return &client{
+		Client: httpclient.NewRetryingClient(httpclient.Config{
+			BaseURL: cfg.BaseURL,
+		}),
+	}
+}
+
+// GetSongs returns the songs played on The Current for a date in YYYY-MM-DD.
+// The endpoint and response format haven't been checked against a captured playlog
+// response yet, testdata/playlist.json only has the fields the source reads.
+func (c *client) GetSongs(ctx context.Context, date string) (models.Playlist, error) {
+	resp, err := c.Get(ctx, "/playlist", httpclient.WithQuery(map[string]string{
+		"date": date,
+	}))
+	if err != nil {
+		return models.Playlist{}, err
+	}
+
+	defer resp.Body.Close()
+
+	playlist, err := decode.JSON[models.Playlist](resp)
+	if err != nil {
+		return models.Playlist{}, err
+	}
+
+	return playlist, nil
+}
@@ -0,0 +1,37 @@
+package thecurrentclient
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClient_GetSongs(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/playlist", r.URL.Path)
+		assert.Equal(t, "2025-10-19", r.URL.Query().Get("date"))
+
+		http.ServeFile(w, r, "testdata/playlist.json")
+	}))
+	defer ts.Close()
+
+	baseURL, err := url.Parse(ts.URL)
+	require.NoError(t, err)
+	c := New(Config{
+		BaseURL: baseURL,
+	})
+
+	playlist, err := c.GetSongs(t.Context(), "2025-10-19")
+	require.NoError(t, err)
+	require.Len(t, playlist.Songs, 3)
+
+	assert.Equal(t, "Wednesday", playlist.Songs[0].Artist)
+	assert.Equal(t, "Elderberry Wine", playlist.Songs[0].Track)
+	assert.Equal(t, "Bleeds", playlist.Songs[0].Album)
+	assert.Equal(t, "2025-10-19T06:04:00-05:00", playlist.Songs[0].PlayedAt)
+	assert.Equal(t, "Morning Show", playlist.Songs[0].Program)
+}
@@ -0,0 +1,207 @@
+package tidalclient
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/jbenzshawel/playlist-generator/internal/app/commands/playlists/tidal/models"
+	"github.com/jbenzshawel/playlist-generator/internal/infrastructure/clients/httpclient"
+	"github.com/jbenzshawel/playlist-generator/internal/infrastructure/clients/httpclient/decode"
+)
+
+const (
+	defaultCountryCode = "US"
+
+	jsonAPIMediaType = "application/vnd.api+json"
+
+	clientTimeout = 10 * time.Second
+)
+
+type Config struct {
+	BaseURL *url.URL
+	// Client is the OAuth client of the user the playlists are created for
+	Client *http.Client
+	// CountryCode is the catalog country searched for tracks. It defaults to US.
+	CountryCode string
+}
+
+type Client struct {
+	httpclient.Client
+	countryCode string
+}
+
+func New(cfg Config) *Client {
+	countryCode := cfg.CountryCode
+	if countryCode == "" {
+		countryCode = defaultCountryCode
+	}
+
+	client := &http.Client{Timeout: clientTimeout}
+	if cfg.Client != nil {
+		c := *cfg.Client
+		client = &c
+	}
+
+	base := client.Transport
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	client.Transport = &jsonAPITransport{base: base}
+
+	return &Client{
+		Client: httpclient.NewRetryingClient(httpclient.Config{
+			BaseURL:          cfg.BaseURL,
+			Client:           client,
+			LimitWindow:      60,
+			LimitNumRequests: 300,
+			LimitBatchSize:   6,
+		}),
+		countryCode: countryCode,
+	}
+}
+
+// GetTracksByISRC returns the tracks of a recording including their artists and albums.
+func (c *Client) GetTracksByISRC(ctx context.Context, isrc string) (models.TrackDocument, error) {
+	return c.getTracks(ctx, map[string]string{"filter[isrc]": isrc})
+}
+
+// GetTracks returns tracks by ID including their artists and albums.
+func (c *Client) GetTracks(ctx context.Context, trackIDs []string) (models.TrackDocument, error) {
+	return c.getTracks(ctx, map[string]string{"filter[id]": strings.Join(trackIDs, ",")})
+}
+
+func (c *Client) getTracks(ctx context.Context, filter map[string]string) (models.TrackDocument, error) {
+	query := map[string]string{
+		"countryCode": c.countryCode,
+		"include":     "artists,albums",
+	}
+	for k, v := range filter {
+		query[k] = v
+	}
+
+	resp, err := c.Get(ctx, "/tracks", httpclient.WithQuery(query))
+	if err != nil {
+		return models.TrackDocument{}, err
+	}
+
+	defer resp.Body.Close()
+
+	tracks, err := decode.JSON[models.TrackDocument](resp)
+	if err != nil {
+		return models.TrackDocument{}, err
+	}
+
+	return tracks, nil
+}
+
+// SearchTracks returns the IDs of the tracks found by searching for a query. The tracks
+// are found with GetTracks.
+func (c *Client) SearchTracks(ctx context.Context, query string) (models.RelationshipDocument, error) {
+	resp, err := c.Get(ctx, fmt.Sprintf("/searchResults/%s/relationships/tracks", url.PathEscape(query)), httpclient.WithQuery(map[string]string{
+		"countryCode": c.countryCode,
+	}))
+	if err != nil {
+		return models.RelationshipDocument{}, err
+	}
+
+	defer resp.Body.Close()
+
+	results, err := decode.JSON[models.RelationshipDocument](resp)
+	if err != nil {
+		return models.RelationshipDocument{}, err
+	}
+
+	return results, nil
+}
+
+// GetAlbumsByBarcodeID returns the albums with a UPC or EAN barcode including their items.
+func (c *Client) GetAlbumsByBarcodeID(ctx context.Context, barcodeID string) (models.AlbumDocument, error) {
+	resp, err := c.Get(ctx, "/albums", httpclient.WithQuery(map[string]string{
+		"countryCode":       c.countryCode,
+		"filter[barcodeId]": barcodeID,
+		"include":           "items",
+	}))
+	if err != nil {
+		return models.AlbumDocument{}, err
+	}
+
+	defer resp.Body.Close()
+
+	albums, err := decode.JSON[models.AlbumDocument](resp)
+	if err != nil {
+		return models.AlbumDocument{}, err
+	}
+
+	return albums, nil
+}
+
+func (c *Client) CreatePlaylist(ctx context.Context, request models.PlaylistDocument) (models.Playlist, error) {
+	resp, err := c.Post(ctx, "/playlists", httpclient.WithJSONBody(request))
+	if err != nil {
+		return models.Playlist{}, err
+	}
+
+	defer resp.Body.Close()
+
+	playlist, err := decode.JSON[models.PlaylistDocument](resp)
+	if err != nil {
+		return models.Playlist{}, err
+	}
+
+	return playlist.Data, nil
+}
+
+// GetPlaylistItems returns a page of the items in a playlist. The first page is returned
+// when the cursor is empty.
+func (c *Client) GetPlaylistItems(ctx context.Context, playlistID, cursor string) (models.RelationshipDocument, error) {
+	query := map[string]string{
+		"countryCode": c.countryCode,
+	}
+	if cursor != "" {
+		query["page[cursor]"] = cursor
+	}
+
+	resp, err := c.Get(ctx, fmt.Sprintf("/playlists/%s/relationships/items", playlistID), httpclient.WithQuery(query))
+	if err != nil {
+		return models.RelationshipDocument{}, err
+	}
+
+	defer resp.Body.Close()
+
+	page, err := decode.JSON[models.RelationshipDocument](resp)
+	if err != nil {
+		return models.RelationshipDocument{}, err
+	}
+
+	return page, nil
+}
+
+func (c *Client) AddPlaylistItems(ctx context.Context, playlistID string, request models.AddPlaylistItemsRequest) error {
+	resp, err := c.Post(ctx, fmt.Sprintf("/playlists/%s/relationships/items", playlistID), httpclient.WithJSONBody(request))
+	if err != nil {
+		return err
+	}
+
+	defer resp.Body.Close()
+
+	return decode.NoContent(resp)
+}
+
+// jsonAPITransport sets the JSON:API media type the Tidal API expects on every request.
+type jsonAPITransport struct {
+	base http.RoundTripper
+}
+
+func (t *jsonAPITransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	req = req.Clone(req.Context())
+	req.Header.Set("Accept", jsonAPIMediaType)
+	if req.Body != nil {
+		req.Header.Set("Content-Type", jsonAPIMediaType)
+	}
+
+	return t.base.RoundTrip(req)
+}
@@ -0,0 +1,209 @@
+package tidalclient
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/jbenzshawel/playlist-generator/internal/app/commands/playlists/tidal/models"
+)
+
+func TestClient_GetTracksByISRC(t *testing.T) {
+	c, mux := newTestClient(t)
+
+	mux.HandleFunc("GET /v2/tracks", func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "USMC19838577", r.URL.Query().Get("filter[isrc]"))
+		assert.Equal(t, "artists,albums", r.URL.Query().Get("include"))
+		assert.Equal(t, "CA", r.URL.Query().Get("countryCode"))
+
+		_, _ = io.WriteString(w, `{"data": [{
+			"id": "1901826",
+			"type": "tracks",
+			"attributes": {"title": "Never There", "isrc": "USMC19838577"},
+			"relationships": {
+				"artists": {"data": [{"id": "16474", "type": "artists"}]},
+				"albums": {"data": [{"id": "1901818", "type": "albums"}]}
+			}
+		}], "included": [
+			{"id": "16474", "type": "artists", "attributes": {"name": "CAKE"}},
+			{"id": "1901818", "type": "albums", "attributes": {"title": "Prolonging The Magic"}}
+		]}`)
+	})
+
+	doc, err := c.GetTracksByISRC(t.Context(), "USMC19838577")
+	require.NoError(t, err)
+	require.Len(t, doc.Data, 1)
+
+	track := doc.Data[0]
+	assert.Equal(t, "1901826", track.ID)
+	assert.Equal(t, "Never There", track.Attributes.Title)
+	assert.Equal(t, []models.ResourceIdentifier{{ID: "16474", Type: "artists"}}, track.Relationships.Artists.Data)
+	require.Len(t, doc.Included, 2)
+	assert.Equal(t, "CAKE", doc.Included[0].Attributes.Name)
+	assert.Equal(t, "Prolonging The Magic", doc.Included[1].Attributes.Title)
+}
+
+func TestClient_GetTracks(t *testing.T) {
+	c, mux := newTestClient(t)
+
+	mux.HandleFunc("GET /v2/tracks", func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "1901826,1901827", r.URL.Query().Get("filter[id]"))
+
+		_, _ = io.WriteString(w, `{"data": [
+			{"id": "1901826", "type": "tracks", "attributes": {"title": "Never There"}},
+			{"id": "1901827", "type": "tracks", "attributes": {"title": "Sheep Go To Heaven"}}
+		]}`)
+	})
+
+	doc, err := c.GetTracks(t.Context(), []string{"1901826", "1901827"})
+	require.NoError(t, err)
+	assert.Len(t, doc.Data, 2)
+}
+
+func TestClient_SearchTracks(t *testing.T) {
+	c, mux := newTestClient(t)
+
+	mux.HandleFunc("GET /v2/searchResults/{query}/relationships/tracks", func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "Cake Never There", r.PathValue("query"))
+
+		_, _ = io.WriteString(w, `{"data": [{"id": "1901826", "type": "tracks"}]}`)
+	})
+
+	doc, err := c.SearchTracks(t.Context(), "Cake Never There")
+	require.NoError(t, err)
+	assert.Equal(t, []models.ResourceIdentifier{{ID: "1901826", Type: models.TracksResourceType}}, doc.Data)
+}
+
+func TestClient_GetAlbumsByBarcodeID(t *testing.T) {
+	c, mux := newTestClient(t)
+
+	mux.HandleFunc("GET /v2/albums", func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "008811199728", r.URL.Query().Get("filter[barcodeId]"))
+		assert.Equal(t, "items", r.URL.Query().Get("include"))
+
+		_, _ = io.WriteString(w, `{"data": [{
+			"id": "1901818",
+			"type": "albums",
+			"attributes": {"title": "Prolonging The Magic", "barcodeId": "008811199728"},
+			"relationships": {"items": {"data": [{"id": "1901826", "type": "tracks"}]}}
+		}], "included": [
+			{"id": "1901826", "type": "tracks", "attributes": {"title": "Never There"}}
+		]}`)
+	})
+
+	doc, err := c.GetAlbumsByBarcodeID(t.Context(), "008811199728")
+	require.NoError(t, err)
+	require.Len(t, doc.Data, 1)
+	assert.Equal(t, "008811199728", doc.Data[0].Attributes.BarcodeID)
+	assert.Len(t, doc.Data[0].Relationships.Items.Data, 1)
+	require.Len(t, doc.Included, 1)
+	assert.Equal(t, "Never There", doc.Included[0].Attributes.Title)
+}
+
+func TestClient_CreatePlaylist(t *testing.T) {
+	c, mux := newTestClient(t)
+
+	mux.HandleFunc("POST /v2/playlists", func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, jsonAPIMediaType, r.Header.Get("Content-Type"))
+
+		var request models.PlaylistDocument
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&request))
+		assert.Equal(t, models.PlaylistsResourceType, request.Data.Type)
+		assert.Equal(t, "Studio One 2025-10", request.Data.Attributes.Name)
+
+		w.WriteHeader(http.StatusCreated)
+		_, _ = io.WriteString(w, `{"data": {
+			"id": "2f2b8a4e-7a4b-4f4a-9d2b-3c1e2f9b8a7d",
+			"type": "playlists",
+			"attributes": {"name": "Studio One 2025-10"}
+		}}`)
+	})
+
+	var request models.PlaylistDocument
+	request.Data.Type = models.PlaylistsResourceType
+	request.Data.Attributes.Name = "Studio One 2025-10"
+
+	playlist, err := c.CreatePlaylist(t.Context(), request)
+	require.NoError(t, err)
+	assert.Equal(t, "2f2b8a4e-7a4b-4f4a-9d2b-3c1e2f9b8a7d", playlist.ID)
+}
+
+func TestClient_GetPlaylistItems(t *testing.T) {
+	c, mux := newTestClient(t)
+
+	mux.HandleFunc("GET /v2/playlists/p1/relationships/items", func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Query().Get("page[cursor]") {
+		case "":
+			_, _ = io.WriteString(w, `{
+				"data": [{"id": "1901826", "type": "tracks"}],
+				"links": {"next": "/playlists/p1/relationships/items?page%5Bcursor%5D=next"}
+			}`)
+		case "next":
+			_, _ = io.WriteString(w, `{"data": [{"id": "1901827", "type": "tracks"}], "links": {}}`)
+		default:
+			http.NotFound(w, r)
+		}
+	})
+
+	first, err := c.GetPlaylistItems(t.Context(), "p1", "")
+	require.NoError(t, err)
+	assert.Equal(t, "1901826", first.Data[0].ID)
+	assert.NotEmpty(t, first.Links.Next)
+
+	last, err := c.GetPlaylistItems(t.Context(), "p1", "next")
+	require.NoError(t, err)
+	assert.Equal(t, "1901827", last.Data[0].ID)
+	assert.Empty(t, last.Links.Next)
+}
+
+func TestClient_AddPlaylistItems(t *testing.T) {
+	c, mux := newTestClient(t)
+
+	mux.HandleFunc("POST /v2/playlists/p1/relationships/items", func(w http.ResponseWriter, r *http.Request) {
+		var request models.AddPlaylistItemsRequest
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&request))
+		assert.Equal(t, []models.ResourceIdentifier{{ID: "1901826", Type: "tracks"}}, request.Data)
+
+		w.WriteHeader(http.StatusNoContent)
+	})
+	mux.HandleFunc("POST /v2/playlists/missing/relationships/items", func(w http.ResponseWriter, r *http.Request) {
+		http.NotFound(w, r)
+	})
+
+	request := models.AddPlaylistItemsRequest{
+		Data: []models.ResourceIdentifier{{ID: "1901826", Type: models.TracksResourceType}},
+	}
+
+	require.NoError(t, c.AddPlaylistItems(t.Context(), "p1", request))
+	assert.Error(t, c.AddPlaylistItems(t.Context(), "missing", request))
+}
+
+// newTestClient returns a client for a Tidal stand-in that checks every request accepts
+// the JSON:API media type.
+func newTestClient(t *testing.T) (*Client, *http.ServeMux) {
+	t.Helper()
+
+	mux := http.NewServeMux()
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, jsonAPIMediaType, r.Header.Get("Accept"))
+
+		w.Header().Set("Content-Type", jsonAPIMediaType)
+		mux.ServeHTTP(w, r)
+	}))
+	t.Cleanup(ts.Close)
+
+	baseURL, err := url.Parse(ts.URL + "/v2")
+	require.NoError(t, err)
+
+	return New(Config{
+		BaseURL:     baseURL,
+		CountryCode: "CA",
+	}), mux
+}
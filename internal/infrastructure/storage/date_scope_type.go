@@ -0,0 +1,22 @@
+package storage
+
+import (
+	"context"
+
+	"github.com/jbenzshawel/playlist-generator/internal/domain"
+)
+
+func initPlaylistDateScopes(ctx context.Context, db *sqlDB) error {
+	for _, ds := range domain.AllPlaylistDateScopes() {
+		query := `INSERT INTO playlist_date_scopes (id, name)
+		VALUES (?, ?)
+		ON CONFLICT (id) DO NOTHING;`
+
+		_, err := db.ExecContext(ctx, query, int(ds), ds.String())
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
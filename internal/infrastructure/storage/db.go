@@ -0,0 +1,135 @@
+package storage
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/jbenzshawel/playlist-generator/internal/infrastructure/storage/internal/dialect"
+)
+
+const (
+	sqliteParams = "_busy_timeout=5000&_pragma=journal_mode(WAL)"
+	memoryPath   = ":memory:"
+)
+
+// SQLiteDSN returns the DSN of the SQLite database file at path, or of an in-memory database
+// that only lasts as long as the storage is open when path is :memory:.
+func SQLiteDSN(path string) string {
+	if path == memoryPath {
+		return "file::memory:?mode=memory&cache=shared"
+	}
+	return "file:" + path + "?" + sqliteParams
+}
+
+// sqlDB is a database handle that rebinds the placeholders of queries written for SQLite
+// to the placeholders of the database's dialect.
+type sqlDB struct {
+	db      *sql.DB
+	dialect dialect.Dialect
+	// keepAlive holds a connection to an in-memory database open, since the database is
+	// deleted when its last connection closes
+	keepAlive *sql.Conn
+}
+
+func openDB(dsn string) (*sqlDB, error) {
+	d := dialect.FromDSN(dsn)
+
+	path, memory := sqlitePath(dsn)
+	if d == dialect.SQLite && !memory {
+		err := os.MkdirAll(filepath.Dir(path), 0o755)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create database directory: %w", err)
+		}
+	}
+
+	db, err := sql.Open(d.DriverName(), dsn)
+	if err != nil {
+		return nil, err
+	}
+
+	var keepAlive *sql.Conn
+	if d == dialect.SQLite && memory {
+		keepAlive, err = db.Conn(context.Background())
+		if err != nil {
+			_ = db.Close()
+			return nil, err
+		}
+	}
+
+	return &sqlDB{db: db, dialect: d, keepAlive: keepAlive}, nil
+}
+
+// sqlitePath returns the file path of a SQLite DSN, or whether the DSN is for an
+// in-memory database.
+func sqlitePath(dsn string) (string, bool) {
+	path, params, _ := strings.Cut(strings.TrimPrefix(dsn, "file:"), "?")
+	if path == "" || path == memoryPath || strings.Contains(params, "mode=memory") {
+		return "", true
+	}
+	return path, false
+}
+
+func (d *sqlDB) ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error) {
+	return d.db.ExecContext(ctx, d.dialect.Rebind(query), args...)
+}
+
+func (d *sqlDB) QueryContext(ctx context.Context, query string, args ...any) (*sql.Rows, error) {
+	return d.db.QueryContext(ctx, d.dialect.Rebind(query), args...)
+}
+
+func (d *sqlDB) QueryRowContext(ctx context.Context, query string, args ...any) *sql.Row {
+	return d.db.QueryRowContext(ctx, d.dialect.Rebind(query), args...)
+}
+
+func (d *sqlDB) BeginTx(ctx context.Context, opts *sql.TxOptions) (*sqlTx, error) {
+	tx, err := d.db.BeginTx(ctx, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	return &sqlTx{tx: tx, dialect: d.dialect}, nil
+}
+
+func (d *sqlDB) Close() error {
+	if d.keepAlive != nil {
+		_ = d.keepAlive.Close()
+	}
+	return d.db.Close()
+}
+
+// sqlTx is a transaction that rebinds the placeholders of queries written for SQLite
+// to the placeholders of the database's dialect.
+type sqlTx struct {
+	tx      *sql.Tx
+	dialect dialect.Dialect
+}
+
+func (t *sqlTx) ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error) {
+	return t.tx.ExecContext(ctx, t.dialect.Rebind(query), args...)
+}
+
+func (t *sqlTx) QueryContext(ctx context.Context, query string, args ...any) (*sql.Rows, error) {
+	return t.tx.QueryContext(ctx, t.dialect.Rebind(query), args...)
+}
+
+func (t *sqlTx) QueryRowContext(ctx context.Context, query string, args ...any) *sql.Row {
+	return t.tx.QueryRowContext(ctx, t.dialect.Rebind(query), args...)
+}
+
+// StmtContext returns the prepared statement for use in the transaction. Prepared
+// statements are rebound when they're prepared.
+func (t *sqlTx) StmtContext(ctx context.Context, stmt *sql.Stmt) *sql.Stmt {
+	return t.tx.StmtContext(ctx, stmt)
+}
+
+func (t *sqlTx) Commit() error {
+	return t.tx.Commit()
+}
+
+func (t *sqlTx) Rollback() error {
+	return t.tx.Rollback()
+}
@@ -0,0 +1,68 @@
+package storage
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/jbenzshawel/playlist-generator/internal/domain"
+)
+
+func TestSQLiteDSN(t *testing.T) {
+	assert.Equal(t, "file:db/app.db?_busy_timeout=5000&_pragma=journal_mode(WAL)", SQLiteDSN("db/app.db"))
+	assert.Equal(t, "file:/var/lib/playlists/app.db?_busy_timeout=5000&_pragma=journal_mode(WAL)", SQLiteDSN("/var/lib/playlists/app.db"))
+	assert.Equal(t, "file::memory:?mode=memory&cache=shared", SQLiteDSN(":memory:"))
+}
+
+func TestSqlitePath(t *testing.T) {
+	testCases := []struct {
+		dsn            string
+		expectedPath   string
+		expectedMemory bool
+	}{
+		{dsn: "file:db/app.db?_busy_timeout=5000", expectedPath: "db/app.db"},
+		{dsn: "db/app.db", expectedPath: "db/app.db"},
+		{dsn: "file:/var/lib/app.db", expectedPath: "/var/lib/app.db"},
+		{dsn: ":memory:", expectedMemory: true},
+		{dsn: "file::memory:?cache=shared", expectedMemory: true},
+		{dsn: "file:test.db?mode=memory&cache=shared", expectedMemory: true},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.dsn, func(t *testing.T) {
+			path, memory := sqlitePath(tc.dsn)
+			assert.Equal(t, tc.expectedPath, path)
+			assert.Equal(t, tc.expectedMemory, memory)
+		})
+	}
+}
+
+func TestInitialize_CreatesDirectories(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "data", "db", "app.db")
+
+	storage, err := Initialize(t.Context(), SQLiteDSN(path))
+	require.NoError(t, err)
+	storage.Close()
+
+	assert.FileExists(t, path)
+}
+
+func TestInitialize_Memory(t *testing.T) {
+	storage, err := Initialize(t.Context(), SQLiteDSN(":memory:"))
+	require.NoError(t, err)
+	t.Cleanup(storage.Close)
+
+	// the database outlives idle connections being closed
+	storage.db.db.SetMaxIdleConns(0)
+
+	song, err := domain.NewSong("Cake", "Never There", "Prolonging The Magic", "")
+	require.NoError(t, err)
+
+	repo := NewRepository(storage)
+	require.NoError(t, repo.Begin(t.Context()))
+	require.NoError(t, repo.Song().BulkInsert(t.Context(), []domain.Song{song}))
+	require.NoError(t, repo.Commit())
+
+	assert.Len(t, getAllSongs(t, storage.db), 1)
+}
@@ -0,0 +1,99 @@
+package dialect
+
+import (
+	"strconv"
+	"strings"
+)
+
+// Dialect is the SQL database a storage is backed by. Queries are written for SQLite,
+// with ? or ?NNN placeholders, and rebound to the placeholders of the dialect.
+type Dialect int
+
+const (
+	UnknownDialect Dialect = iota
+	SQLite
+	Postgres
+)
+
+var dialects = map[Dialect]string{
+	SQLite:   "sqlite",
+	Postgres: "postgres",
+}
+
+func (d Dialect) String() string {
+	s, ok := dialects[d]
+	if !ok {
+		return "unknown"
+	}
+	return s
+}
+
+// FromDSN returns Postgres for postgres:// and postgresql:// URLs and SQLite for any
+// other DSN.
+func FromDSN(dsn string) Dialect {
+	if strings.HasPrefix(dsn, "postgres://") || strings.HasPrefix(dsn, "postgresql://") {
+		return Postgres
+	}
+	return SQLite
+}
+
+// DriverName is the database/sql driver the dialect is opened with.
+func (d Dialect) DriverName() string {
+	if d == Postgres {
+		return "pgx"
+	}
+	return "sqlite"
+}
+
+// Rebind replaces the ? and ?NNN placeholders in query with the placeholders of the
+// dialect. Placeholders in string literals, quoted identifiers, and comments are left as is.
+func (d Dialect) Rebind(query string) string {
+	if d != Postgres || !strings.Contains(query, "?") {
+		return query
+	}
+
+	var b strings.Builder
+	b.Grow(len(query) + 8)
+
+	n := 0
+	for i := 0; i < len(query); i++ {
+		c := query[i]
+		switch {
+		case c == '\'' || c == '"':
+			end := strings.IndexByte(query[i+1:], c)
+			if end < 0 {
+				b.WriteString(query[i:])
+				return b.String()
+			}
+			b.WriteString(query[i : i+end+2])
+			i += end + 1
+		case c == '-' && i+1 < len(query) && query[i+1] == '-':
+			end := strings.IndexByte(query[i:], '\n')
+			if end < 0 {
+				b.WriteString(query[i:])
+				return b.String()
+			}
+			b.WriteString(query[i : i+end+1])
+			i += end
+		case c == '?':
+			j := i + 1
+			for j < len(query) && query[j] >= '0' && query[j] <= '9' {
+				j++
+			}
+
+			b.WriteByte('$')
+			if j > i+1 {
+				// numbered placeholders keep their number
+				b.WriteString(query[i+1 : j])
+			} else {
+				n++
+				b.WriteString(strconv.Itoa(n))
+			}
+			i = j - 1
+		default:
+			b.WriteByte(c)
+		}
+	}
+
+	return b.String()
+}
@@ -5,6 +5,8 @@ import (
 	"database/sql"
 	"fmt"
 	"log/slog"
+
+	"github.com/jbenzshawel/playlist-generator/internal/infrastructure/storage/internal/dialect"
 )
 
 type Type int
@@ -12,14 +14,16 @@ type Type int
 const (
 	UnknownType Type = iota
 	InsertSongType
+	InsertSongArtistType
 	InsertSongSourceType
-	InsertSpotifyTrackType
+	InsertTrackType
 )
 
 var types = map[Type]string{
-	InsertSongType:         "InsertSongType",
-	InsertSongSourceType:   "InsertSongSourceType",
-	InsertSpotifyTrackType: "InsertSpotifyTrackType",
+	InsertSongType:       "InsertSongType",
+	InsertSongArtistType: "InsertSongArtistType",
+	InsertSongSourceType: "InsertSongSourceType",
+	InsertTrackType:      "InsertTrackType",
 }
 
 func (t Type) String() string {
@@ -33,35 +37,43 @@ func (t Type) String() string {
 func AllTypes() []Type {
 	return []Type{
 		InsertSongType,
+		InsertSongArtistType,
 		InsertSongSourceType,
-		InsertSpotifyTrackType,
+		InsertTrackType,
 	}
 }
 
 var statementsSQL = map[Type]string{
-	InsertSongType: `INSERT INTO songs (id, artist, track, album, upc, song_hash, created) 
-					VALUES (?,?,?, ?, ?, ?, ?)
+	InsertSongType: `INSERT INTO songs (id, artist, track, album, upc, song_hash, hash_version, created) 
+					VALUES (?,?,?, ?, ?, ?, ?, ?)
 					ON CONFLICT (song_hash) DO NOTHING;`,
 
+	InsertSongArtistType: `INSERT INTO song_artists (song_id, position, artist)
+					VALUES (?, ?, ?)
+					ON CONFLICT (song_id, position) DO NOTHING;`,
+
 	InsertSongSourceType: `INSERT INTO song_sources (id, source_id, source_type_id, song_hash, program_name, date_played, end_time, created)
-			VALUES (?,?,?,?,?,?,?, ?);`,
+			VALUES (?,?,?,?,?,?,?, ?)
+			ON CONFLICT DO NOTHING;`,
 
-	InsertSpotifyTrackType: `INSERT INTO spotify_tracks (id, uri, song_id, match_found)
-			VALUES (?,?,?,?);`,
+	InsertTrackType: `INSERT INTO tracks (song_id, playlist_type_id, id, uri, match_found, match_method_id, confidence, matched_artist, matched_track, matched_album,
+				isrc, matched_at, attempt_count, last_attempt, next_attempt)
+			VALUES (?,?,?,?,?,?,?,?,?,?,?,?,?,?,?);`,
 }
 
 type statements struct {
 	prepared map[Type]*sql.Stmt
 }
 
-func New(ctx context.Context, db *sql.DB) (*statements, error) {
+// New prepares the statements, rebinding their placeholders for the dialect of db.
+func New(ctx context.Context, db *sql.DB, d dialect.Dialect) (*statements, error) {
 	s := &statements{
 		prepared: make(map[Type]*sql.Stmt),
 	}
 
 	for stmtType, stmtSQL := range statementsSQL {
 		var err error
-		s.prepared[stmtType], err = db.PrepareContext(ctx, stmtSQL)
+		s.prepared[stmtType], err = db.PrepareContext(ctx, d.Rebind(stmtSQL))
 		if err != nil {
 			return nil, err
 		}
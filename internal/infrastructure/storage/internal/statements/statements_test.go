@@ -6,13 +6,15 @@ import (
 
 	"github.com/stretchr/testify/require"
 	_ "modernc.org/sqlite"
+
+	"github.com/jbenzshawel/playlist-generator/internal/infrastructure/storage/internal/dialect"
 )
 
 func TestStatements(t *testing.T) {
 	db, err := sql.Open("sqlite", "file::memory:?mode=memory&cache=shared")
 	require.NoError(t, err)
 
-	s, err := New(t.Context(), db)
+	s, err := New(t.Context(), db, dialect.SQLite)
 	require.NoError(t, err)
 	t.Cleanup(func() {
 		s.Close()
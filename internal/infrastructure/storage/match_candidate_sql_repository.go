@@ -0,0 +1,120 @@
+package storage
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/google/uuid"
+
+	"github.com/jbenzshawel/playlist-generator/internal/domain"
+)
+
+var _ domain.MatchCandidateRepository = (*matchCandidateSqlRepository)(nil)
+
+type matchCandidateSqlRepository struct {
+	tx *sqlTx
+}
+
+func (r *matchCandidateSqlRepository) SetTransaction(tx *sqlTx) {
+	r.tx = tx
+}
+
+func (r *matchCandidateSqlRepository) GetReviewSongs(ctx context.Context) ([]domain.Song, error) {
+	rows, err := r.tx.QueryContext(ctx,
+		`SELECT id, artist, track, album, upc, song_hash, created
+			FROM songs
+			WHERE id IN (SELECT song_id FROM match_candidates)
+			ORDER BY created, id;`,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	return scanSongRows(rows)
+}
+
+func (r *matchCandidateSqlRepository) GetCandidates(ctx context.Context, songID uuid.UUID) ([]domain.MatchCandidate, error) {
+	rows, err := r.tx.QueryContext(ctx,
+		`SELECT song_id, rank, track_id, uri, match_method_id, confidence, matched_artist, matched_track, matched_album, created
+			FROM match_candidates
+			WHERE song_id = ?
+			ORDER BY rank;`,
+		songID.String(),
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	return scanMatchCandidates(rows)
+}
+
+func (r *matchCandidateSqlRepository) Replace(ctx context.Context, songID uuid.UUID, candidates []domain.MatchCandidate) error {
+	if err := r.Delete(ctx, songID); err != nil {
+		return err
+	}
+
+	for _, c := range candidates {
+		_, err := r.tx.ExecContext(ctx,
+			`INSERT INTO match_candidates (song_id, rank, track_id, uri, match_method_id, confidence, matched_artist, matched_track, matched_album, created)
+				VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?);`,
+			songID.String(), c.Rank(), c.TrackID(), c.URI(), c.MatchMethod(), c.Confidence(),
+			c.MatchedArtist(), c.MatchedTrack(), c.MatchedAlbum(), timeToUTCString(c.Created()),
+		)
+		if err != nil {
+			return fmt.Errorf("failed to insert match candidate: %w", err)
+		}
+	}
+
+	return nil
+}
+
+func (r *matchCandidateSqlRepository) Delete(ctx context.Context, songID uuid.UUID) error {
+	_, err := r.tx.ExecContext(ctx, `DELETE FROM match_candidates WHERE song_id = ?;`, songID.String())
+	return err
+}
+
+func scanMatchCandidates(rows *sql.Rows) ([]domain.MatchCandidate, error) {
+	var results []domain.MatchCandidate
+	for rows.Next() {
+		var (
+			songIDStr     string
+			rank          int
+			trackID       string
+			uri           string
+			matchMethod   domain.MatchMethod
+			confidence    float64
+			matchedArtist string
+			matchedTrack  string
+			matchedAlbum  string
+			createdStr    string
+		)
+
+		err := rows.Scan(&songIDStr, &rank, &trackID, &uri, &matchMethod, &confidence,
+			&matchedArtist, &matchedTrack, &matchedAlbum, &createdStr)
+		if err != nil {
+			return nil, err
+		}
+
+		songID, err := uuid.Parse(songIDStr)
+		if err != nil {
+			return nil, err
+		}
+
+		created, err := utcStringToTime(createdStr)
+		if err != nil {
+			return nil, err
+		}
+
+		results = append(results, domain.NewMatchCandidateFromDB(
+			songID, rank, trackID, uri, matchMethod, confidence, matchedArtist, matchedTrack, matchedAlbum, created,
+		))
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return results, nil
+}
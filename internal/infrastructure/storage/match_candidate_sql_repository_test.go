@@ -0,0 +1,76 @@
+package storage
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/jbenzshawel/playlist-generator/internal/domain"
+)
+
+func TestMatchCandidateSqlRepository(t *testing.T) {
+	now := formatDateTime(t, time.Now())
+
+	songs := []domain.Song{
+		domain.NewSongFromDB(uuid.New(), "artist1", "track1", "album1", "", "songHash1", now),
+		domain.NewSongFromDB(uuid.New(), "artist2", "track2", "album2", "", "songHash2", now.Add(time.Second)),
+		domain.NewSongFromDB(uuid.New(), "artist3", "track3", "album3", "", "songHash3", now),
+	}
+
+	storage := InitTestStorage(t)
+
+	tx, err := storage.db.BeginTx(t.Context(), nil)
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		_ = tx.Rollback()
+	})
+
+	songRepo := &songSqlRepository{tx: tx, stmts: storage.stmts}
+	require.NoError(t, songRepo.BulkInsert(t.Context(), songs))
+
+	r := &matchCandidateSqlRepository{}
+	r.SetTransaction(tx)
+
+	candidates1 := []domain.MatchCandidate{
+		domain.NewMatchCandidateFromDB(songs[0].ID(), 0, "trackID1", "spotify:track:trackID1", domain.FuzzyMatchMethod, 78.5, "artist1", "track1", "album1", now),
+		domain.NewMatchCandidateFromDB(songs[0].ID(), 1, "trackID1b", "spotify:track:trackID1b", domain.FuzzyMatchMethod, 74, "artist1", "track1 (live)", "album1", now),
+	}
+	candidates2 := []domain.MatchCandidate{
+		domain.NewMatchCandidateFromDB(songs[1].ID(), 0, "trackID2", "spotify:track:trackID2", domain.SingleResultMatchMethod, 71, "artist2", "track2", "album2", now),
+	}
+
+	t.Run("replace", func(t *testing.T) {
+		require.NoError(t, r.Replace(t.Context(), songs[0].ID(), candidates1))
+		require.NoError(t, r.Replace(t.Context(), songs[1].ID(), candidates1[1:]))
+		require.NoError(t, r.Replace(t.Context(), songs[1].ID(), candidates2))
+
+		actual, err := r.GetCandidates(t.Context(), songs[0].ID())
+		require.NoError(t, err)
+		assert.Equal(t, candidates1, actual)
+
+		actual, err = r.GetCandidates(t.Context(), songs[1].ID())
+		require.NoError(t, err)
+		assert.Equal(t, candidates2, actual)
+
+		actual, err = r.GetCandidates(t.Context(), songs[2].ID())
+		require.NoError(t, err)
+		assert.Empty(t, actual)
+	})
+
+	t.Run("get review songs", func(t *testing.T) {
+		actual, err := r.GetReviewSongs(t.Context())
+		require.NoError(t, err)
+		assert.Equal(t, songs[:2], actual)
+	})
+
+	t.Run("delete", func(t *testing.T) {
+		require.NoError(t, r.Delete(t.Context(), songs[0].ID()))
+
+		actual, err := r.GetReviewSongs(t.Context())
+		require.NoError(t, err)
+		assert.Equal(t, songs[1:2], actual)
+	})
+}
@@ -0,0 +1,22 @@
+package storage
+
+import (
+	"context"
+
+	"github.com/jbenzshawel/playlist-generator/internal/domain"
+)
+
+func initMatchMethods(ctx context.Context, db *sqlDB) error {
+	for _, m := range domain.AllMatchMethods() {
+		query := `INSERT INTO match_methods (id, name)
+		VALUES (?, ?)
+		ON CONFLICT (id) DO NOTHING;`
+
+		_, err := db.ExecContext(ctx, query, int(m), m.String())
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,73 @@
+package storage
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/jbenzshawel/playlist-generator/internal/domain"
+)
+
+var _ domain.MatchOverrideRepository = (*matchOverrideSqlRepository)(nil)
+
+type matchOverrideSqlRepository struct {
+	tx *sqlTx
+}
+
+func (r *matchOverrideSqlRepository) SetTransaction(tx *sqlTx) {
+	r.tx = tx
+}
+
+func (r *matchOverrideSqlRepository) GetMatchOverrides(ctx context.Context) ([]domain.MatchOverride, error) {
+	rows, err := r.tx.QueryContext(ctx,
+		`SELECT song_hash, track_id, uri, previous_uri, created FROM match_overrides ORDER BY created, song_hash;`,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	return scanMatchOverrides(rows)
+}
+
+func (r *matchOverrideSqlRepository) Upsert(ctx context.Context, override domain.MatchOverride) error {
+	_, err := r.tx.ExecContext(ctx,
+		`INSERT INTO match_overrides (song_hash, track_id, uri, previous_uri, created)
+			VALUES (?, ?, ?, ?, ?)
+			ON CONFLICT (song_hash) DO UPDATE SET
+				track_id = excluded.track_id,
+				uri = excluded.uri,
+				previous_uri = excluded.previous_uri,
+				created = excluded.created;`,
+		override.SongHash(), override.TrackID(), override.URI(), override.PreviousURI(), timeToUTCString(override.Created()),
+	)
+	return err
+}
+
+func scanMatchOverrides(rows *sql.Rows) ([]domain.MatchOverride, error) {
+	var results []domain.MatchOverride
+	for rows.Next() {
+		var (
+			songHash    string
+			trackID     string
+			uri         string
+			previousURI string
+			createdStr  string
+		)
+
+		if err := rows.Scan(&songHash, &trackID, &uri, &previousURI, &createdStr); err != nil {
+			return nil, err
+		}
+
+		created, err := utcStringToTime(createdStr)
+		if err != nil {
+			return nil, err
+		}
+
+		results = append(results, domain.NewMatchOverrideFromDB(songHash, trackID, uri, previousURI, created))
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return results, nil
+}
@@ -0,0 +1,50 @@
+package storage
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/jbenzshawel/playlist-generator/internal/domain"
+)
+
+func TestMatchOverrideSqlRepository(t *testing.T) {
+	var (
+		now = formatDateTime(t, time.Now())
+	)
+
+	storage := InitTestStorage(t)
+
+	tx, err := storage.db.BeginTx(t.Context(), nil)
+	require.NoError(t, err)
+
+	r := &matchOverrideSqlRepository{}
+	r.SetTransaction(tx)
+
+	overrides := []domain.MatchOverride{
+		domain.NewMatchOverrideFromDB("songHash1", "trackID1b", "spotify:track:trackID1b", "spotify:track:trackID1", now),
+		domain.NewMatchOverrideFromDB("songHash2", "", "", "spotify:track:old", now),
+		domain.NewMatchOverrideFromDB("songHash3", "trackID3", "spotify:track:trackID3", "spotify:track:shared", now),
+	}
+
+	t.Run("upsert", func(t *testing.T) {
+		for _, o := range overrides {
+			require.NoError(t, r.Upsert(t.Context(), o))
+		}
+
+		actual, err := r.GetMatchOverrides(t.Context())
+		require.NoError(t, err)
+		assert.Equal(t, overrides, actual)
+	})
+
+	t.Run("upsert replaces existing override", func(t *testing.T) {
+		overrides[1] = domain.NewMatchOverrideFromDB("songHash2", "trackID2", "spotify:track:trackID2", "spotify:track:old", now)
+		require.NoError(t, r.Upsert(t.Context(), overrides[1]))
+
+		actual, err := r.GetMatchOverrides(t.Context())
+		require.NoError(t, err)
+		assert.Equal(t, overrides, actual)
+	})
+}
@@ -0,0 +1,584 @@
+package storage
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"os"
+	"time"
+
+	"github.com/jbenzshawel/playlist-generator/internal/domain"
+	"github.com/jbenzshawel/playlist-generator/internal/infrastructure/storage/internal/dialect"
+)
+
+// migrationLockID identifies the Postgres advisory lock held while a migration is applied
+const migrationLockID = 7_202_501
+
+var schemaMigrationSchema string = `CREATE TABLE IF NOT EXISTS schema_migrations (
+    version INTEGER PRIMARY KEY,
+    description TEXT NOT NULL,
+    applied TEXT NOT NULL           -- store timestamps as ISO8601 strings (UTC)
+);`
+
+// migration changes the schema of a database created by an earlier version. Migrations
+// are forward only and are never edited once released, a new migration is added instead.
+// Databases created before migrations were added may already hold some of the tables and
+// columns a migration adds, so tables are created if they don't exist and columns are
+// added with addColumn.
+type migration struct {
+	version     int
+	description string
+	up          func(ctx context.Context, tx *sqlTx) error
+}
+
+var migrations = []migration{
+	{
+		version:     1,
+		description: "create initial schema",
+		up: func(ctx context.Context, tx *sqlTx) error {
+			// Postgres doesn't support conflict clauses on constraints, duplicate song
+			// sources are ignored by the insert instead
+			songSourceConflict := " ON CONFLICT IGNORE"
+			if tx.dialect == dialect.Postgres {
+				songSourceConflict = ""
+			}
+
+			return execStatements(
+				`CREATE TABLE IF NOT EXISTS source_types (
+				id INTEGER PRIMARY KEY,
+				name TEXT NOT NULL
+			);`,
+				`CREATE TABLE IF NOT EXISTS playlist_types (
+				id INTEGER PRIMARY KEY,
+				name TEXT NOT NULL
+			);`,
+				`CREATE TABLE IF NOT EXISTS songs (
+				id TEXT PRIMARY KEY,            -- UUID string (e.g., 550e8400-e29b-41d4-a716-446655440000)
+				artist TEXT NOT NULL,
+				track TEXT NOT NULL,
+				album TEXT NOT NULL,
+				upc TEXT,
+				song_hash TEXT NOT NULL UNIQUE, -- derived hash to de-duplicate song
+				created TEXT NOT NULL           -- store timestamps as ISO8601 strings (UTC)
+			);`,
+				`CREATE TABLE IF NOT EXISTS song_sources (
+				id TEXT PRIMARY KEY,
+				source_id TEXT NOT NULL,
+				song_hash TEXT NOT NULL,
+				source_type_id INT NOT NULL,
+				program_name TEXT,
+				date_played TEXT NOT NULL,
+				end_time TEXT NOT NULL,         -- store timestamps as ISO8601 strings (UTC)
+				created TEXT NOT NULL,          -- store timestamps as ISO8601 strings (UTC)
+				UNIQUE(source_id, source_type_id, end_time)`+songSourceConflict+`
+			);`,
+				`CREATE TABLE IF NOT EXISTS spotify_tracks (
+				id TEXT,
+				uri TEXT NOT NULL,
+				song_id TEXT NOT NULL,
+				match_found INTEGER NOT NULL DEFAULT 0 CHECK(match_found IN (0,1)),
+				PRIMARY KEY (id, song_id)
+			);`,
+				`CREATE TABLE IF NOT EXISTS playlists (
+				id TEXT PRIMARY KEY,
+				uri TEXT NOT NULL,
+				name TEXT NOT NULL,
+				date TEXT NOT NULL,
+				source_type_id INT NOT NULL,
+				playlist_type_id INT NOT NULL,
+				last_day_synced TEXT NOT NULL,
+				created TEXT NOT NULL           -- store timestamps as ISO8601 strings (UTC)
+			);`,
+			)(ctx, tx)
+		},
+	},
+	{
+		version:     2,
+		description: "add playlist date scopes",
+		up: func(ctx context.Context, tx *sqlTx) error {
+			_, err := tx.ExecContext(ctx, `CREATE TABLE IF NOT EXISTS playlist_date_scopes (
+				id INTEGER PRIMARY KEY,
+				name TEXT NOT NULL
+			);`)
+			if err != nil {
+				return err
+			}
+
+			// playlists were monthly before they had a date scope
+			return addColumn(ctx, tx, "playlists", "date_scope_id",
+				fmt.Sprintf("INT NOT NULL DEFAULT %d", int(domain.MonthPlaylistDateScope)))
+		},
+	},
+	{
+		version:     3,
+		description: "add playlist program names",
+		up: func(ctx context.Context, tx *sqlTx) error {
+			return addColumn(ctx, tx, "playlists", "program_name", "TEXT NOT NULL DEFAULT ''")
+		},
+	},
+	{
+		version:     4,
+		description: "add spotify track match methods",
+		up: func(ctx context.Context, tx *sqlTx) error {
+			_, err := tx.ExecContext(ctx, `CREATE TABLE IF NOT EXISTS match_methods (
+				id INTEGER PRIMARY KEY,
+				name TEXT NOT NULL
+			);`)
+			if err != nil {
+				return err
+			}
+
+			return addColumn(ctx, tx, "spotify_tracks", "match_method_id", "INTEGER NOT NULL DEFAULT 0")
+		},
+	},
+	{
+		version:     5,
+		description: "add spotify track match confidence",
+		up: addColumns("spotify_tracks", [][2]string{
+			{"confidence", "DOUBLE PRECISION NOT NULL DEFAULT 0"},
+			{"matched_artist", "TEXT NOT NULL DEFAULT ''"},
+			{"matched_track", "TEXT NOT NULL DEFAULT ''"},
+			{"matched_album", "TEXT NOT NULL DEFAULT ''"},
+			{"matched_at", "TEXT NOT NULL DEFAULT ''"},
+		}),
+	},
+	{
+		version:     6,
+		description: "add match overrides",
+		up: execStatements(
+			`CREATE TABLE IF NOT EXISTS match_overrides (
+				song_hash TEXT PRIMARY KEY,
+				track_id TEXT NOT NULL,              -- empty when the song should never be matched
+				uri TEXT NOT NULL,
+				previous_uri TEXT NOT NULL,          -- the uri the song was matched to before the override
+				created TEXT NOT NULL
+			);`,
+		),
+	},
+	{
+		version:     7,
+		description: "add spotify track search attempts",
+		up: addColumns("spotify_tracks", [][2]string{
+			{"attempt_count", "INTEGER NOT NULL DEFAULT 1"},
+			{"last_attempt", "TEXT NOT NULL DEFAULT ''"},
+			// empty when the song shouldn't be searched for again
+			{"next_attempt", "TEXT NOT NULL DEFAULT '1970-01-01T00:00:00Z'"},
+		}),
+	},
+	{
+		version:     8,
+		description: "add song hash versions",
+		up: func(ctx context.Context, tx *sqlTx) error {
+			// domain.SongHashVersion the song hash was calculated with
+			return addColumn(ctx, tx, "songs", "hash_version", "INTEGER NOT NULL DEFAULT 1")
+		},
+	},
+	{
+		version:     9,
+		description: "add song artists",
+		up: execStatements(
+			`CREATE TABLE IF NOT EXISTS song_artists (
+				song_id TEXT NOT NULL,
+				position INTEGER NOT NULL,      -- order the artist appears in the song's artist credit
+				artist TEXT NOT NULL,           -- normalized artist name
+				PRIMARY KEY (song_id, position)
+			);`,
+			`CREATE INDEX IF NOT EXISTS idx_song_artists_artist ON song_artists (artist);`,
+		),
+	},
+	{
+		version:     10,
+		description: "add match candidates",
+		up: execStatements(
+			`CREATE TABLE IF NOT EXISTS match_candidates (
+				song_id TEXT NOT NULL,
+				rank INTEGER NOT NULL,               -- 0 is the best match
+				track_id TEXT NOT NULL,
+				uri TEXT NOT NULL,
+				match_method_id INTEGER NOT NULL,
+				confidence DOUBLE PRECISION NOT NULL,
+				matched_artist TEXT NOT NULL,
+				matched_track TEXT NOT NULL,
+				matched_album TEXT NOT NULL,
+				created TEXT NOT NULL,
+				PRIMARY KEY (song_id, rank)
+			);`,
+		),
+	},
+	{
+		version:     11,
+		description: "add oauth tokens",
+		up: execStatements(
+			`CREATE TABLE IF NOT EXISTS oauth_tokens (
+				name TEXT PRIMARY KEY,          -- the client the token authenticates, e.g. spotify
+				access_token TEXT NOT NULL,
+				token_type TEXT NOT NULL,
+				refresh_token TEXT NOT NULL,
+				expiry TEXT NOT NULL,           -- empty when the access token doesn't expire
+				updated TEXT NOT NULL
+			);`,
+		),
+	},
+	{
+		version:     12,
+		description: "add provider tracks",
+		up: execStatements(
+			`CREATE TABLE IF NOT EXISTS tracks (
+				song_id TEXT NOT NULL,
+				playlist_type_id INTEGER NOT NULL,   -- the streaming provider the track is on
+				id TEXT NOT NULL,                    -- empty when no track was found
+				uri TEXT NOT NULL,
+				match_found INTEGER NOT NULL DEFAULT 0 CHECK(match_found IN (0,1)),
+				match_method_id INTEGER NOT NULL DEFAULT 0,
+				confidence DOUBLE PRECISION NOT NULL DEFAULT 0,
+				matched_artist TEXT NOT NULL DEFAULT '',
+				matched_track TEXT NOT NULL DEFAULT '',
+				matched_album TEXT NOT NULL DEFAULT '',
+				matched_at TEXT NOT NULL DEFAULT '',
+				attempt_count INTEGER NOT NULL DEFAULT 1,
+				last_attempt TEXT NOT NULL DEFAULT '',
+				next_attempt TEXT NOT NULL DEFAULT '', -- empty when the song shouldn't be searched for again
+				PRIMARY KEY (song_id, playlist_type_id)
+			);`,
+		),
+	},
+	{
+		version:     13,
+		description: "add track isrcs",
+		up: func(ctx context.Context, tx *sqlTx) error {
+			// lets providers without UPC search find a song by the ISRC of another provider's match
+			return addColumn(ctx, tx, "tracks", "isrc", "TEXT NOT NULL DEFAULT ''")
+		},
+	},
+	{
+		version:     14,
+		description: "move spotify tracks to provider tracks",
+		up: func(ctx context.Context, tx *sqlTx) error {
+			// spotify_tracks allowed several rows per song, only the first is kept. The WHERE
+			// clause stops SQLite parsing the conflict clause as a join constraint.
+			_, err := tx.ExecContext(ctx, fmt.Sprintf(
+				`INSERT INTO tracks (song_id, playlist_type_id, id, uri, match_found, match_method_id, confidence,
+					matched_artist, matched_track, matched_album, matched_at, attempt_count, last_attempt, next_attempt)
+				SELECT song_id, %d, COALESCE(id, ''), uri, match_found, match_method_id, confidence,
+					matched_artist, matched_track, matched_album, matched_at, attempt_count, last_attempt, next_attempt
+					FROM spotify_tracks
+					WHERE true
+				ON CONFLICT (song_id, playlist_type_id) DO NOTHING;`,
+				int(domain.SpotifyPlaylistType),
+			))
+			if err != nil {
+				return err
+			}
+
+			_, err = tx.ExecContext(ctx, `DROP TABLE spotify_tracks;`)
+			return err
+		},
+	},
+	{
+		version:     15,
+		description: "rehash songs with song hash version 2",
+		up:          rehashSongs,
+	},
+	{
+		version:     16,
+		description: "store artists of existing songs",
+		up:          initSongArtists,
+	},
+	{
+		version:     17,
+		description: "add rolling playlist windows",
+		up: func(ctx context.Context, tx *sqlTx) error {
+			err := addColumn(ctx, tx, "playlists", "rolling_days", "INTEGER NOT NULL DEFAULT 0")
+			if err != nil {
+				return err
+			}
+
+			// rolling playlists were keyed by the number of days in their window
+			_, err = tx.ExecContext(ctx,
+				`UPDATE playlists SET rolling_days = CAST(date AS INTEGER), date = ? WHERE date_scope_id = ?;`,
+				domain.RollingPlaylistDate, domain.RollingPlaylistDateScope,
+			)
+			if err != nil {
+				return err
+			}
+
+			// a source that had several windows now shares one key, only its most recently
+			// created playlist is kept
+			_, err = tx.ExecContext(ctx,
+				`DELETE FROM playlists
+					WHERE date_scope_id = ?
+					AND EXISTS (
+						SELECT 1 FROM playlists newer
+						WHERE newer.date_scope_id = playlists.date_scope_id
+							AND newer.playlist_type_id = playlists.playlist_type_id
+							AND newer.source_type_id = playlists.source_type_id
+							AND newer.program_name = playlists.program_name
+							AND (newer.created > playlists.created OR (newer.created = playlists.created AND newer.id > playlists.id))
+					);`,
+				domain.RollingPlaylistDateScope,
+			)
+			return err
+		},
+	},
+	{
+		version:     18,
+		description: "clear studio one plays without end times",
+		up: func(ctx context.Context, tx *sqlTx) error {
+			// studio one end times weren't parsed, so plays were stored with the zero time and
+			// syncing a stored day again would insert them a second time. Clearing them lets
+			// the day be downloaded again with its end times.
+			_, err := tx.ExecContext(ctx,
+				`DELETE FROM song_sources WHERE source_type_id = ? AND end_time = ?;`,
+				domain.StudioOneSourceType, timeToUTCString(time.Time{}),
+			)
+			return err
+		},
+	},
+	{
+		version:     19,
+		description: "add replaced tracks",
+		up: func(ctx context.Context, tx *sqlTx) error {
+			_, err := tx.ExecContext(ctx,
+				`CREATE TABLE IF NOT EXISTS replaced_tracks (
+					playlist_type_id INTEGER NOT NULL,
+					previous_uri TEXT NOT NULL,
+					song_id TEXT NOT NULL,               -- the song that was matched to previous_uri
+					uri TEXT NOT NULL,                   -- empty when the song is no longer matched
+					created TEXT NOT NULL,
+					PRIMARY KEY (playlist_type_id, previous_uri)
+				);`,
+			)
+			if err != nil {
+				return err
+			}
+
+			// only overrides recorded the uri they replaced before this table existed
+			_, err = tx.ExecContext(ctx,
+				`INSERT INTO replaced_tracks (playlist_type_id, previous_uri, song_id, uri, created)
+				SELECT ?, match_overrides.previous_uri, songs.id, match_overrides.uri, match_overrides.created
+					FROM match_overrides
+					JOIN songs ON songs.song_hash = match_overrides.song_hash
+					WHERE match_overrides.previous_uri != ''
+					  AND match_overrides.previous_uri != match_overrides.uri
+				ON CONFLICT (playlist_type_id, previous_uri) DO NOTHING;`,
+				domain.SpotifyPlaylistType,
+			)
+			return err
+		},
+	},
+}
+
+// MigrationStatus is a migration and when it was applied to a database.
+type MigrationStatus struct {
+	Version     int
+	Description string
+	// Applied is when the migration was applied, or zero when it's pending
+	Applied time.Time
+}
+
+// MigrationStatuses opens the database and returns the status of every migration
+// without applying any. A SQLite database file must already exist, so a mistyped path
+// isn't created as an empty database.
+func MigrationStatuses(ctx context.Context, dsn string) ([]MigrationStatus, error) {
+	path, memory := sqlitePath(dsn)
+	if dialect.FromDSN(dsn) == dialect.SQLite && !memory {
+		_, err := os.Stat(path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to open database: %w", err)
+		}
+	}
+
+	db, err := openDB(dsn)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open database: %w", err)
+	}
+	defer db.Close()
+
+	return migrationStatuses(ctx, db)
+}
+
+// MigrationStatuses returns the status of every migration.
+func (s *Storage) MigrationStatuses(ctx context.Context) ([]MigrationStatus, error) {
+	return migrationStatuses(ctx, s.db)
+}
+
+func migrationStatuses(ctx context.Context, db *sqlDB) ([]MigrationStatus, error) {
+	applied := make(map[int]time.Time)
+
+	exists, err := tableExists(ctx, db, "schema_migrations")
+	if err != nil {
+		return nil, err
+	}
+
+	if exists {
+		rows, err := db.QueryContext(ctx, `SELECT version, applied FROM schema_migrations;`)
+		if err != nil {
+			return nil, err
+		}
+		defer rows.Close()
+
+		for rows.Next() {
+			var (
+				version    int
+				appliedStr string
+			)
+			err = rows.Scan(&version, &appliedStr)
+			if err != nil {
+				return nil, err
+			}
+
+			applied[version], err = utcStringToTime(appliedStr)
+			if err != nil {
+				return nil, err
+			}
+		}
+		if err = rows.Err(); err != nil {
+			return nil, err
+		}
+	}
+
+	statuses := make([]MigrationStatus, 0, len(migrations))
+	for _, m := range migrations {
+		statuses = append(statuses, MigrationStatus{
+			Version:     m.version,
+			Description: m.description,
+			Applied:     applied[m.version],
+		})
+	}
+
+	return statuses, nil
+}
+
+// migrate applies the migrations that haven't been applied to the database, in version
+// order and each in its own transaction.
+func migrate(ctx context.Context, db *sqlDB) error {
+	_, err := db.ExecContext(ctx, schemaMigrationSchema)
+	if err != nil {
+		return fmt.Errorf("failed to create schema_migrations: %w", err)
+	}
+
+	for _, m := range migrations {
+		err = applyMigration(ctx, db, m)
+		if err != nil {
+			return fmt.Errorf("migration %d (%s) failed: %w", m.version, m.description, err)
+		}
+	}
+
+	return nil
+}
+
+func applyMigration(ctx context.Context, db *sqlDB, m migration) error {
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = tx.Rollback()
+	}()
+
+	if tx.dialect == dialect.Postgres {
+		// runners sharing a database wait for each other's migrations, a SQLite write
+		// transaction already holds the database lock
+		_, err = tx.ExecContext(ctx, `SELECT pg_advisory_xact_lock(?);`, migrationLockID)
+		if err != nil {
+			return err
+		}
+	}
+
+	// checked in the transaction in case another process applied the migration
+	var applied bool
+	err = tx.QueryRowContext(ctx,
+		`SELECT EXISTS (SELECT 1 FROM schema_migrations WHERE version = ?);`,
+		m.version,
+	).Scan(&applied)
+	if err != nil {
+		return err
+	}
+	if applied {
+		return nil
+	}
+
+	err = m.up(ctx, tx)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.ExecContext(ctx,
+		`INSERT INTO schema_migrations (version, description, applied) VALUES (?, ?, ?);`,
+		m.version, m.description, timeToUTCString(time.Now()),
+	)
+	if err != nil {
+		return err
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return err
+	}
+
+	slog.Debug("applied migration", slog.Int("version", m.version), slog.String("description", m.description))
+
+	return nil
+}
+
+func execStatements(statements ...string) func(ctx context.Context, tx *sqlTx) error {
+	return func(ctx context.Context, tx *sqlTx) error {
+		for _, statement := range statements {
+			_, err := tx.ExecContext(ctx, statement)
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+}
+
+// addColumns adds the columns, each a name and definition, that the table doesn't have.
+func addColumns(table string, columns [][2]string) func(ctx context.Context, tx *sqlTx) error {
+	return func(ctx context.Context, tx *sqlTx) error {
+		for _, column := range columns {
+			err := addColumn(ctx, tx, table, column[0], column[1])
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+}
+
+// addColumn adds the column to the table unless the table already has it.
+func addColumn(ctx context.Context, tx *sqlTx, table, column, definition string) error {
+	query := `SELECT EXISTS (SELECT 1 FROM pragma_table_info(?) WHERE name = ?);`
+	if tx.dialect == dialect.Postgres {
+		query = `SELECT EXISTS (
+			SELECT 1 FROM information_schema.columns
+			WHERE table_schema = current_schema() AND table_name = ? AND column_name = ?
+		);`
+	}
+
+	var exists bool
+	err := tx.QueryRowContext(ctx, query, table, column).Scan(&exists)
+	if err != nil {
+		return err
+	}
+	if exists {
+		return nil
+	}
+
+	_, err = tx.ExecContext(ctx, fmt.Sprintf(`ALTER TABLE %s ADD COLUMN %s %s;`, table, column, definition))
+	return err
+}
+
+func tableExists(ctx context.Context, db *sqlDB, table string) (bool, error) {
+	query := `SELECT EXISTS (SELECT 1 FROM sqlite_master WHERE type = 'table' AND name = ?);`
+	if db.dialect == dialect.Postgres {
+		query = `SELECT EXISTS (
+			SELECT 1 FROM information_schema.tables
+			WHERE table_schema = current_schema() AND table_name = ?
+		);`
+	}
+
+	var exists bool
+	err := db.QueryRowContext(ctx, query, table).Scan(&exists)
+	return exists, err
+}
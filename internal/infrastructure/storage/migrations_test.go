@@ -0,0 +1,305 @@
+package storage
+
+import (
+	"database/sql"
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/jbenzshawel/playlist-generator/internal/domain"
+)
+
+// baselineSchema is the schema databases were created with before migrations were added.
+var baselineSchema = []string{
+	`CREATE TABLE IF NOT EXISTS source_types (
+    id INTEGER PRIMARY KEY,
+    name TEXT NOT NULL
+);`,
+	`CREATE TABLE IF NOT EXISTS playlist_types (
+    id INTEGER PRIMARY KEY,
+    name TEXT NOT NULL
+);`,
+	`CREATE TABLE IF NOT EXISTS songs (
+    id TEXT PRIMARY KEY,
+    artist TEXT NOT NULL,
+    track TEXT NOT NULL,
+    album TEXT NOT NULL,
+    upc TEXT,
+    song_hash TEXT NOT NULL UNIQUE,
+    created TEXT NOT NULL
+);`,
+	`CREATE TABLE IF NOT EXISTS song_sources (
+    id TEXT PRIMARY KEY,
+    source_id TEXT NOT NULL,
+    song_hash TEXT NOT NULL,
+    source_type_id INT NOT NULL,
+    program_name TEXT,
+    date_played TEXT NOT NULL,
+    end_time TEXT NOT NULL,
+    created TEXT NOT NULL,
+   UNIQUE(source_id, source_type_id, end_time) ON CONFLICT IGNORE
+);`,
+	`CREATE TABLE IF NOT EXISTS spotify_tracks (
+    id TEXT,
+    uri TEXT NOT NULL,
+    song_id TEXT NOT NULL,
+    match_found INTEGER NOT NULL DEFAULT 0 CHECK(match_found IN (0,1)),
+    PRIMARY KEY (id, song_id)
+);`,
+	`CREATE TABLE IF NOT EXISTS playlists (
+    id TEXT PRIMARY KEY,
+    uri TEXT NOT NULL,
+    name TEXT NOT NULL,
+    date TEXT NOT NULL,
+    source_type_id INT NOT NULL,
+  	playlist_type_id INT NOT NULL,
+    last_day_synced TEXT NOT NULL,        
+    created TEXT NOT NULL
+);`,
+	`INSERT INTO songs (id, artist, track, album, upc, song_hash, created)
+		VALUES ('3c1e2a56-7d0b-4b0e-9a3e-1f5c8d2b6a41', 'Bon Iver, St. Vincent', 'Sleeping', 'Sleeping', '', 'legacyHash', '2025-01-01T00:00:00Z');`,
+	`INSERT INTO spotify_tracks (id, uri, song_id, match_found) VALUES ('track1', 'spotify:track:track1', '3c1e2a56-7d0b-4b0e-9a3e-1f5c8d2b6a41', 1);`,
+	`INSERT INTO playlists (id, uri, name, date, source_type_id, playlist_type_id, last_day_synced, created)
+		VALUES ('playlist1', 'spotify:playlist:playlist1', 'Studio One 2025-01', '2025-01', 1, 1, '2025-01-31', '2025-01-01T00:00:00Z');`,
+}
+
+func TestMigrate_BaselineDatabase(t *testing.T) {
+	dsn := "file:" + filepath.Join(t.TempDir(), "app.db")
+
+	db, err := sql.Open("sqlite", dsn)
+	require.NoError(t, err)
+	for _, statement := range baselineSchema {
+		_, err = db.ExecContext(t.Context(), statement)
+		require.NoError(t, err)
+	}
+	require.NoError(t, db.Close())
+
+	t.Run("migrations pending", func(t *testing.T) {
+		statuses, err := MigrationStatuses(t.Context(), dsn)
+		require.NoError(t, err)
+
+		require.Len(t, statuses, len(migrations))
+		for _, status := range statuses {
+			assert.True(t, status.Applied.IsZero(), "migration %d", status.Version)
+		}
+	})
+
+	t.Run("missing database", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "missing.db")
+
+		_, err := MigrationStatuses(t.Context(), SQLiteDSN(path))
+		require.ErrorIs(t, err, os.ErrNotExist)
+		assert.NoFileExists(t, path)
+	})
+
+	storage, err := Initialize(t.Context(), dsn)
+	require.NoError(t, err)
+	t.Cleanup(storage.Close)
+
+	t.Run("migrations applied", func(t *testing.T) {
+		statuses, err := storage.MigrationStatuses(t.Context())
+		require.NoError(t, err)
+
+		require.Len(t, statuses, len(migrations))
+		for idx, status := range statuses {
+			assert.Equal(t, migrations[idx].version, status.Version)
+			assert.False(t, status.Applied.IsZero(), "migration %d", status.Version)
+		}
+	})
+
+	t.Run("schema matches new database", func(t *testing.T) {
+		expected, err := Initialize(t.Context(), "file:"+filepath.Join(t.TempDir(), "new.db"))
+		require.NoError(t, err)
+		defer expected.Close()
+
+		expectedTables := listTables(t, expected.db)
+		actualTables := listTables(t, storage.db)
+		assert.ElementsMatch(t, expectedTables, actualTables)
+
+		for _, table := range expectedTables {
+			assert.ElementsMatch(t, listColumns(t, expected.db, table), listColumns(t, storage.db, table), table)
+		}
+	})
+
+	t.Run("playlists are monthly", func(t *testing.T) {
+		assert.Equal(t, map[string]string{
+			"playlist1": "2",
+		}, queryStringMap(t, storage.db, `SELECT id, date_scope_id || program_name FROM playlists;`))
+	})
+
+	t.Run("spotify tracks moved", func(t *testing.T) {
+		tracks := getAllTracks(t, storage.db, domain.SpotifyPlaylistType)
+		require.Len(t, tracks, 1)
+		assert.Equal(t, "track1", tracks[0].TrackID())
+		assert.Equal(t, "spotify:track:track1", tracks[0].URI())
+		assert.True(t, tracks[0].MatchFound())
+	})
+
+	t.Run("songs rehashed", func(t *testing.T) {
+		hash, err := domain.NewSongHash("Bon Iver, St. Vincent", "Sleeping", "Sleeping")
+		require.NoError(t, err)
+
+		assert.Equal(t, map[string]string{
+			"3c1e2a56-7d0b-4b0e-9a3e-1f5c8d2b6a41": hash,
+		}, queryStringMap(t, storage.db, `SELECT id, song_hash FROM songs;`))
+		assert.Equal(t, map[string]string{
+			"0": "bon iver",
+			"1": "st vincent",
+		}, queryStringMap(t, storage.db, `SELECT position, artist FROM song_artists;`))
+	})
+}
+
+func TestMigrate_UnversionedDatabase(t *testing.T) {
+	dsn := "file:" + filepath.Join(t.TempDir(), "app.db")
+
+	// databases created by the last version without migrations have the whole schema
+	// but no schema_migrations table
+	storage, err := Initialize(t.Context(), dsn)
+	require.NoError(t, err)
+	_, err = storage.db.ExecContext(t.Context(), `DROP TABLE schema_migrations;`)
+	require.NoError(t, err)
+	storage.Close()
+
+	storage, err = Initialize(t.Context(), dsn)
+	require.NoError(t, err)
+	t.Cleanup(storage.Close)
+
+	statuses, err := storage.MigrationStatuses(t.Context())
+	require.NoError(t, err)
+	for _, status := range statuses {
+		assert.False(t, status.Applied.IsZero(), "migration %d", status.Version)
+	}
+}
+
+func TestMigrate_RollingPlaylistWindows(t *testing.T) {
+	dsn := "file:" + filepath.Join(t.TempDir(), "app.db")
+
+	storage, err := Initialize(t.Context(), dsn)
+	require.NoError(t, err)
+
+	// rolling playlists were keyed by the number of days in their window before migration 17
+	_, err = storage.db.ExecContext(t.Context(),
+		`INSERT INTO playlists (id, uri, name, date, date_scope_id, source_type_id, program_name, playlist_type_id, last_day_synced, created)
+			VALUES ('playlist1', 'spotify:playlist:playlist1', 'Studio One Last 30 Days', '30', ?1, 1, '', 1, '', '2025-01-01T00:00:00Z'),
+				('playlist2', 'spotify:playlist:playlist2', 'Studio One Last 7 Days', '7', ?1, 1, '', 1, '', '2025-01-02T00:00:00Z'),
+				('playlist3', 'spotify:playlist:playlist3', 'Studio One Last 14 Days', '14', ?1, 1, '', 1, '', '2025-01-02T00:00:00Z'),
+				('playlist4', 'spotify:playlist:playlist4', 'Morning Show Last 30 Days', '30', ?1, 1, 'Morning Show', 1, '', '2025-01-01T00:00:00Z'),
+				('playlist5', 'tidal:playlist5', 'Studio One Last 30 Days', '30', ?1, 1, '', 3, '', '2025-01-01T00:00:00Z');`,
+		domain.RollingPlaylistDateScope,
+	)
+	require.NoError(t, err)
+	_, err = storage.db.ExecContext(t.Context(), `DELETE FROM schema_migrations WHERE version = 17;`)
+	require.NoError(t, err)
+	storage.Close()
+
+	storage, err = Initialize(t.Context(), dsn)
+	require.NoError(t, err)
+	t.Cleanup(storage.Close)
+
+	assert.Equal(t, map[string]string{
+		// the newest window of each source is kept, the id breaks ties
+		"playlist3": domain.RollingPlaylistDate + " 14",
+		"playlist4": domain.RollingPlaylistDate + " 30",
+		"playlist5": domain.RollingPlaylistDate + " 30",
+	}, queryStringMap(t, storage.db, `SELECT id, date || ' ' || rolling_days FROM playlists;`))
+}
+
+func TestMigrate_StudioOneEndTimes(t *testing.T) {
+	dsn := "file:" + filepath.Join(t.TempDir(), "app.db")
+
+	storage, err := Initialize(t.Context(), dsn)
+	require.NoError(t, err)
+
+	// studio one plays were stored with the zero time before their end times were parsed
+	for _, source := range [][]any{
+		{"source1", "sourceID1", domain.StudioOneSourceType, "0001-01-01T00:00:00Z"},
+		{"source2", "sourceID2", domain.StudioOneSourceType, "2025-01-01T14:05:00Z"},
+		{"source3", "sourceID3", domain.TheCurrentSourceType, "0001-01-01T00:00:00Z"},
+	} {
+		_, err = storage.db.ExecContext(t.Context(),
+			`INSERT INTO song_sources (id, source_id, song_hash, source_type_id, program_name, date_played, end_time, created)
+				VALUES (?, ?, 'songHash1', ?, '', '2025-01-01', ?, '2025-01-01T00:00:00Z');`,
+			source...,
+		)
+		require.NoError(t, err)
+	}
+	_, err = storage.db.ExecContext(t.Context(), `DELETE FROM schema_migrations WHERE version = 18;`)
+	require.NoError(t, err)
+	storage.Close()
+
+	storage, err = Initialize(t.Context(), dsn)
+	require.NoError(t, err)
+	t.Cleanup(storage.Close)
+
+	assert.Equal(t, map[string]string{
+		"source2": "2025-01-01T14:05:00Z",
+		"source3": "0001-01-01T00:00:00Z",
+	}, queryStringMap(t, storage.db, `SELECT id, end_time FROM song_sources;`))
+}
+
+func TestMigrate_ReplacedTracks(t *testing.T) {
+	dsn := "file:" + filepath.Join(t.TempDir(), "app.db")
+
+	storage, err := Initialize(t.Context(), dsn)
+	require.NoError(t, err)
+
+	_, err = storage.db.ExecContext(t.Context(),
+		`INSERT INTO songs (id, artist, track, album, upc, song_hash, created)
+			VALUES ('songID1', 'artist1', 'track1', 'album1', '', 'songHash1', '2025-01-01T00:00:00Z'),
+				('songID2', 'artist2', 'track2', 'album2', '', 'songHash2', '2025-01-01T00:00:00Z');`,
+	)
+	require.NoError(t, err)
+
+	// overrides recorded the uri they replaced, the second song wasn't matched before its override
+	_, err = storage.db.ExecContext(t.Context(),
+		`INSERT INTO match_overrides (song_hash, track_id, uri, previous_uri, created)
+			VALUES ('songHash1', 'trackID1', 'spotify:track:trackID1', 'spotify:track:old', '2025-01-01T00:00:00Z'),
+				('songHash2', 'trackID2', 'spotify:track:trackID2', '', '2025-01-01T00:00:00Z');`,
+	)
+	require.NoError(t, err)
+	_, err = storage.db.ExecContext(t.Context(), `DELETE FROM schema_migrations WHERE version = 19;`)
+	require.NoError(t, err)
+	storage.Close()
+
+	storage, err = Initialize(t.Context(), dsn)
+	require.NoError(t, err)
+	t.Cleanup(storage.Close)
+
+	assert.Equal(t, map[string]string{
+		"spotify:track:old": "spotify:track:trackID1",
+	}, queryStringMap(t, storage.db, `SELECT previous_uri, uri FROM replaced_tracks WHERE song_id = 'songID1';`))
+	assert.Empty(t, queryStringMap(t, storage.db, `SELECT previous_uri, uri FROM replaced_tracks WHERE song_id = 'songID2';`))
+}
+
+func TestMigrations_Ordered(t *testing.T) {
+	versions := make([]int, 0, len(migrations))
+	for idx, m := range migrations {
+		assert.Equal(t, idx+1, m.version)
+		assert.NotEmpty(t, m.description)
+		versions = append(versions, m.version)
+	}
+	assert.True(t, slices.IsSorted(versions))
+}
+
+func listColumns(t *testing.T, db *sqlDB, table string) []string {
+	rows, err := db.QueryContext(t.Context(), `SELECT name FROM pragma_table_info(?);`, table)
+	require.NoError(t, err)
+
+	defer func() {
+		require.NoError(t, rows.Close())
+	}()
+
+	var columns []string
+	for rows.Next() {
+		var name string
+		require.NoError(t, rows.Scan(&name))
+		columns = append(columns, name)
+	}
+	require.NoError(t, rows.Err())
+
+	return columns
+}
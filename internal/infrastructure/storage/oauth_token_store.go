@@ -0,0 +1,65 @@
+package storage
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"golang.org/x/oauth2"
+)
+
+// TokenStore persists the OAuth token of a client so it can be reused and refreshed
+// on later runs without logging in again.
+type TokenStore struct {
+	db   *sqlDB
+	name string
+}
+
+// NewTokenStore returns the token store for the client with name.
+func NewTokenStore(s *Storage, name string) *TokenStore {
+	return &TokenStore{db: s.db, name: name}
+}
+
+// Token returns the stored token, or nil when no token is stored.
+func (t *TokenStore) Token(ctx context.Context) (*oauth2.Token, error) {
+	var (
+		tok       oauth2.Token
+		expiryStr string
+	)
+
+	err := t.db.QueryRowContext(ctx,
+		`SELECT access_token, token_type, refresh_token, expiry FROM oauth_tokens WHERE name = ?;`,
+		t.name,
+	).Scan(&tok.AccessToken, &tok.TokenType, &tok.RefreshToken, &expiryStr)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	tok.Expiry, err = optionalUTCStringToTime(expiryStr)
+	if err != nil {
+		return nil, err
+	}
+
+	return &tok, nil
+}
+
+// SaveToken replaces the stored token. The stored refresh token is kept when the
+// token doesn't include one, since refresh responses may leave it out.
+func (t *TokenStore) SaveToken(ctx context.Context, tok *oauth2.Token) error {
+	_, err := t.db.ExecContext(ctx,
+		`INSERT INTO oauth_tokens (name, access_token, token_type, refresh_token, expiry, updated)
+			VALUES (?, ?, ?, ?, ?, ?)
+			ON CONFLICT (name) DO UPDATE SET
+				access_token = excluded.access_token,
+				token_type = excluded.token_type,
+				refresh_token = CASE WHEN excluded.refresh_token = '' THEN oauth_tokens.refresh_token ELSE excluded.refresh_token END,
+				expiry = excluded.expiry,
+				updated = excluded.updated;`,
+		t.name, tok.AccessToken, tok.TokenType, tok.RefreshToken, optionalTimeToUTCString(tok.Expiry), timeToUTCString(time.Now()),
+	)
+	return err
+}
@@ -0,0 +1,60 @@
+package storage
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/oauth2"
+)
+
+func TestTokenStore(t *testing.T) {
+	storage := InitTestStorage(t)
+
+	store := NewTokenStore(storage, "spotify")
+	otherStore := NewTokenStore(storage, "other")
+
+	t.Run("no token stored", func(t *testing.T) {
+		tok, err := store.Token(t.Context())
+		require.NoError(t, err)
+		assert.Nil(t, tok)
+	})
+
+	expected := &oauth2.Token{
+		AccessToken:  "access1",
+		TokenType:    "Bearer",
+		RefreshToken: "refresh1",
+		Expiry:       formatDateTime(t, time.Now().Add(time.Hour)),
+	}
+
+	t.Run("save token", func(t *testing.T) {
+		require.NoError(t, store.SaveToken(t.Context(), expected))
+
+		tok, err := store.Token(t.Context())
+		require.NoError(t, err)
+		assert.Equal(t, expected, tok)
+
+		tok, err = otherStore.Token(t.Context())
+		require.NoError(t, err)
+		assert.Nil(t, tok)
+	})
+
+	t.Run("refreshed token keeps refresh token", func(t *testing.T) {
+		refreshed := &oauth2.Token{
+			AccessToken: "access2",
+			TokenType:   "Bearer",
+			Expiry:      formatDateTime(t, time.Now().Add(2*time.Hour)),
+		}
+		require.NoError(t, store.SaveToken(t.Context(), refreshed))
+
+		tok, err := store.Token(t.Context())
+		require.NoError(t, err)
+		assert.Equal(t, &oauth2.Token{
+			AccessToken:  "access2",
+			TokenType:    "Bearer",
+			RefreshToken: "refresh1",
+			Expiry:       refreshed.Expiry,
+		}, tok)
+	})
+}
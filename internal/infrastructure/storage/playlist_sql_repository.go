@@ -10,27 +10,16 @@ import (
 
 var _ domain.PlaylistRepository = (*playlistSqlRepository)(nil)
 
-var playlistsSchema string = `CREATE TABLE IF NOT EXISTS playlists (
-    id TEXT PRIMARY KEY,
-    uri TEXT NOT NULL,
-    name TEXT NOT NULL,
-    date TEXT NOT NULL,
-    source_type_id INT NOT NULL,
-  	playlist_type_id INT NOT NULL,
-    last_day_synced TEXT NOT NULL,        
-    created TEXT NOT NULL           -- store timestamps as ISO8601 strings (UTC)
-);`
-
 type playlistSqlRepository struct {
-	tx *sql.Tx
+	tx *sqlTx
 }
 
-func (r *playlistSqlRepository) SetTransaction(tx *sql.Tx) {
+func (r *playlistSqlRepository) SetTransaction(tx *sqlTx) {
 	r.tx = tx
 }
 
 func (r *playlistSqlRepository) GetPlaylistByID(ctx context.Context, id string) (domain.Playlist, error) {
-	row := r.tx.QueryRowContext(ctx, `SELECT id, uri, name, date, source_type_id, playlist_type_id, last_day_synced, created
+	row := r.tx.QueryRowContext(ctx, `SELECT id, uri, name, date, date_scope_id, source_type_id, program_name, playlist_type_id, last_day_synced, rolling_days, created
 		FROM playlists WHERE id = ?`,
 		id,
 	)
@@ -42,10 +31,11 @@ func (r *playlistSqlRepository) GetPlaylistByID(ctx context.Context, id string)
 	return p, nil
 }
 
-func (r *playlistSqlRepository) GetPlaylistByDate(ctx context.Context, playlistType domain.PlaylistType, date string) (domain.Playlist, error) {
-	row := r.tx.QueryRowContext(ctx, `SELECT id, uri, name, date, source_type_id, playlist_type_id, last_day_synced, created
-		FROM playlists WHERE playlist_type_id = ? AND date = ?`,
-		playlistType, date,
+func (r *playlistSqlRepository) GetPlaylistByDate(ctx context.Context, playlistType domain.PlaylistType, sourceType domain.SourceType, programName string, dateScope domain.PlaylistDateScope, date string) (domain.Playlist, error) {
+	row := r.tx.QueryRowContext(ctx, `SELECT id, uri, name, date, date_scope_id, source_type_id, program_name, playlist_type_id, last_day_synced, rolling_days, created
+		FROM playlists WHERE playlist_type_id = ? AND source_type_id = ? AND program_name = ? AND date_scope_id = ? AND date = ?
+		ORDER BY created DESC`,
+		playlistType, sourceType, programName, dateScope, date,
 	)
 
 	p, err := scanPlaylistRow(row)
@@ -68,12 +58,15 @@ func scanPlaylistRow(row rowScanner) (domain.Playlist, error) {
 		uri           string
 		name          string
 		date          string
+		dateScope     domain.PlaylistDateScope
 		playlistType  domain.PlaylistType
 		sourceType    domain.SourceType
+		programName   string
 		lastDaySynced string
+		rollingDays   int
 		createdStr    string
 	)
-	err := row.Scan(&id, &uri, &name, &date, &playlistType, &sourceType, &lastDaySynced, &createdStr)
+	err := row.Scan(&id, &uri, &name, &date, &dateScope, &sourceType, &programName, &playlistType, &lastDaySynced, &rollingDays, &createdStr)
 
 	if err != nil {
 		return domain.Playlist{}, err
@@ -84,15 +77,15 @@ func scanPlaylistRow(row rowScanner) (domain.Playlist, error) {
 		return domain.Playlist{}, err
 	}
 
-	return domain.NewPlaylistFromDB(id, uri, date, name, playlistType, sourceType, lastDaySynced, created), nil
+	return domain.NewPlaylistFromDB(id, uri, date, name, dateScope, playlistType, sourceType, programName, lastDaySynced, rollingDays, created), nil
 }
 
 func (r *playlistSqlRepository) Insert(ctx context.Context, playlist domain.Playlist) error {
 	_, err := r.tx.ExecContext(
 		ctx,
-		`INSERT INTO playlists (id, uri, name, date, source_type_id, playlist_type_id, last_day_synced, created)
-			VALUES (?,?, ?, ?,?, ?, ?, ?);`,
-		playlist.ID(), playlist.URI(), playlist.Name(), playlist.Date(), playlist.SourceType(), playlist.PlaylistType(), "", timeToUTCString(playlist.Created()),
+		`INSERT INTO playlists (id, uri, name, date, date_scope_id, source_type_id, program_name, playlist_type_id, last_day_synced, rolling_days, created)
+			VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?);`,
+		playlist.ID(), playlist.URI(), playlist.Name(), playlist.Date(), playlist.DateScope(), playlist.SourceType(), playlist.ProgramName(), playlist.PlaylistType(), "", rollingDays(playlist), timeToUTCString(playlist.Created()),
 	)
 	if err != nil {
 		return err
@@ -113,3 +106,25 @@ func (r *playlistSqlRepository) SetLastDaySynced(ctx context.Context, id, lastDa
 
 	return nil
 }
+
+func (r *playlistSqlRepository) SetRollingWindow(ctx context.Context, id, name string, days int) error {
+	_, err := r.tx.ExecContext(
+		ctx,
+		`UPDATE playlists SET name = ?, rolling_days = ? WHERE id = ?;`,
+		name, days, id,
+	)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// rollingDays is the number of days in a rolling playlist's window, or 0 for other playlists.
+func rollingDays(playlist domain.Playlist) int {
+	days, err := playlist.RollingDays()
+	if err != nil {
+		return 0
+	}
+	return days
+}
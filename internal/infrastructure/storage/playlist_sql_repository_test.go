@@ -15,16 +15,20 @@ func TestPlaylistSqlRepository(t *testing.T) {
 		id1 = "id1"
 		id2 = "id2"
 		id3 = "id3"
+		id4 = "id4"
 
 		date1 = "2025-01"
 		date2 = "2025-01"
 		date3 = "2025-02"
+
+		programName = "World Cafe"
 	)
 
 	expectedPlaylists := []domain.Playlist{
-		domain.NewPlaylistFromDB(id1, "uri1", date1, "name1", domain.SpotifyPlaylistType, domain.StudioOneSourceType, "", formatDateTime(t, time.Now())),
-		domain.NewPlaylistFromDB(id2, "uri2", date2, "name2", domain.SpotifyPlaylistType, domain.StudioOneSourceType, "", formatDateTime(t, time.Now().AddDate(0, 0, -1))),
-		domain.NewPlaylistFromDB(id3, "uri3", date3, "name3", domain.SpotifyPlaylistType, domain.StudioOneSourceType, "", formatDateTime(t, time.Now())),
+		domain.NewPlaylistFromDB(id1, "uri1", date1, "name1", domain.MonthPlaylistDateScope, domain.SpotifyPlaylistType, domain.StudioOneSourceType, "", "", 0, formatDateTime(t, time.Now())),
+		domain.NewPlaylistFromDB(id2, "uri2", date2, "name2", domain.MonthPlaylistDateScope, domain.SpotifyPlaylistType, domain.StudioOneSourceType, "", "", 0, formatDateTime(t, time.Now().AddDate(0, 0, -1))),
+		domain.NewPlaylistFromDB(id3, "uri3", date3, "name3", domain.MonthPlaylistDateScope, domain.SpotifyPlaylistType, domain.StudioOneSourceType, "", "", 0, formatDateTime(t, time.Now())),
+		domain.NewPlaylistFromDB(id4, "uri4", date3, "name4", domain.MonthPlaylistDateScope, domain.SpotifyPlaylistType, domain.TheCurrentSourceType, programName, "", 0, formatDateTime(t, time.Now())),
 	}
 
 	storage := InitTestStorage(t)
@@ -61,13 +65,25 @@ func TestPlaylistSqlRepository(t *testing.T) {
 
 	t.Run("get playlist by date", func(t *testing.T) {
 		// Playlist 1 and 2 have same date so newest should be returned (playlist 1)
-		playlist1, err := r.GetPlaylistByDate(t.Context(), domain.SpotifyPlaylistType, expectedPlaylists[0].Date())
+		playlist1, err := r.GetPlaylistByDate(t.Context(), domain.SpotifyPlaylistType, domain.StudioOneSourceType, "", domain.MonthPlaylistDateScope, expectedPlaylists[0].Date())
 		require.NoError(t, err)
 		assert.Equal(t, expectedPlaylists[0], playlist1)
 
-		playlist3, err := r.GetPlaylistByDate(t.Context(), domain.SpotifyPlaylistType, expectedPlaylists[2].Date())
+		playlist3, err := r.GetPlaylistByDate(t.Context(), domain.SpotifyPlaylistType, domain.StudioOneSourceType, "", domain.MonthPlaylistDateScope, expectedPlaylists[2].Date())
 		require.NoError(t, err)
 		assert.Equal(t, expectedPlaylists[2], playlist3)
+
+		otherSource, err := r.GetPlaylistByDate(t.Context(), domain.SpotifyPlaylistType, domain.TheCurrentSourceType, "", domain.MonthPlaylistDateScope, expectedPlaylists[2].Date())
+		require.NoError(t, err)
+		assert.True(t, otherSource.IsZero())
+
+		otherScope, err := r.GetPlaylistByDate(t.Context(), domain.SpotifyPlaylistType, domain.StudioOneSourceType, "", domain.YearPlaylistDateScope, expectedPlaylists[2].Date())
+		require.NoError(t, err)
+		assert.True(t, otherScope.IsZero())
+
+		programPlaylist, err := r.GetPlaylistByDate(t.Context(), domain.SpotifyPlaylistType, domain.TheCurrentSourceType, programName, domain.MonthPlaylistDateScope, expectedPlaylists[3].Date())
+		require.NoError(t, err)
+		assert.Equal(t, expectedPlaylists[3], programPlaylist)
 	})
 
 	t.Run("set last synced date", func(t *testing.T) {
@@ -81,6 +97,19 @@ func TestPlaylistSqlRepository(t *testing.T) {
 		expectedPlaylists[0] = playlist
 	})
 
+	t.Run("set rolling window", func(t *testing.T) {
+		rolling := domain.NewPlaylistFromDB("id5", "uri5", domain.RollingPlaylistDate, "Studio One Last 30 Days", domain.RollingPlaylistDateScope, domain.SpotifyPlaylistType, domain.StudioOneSourceType, "", "", 30, formatDateTime(t, time.Now()))
+		require.NoError(t, r.Insert(t.Context(), rolling))
+
+		require.NoError(t, r.SetRollingWindow(t.Context(), "id5", "Studio One Last 7 Days", 7))
+
+		playlist, err := r.GetPlaylistByDate(t.Context(), domain.SpotifyPlaylistType, domain.StudioOneSourceType, "", domain.RollingPlaylistDateScope, domain.RollingPlaylistDate)
+		require.NoError(t, err)
+		assert.Equal(t, rolling.WithRollingWindow("Studio One Last 7 Days", 7), playlist)
+
+		expectedPlaylists = append(expectedPlaylists, playlist)
+	})
+
 	t.Run("commit", func(t *testing.T) {
 		require.NoError(t, tx.Commit())
 
@@ -93,7 +122,8 @@ func TestPlaylistSqlRepository(t *testing.T) {
 func getAllPlaylists(t *testing.T, db queryContexter) []domain.Playlist {
 	rows, err := db.QueryContext(
 		t.Context(),
-		`SELECT * FROM playlists;`,
+		`SELECT id, uri, name, date, date_scope_id, source_type_id, program_name, playlist_type_id, last_day_synced, rolling_days, created
+			FROM playlists;`,
 	)
 	require.NoError(t, err)
 
@@ -1,23 +1,18 @@
 package storage
 
 import (
-	"database/sql"
+	"context"
 
 	"github.com/jbenzshawel/playlist-generator/internal/domain"
 )
 
-var playlistTypeSchema string = `CREATE TABLE IF NOT EXISTS playlist_types (
-    id INTEGER PRIMARY KEY,
-    name TEXT NOT NULL
-);`
-
-func initPlaylistTypes(db *sql.DB) error {
+func initPlaylistTypes(ctx context.Context, db *sqlDB) error {
 	for _, pt := range domain.AllPlaylistTypes() {
-		query := `INSERT INTO playlist_types (ID, Name)
-		SELECT ?, ?
-		WHERE NOT EXISTS (SELECT 1 FROM playlist_types WHERE id = ?);`
+		query := `INSERT INTO playlist_types (id, name)
+		VALUES (?, ?)
+		ON CONFLICT (id) DO NOTHING;`
 
-		_, err := db.Exec(query, int(pt), pt.String(), int(pt))
+		_, err := db.ExecContext(ctx, query, int(pt), pt.String())
 		if err != nil {
 			return err
 		}
@@ -2,7 +2,6 @@ package storage
 
 import (
 	"context"
-	"database/sql"
 
 	"github.com/jbenzshawel/playlist-generator/internal/domain"
 )
@@ -10,13 +9,15 @@ import (
 var _ domain.Repository = (*repository)(nil)
 
 type repository struct {
-	db *sql.DB
-	tx *sql.Tx
+	db *sqlDB
+	tx *sqlTx
 
-	song         *songSqlRepository
-	songSource   *songSourceSqlRepository
-	spotifyTrack *spotifyTrackSqlRepository
-	playlist     *playlistSqlRepository
+	song           *songSqlRepository
+	songSource     *songSourceSqlRepository
+	tracks         map[domain.PlaylistType]*trackSqlRepository
+	matchOverride  *matchOverrideSqlRepository
+	matchCandidate *matchCandidateSqlRepository
+	playlist       *playlistSqlRepository
 }
 
 func (r *repository) Song() domain.SongRepository {
@@ -27,8 +28,16 @@ func (r *repository) SongSource() domain.SongSourceRepository {
 	return r.songSource
 }
 
-func (r *repository) SpotifyTrack() domain.SpotifyTrackRepository {
-	return r.spotifyTrack
+func (r *repository) Track(playlistType domain.PlaylistType) domain.TrackRepository {
+	return r.tracks[playlistType]
+}
+
+func (r *repository) MatchOverride() domain.MatchOverrideRepository {
+	return r.matchOverride
+}
+
+func (r *repository) MatchCandidate() domain.MatchCandidateRepository {
+	return r.matchCandidate
 }
 
 func (r *repository) Playlist() domain.PlaylistRepository {
@@ -44,7 +53,11 @@ func (r *repository) Begin(ctx context.Context) error {
 	r.tx = tx
 	r.song.SetTransaction(tx)
 	r.songSource.SetTransaction(tx)
-	r.spotifyTrack.SetTransaction(tx)
+	for _, track := range r.tracks {
+		track.SetTransaction(tx)
+	}
+	r.matchOverride.SetTransaction(tx)
+	r.matchCandidate.SetTransaction(tx)
 	r.playlist.SetTransaction(tx)
 
 	return nil
@@ -59,11 +72,18 @@ func (r *repository) Rollback() error {
 }
 
 func NewRepository(s *Storage) *repository {
+	tracks := make(map[domain.PlaylistType]*trackSqlRepository)
+	for _, pt := range domain.AllPlaylistTypes() {
+		tracks[pt] = &trackSqlRepository{stmts: s.stmts, playlistType: pt}
+	}
+
 	return &repository{
-		db:           s.db,
-		song:         &songSqlRepository{stmts: s.stmts},
-		songSource:   &songSourceSqlRepository{stmts: s.stmts},
-		spotifyTrack: &spotifyTrackSqlRepository{stmts: s.stmts},
-		playlist:     &playlistSqlRepository{},
+		db:             s.db,
+		song:           &songSqlRepository{stmts: s.stmts},
+		songSource:     &songSourceSqlRepository{stmts: s.stmts},
+		tracks:         tracks,
+		matchOverride:  &matchOverrideSqlRepository{},
+		matchCandidate: &matchCandidateSqlRepository{},
+		playlist:       &playlistSqlRepository{},
 	}
 }
@@ -33,9 +33,9 @@ func TestRepository(t *testing.T) {
 			domain.NewSongSourceFromDB(uuid.New(), "sourceID2", songHash2, domain.StudioOneSourceType, "Studio One Tracks", datePlayedNowDay, now, now),
 		}
 
-		spotifyTrack = domain.NewSpotifyTrack(songID1, "trackID1", "upc1")
+		spotifyTrack = domain.NewTrackFromDB(domain.SpotifyPlaylistType, "trackID1", "upc1", songID1, true, domain.FuzzyMatchMethod, 90, "artist", "track", "album", "", now, 1, now, time.Time{})
 
-		playlist = domain.NewPlaylistFromDB("id1", "uri1", "2025-01-01", "name1", domain.SpotifyPlaylistType, domain.StudioOneSourceType, "", formatDateTime(t, time.Now()))
+		playlist = domain.NewPlaylistFromDB("id1", "uri1", "2025-01-01", "name1", domain.DayPlaylistDateScope, domain.SpotifyPlaylistType, domain.StudioOneSourceType, "", "", 0, formatDateTime(t, time.Now()))
 	)
 
 	storage := InitTestStorage(t)
@@ -47,7 +47,7 @@ func TestRepository(t *testing.T) {
 
 		require.NoError(t, repo.Song().BulkInsert(t.Context(), songs))
 		require.NoError(t, repo.SongSource().BulkInsert(t.Context(), songSources))
-		require.NoError(t, repo.SpotifyTrack().Insert(t.Context(), spotifyTrack))
+		require.NoError(t, repo.Track(domain.SpotifyPlaylistType).Insert(t.Context(), spotifyTrack))
 		require.NoError(t, repo.Playlist().Insert(t.Context(), playlist))
 
 		require.NoError(t, repo.Rollback())
@@ -55,7 +55,7 @@ func TestRepository(t *testing.T) {
 		assert.Empty(t, getAllSongs(t, storage.db))
 		assert.Empty(t, getAllSongSources(t, storage.db))
 		assert.Empty(t, getAllPlaylists(t, storage.db))
-		assert.Empty(t, getAllSpotifyTracks(t, storage.db))
+		assert.Empty(t, getAllTracks(t, storage.db, domain.SpotifyPlaylistType))
 	})
 
 	t.Run("commit transaction", func(t *testing.T) {
@@ -63,7 +63,7 @@ func TestRepository(t *testing.T) {
 
 		require.NoError(t, repo.Song().BulkInsert(t.Context(), songs))
 		require.NoError(t, repo.SongSource().BulkInsert(t.Context(), songSources))
-		require.NoError(t, repo.SpotifyTrack().Insert(t.Context(), spotifyTrack))
+		require.NoError(t, repo.Track(domain.SpotifyPlaylistType).Insert(t.Context(), spotifyTrack))
 		require.NoError(t, repo.Playlist().Insert(t.Context(), playlist))
 
 		require.NoError(t, repo.Commit())
@@ -71,6 +71,6 @@ func TestRepository(t *testing.T) {
 		assert.Equal(t, songs, getAllSongs(t, storage.db))
 		assert.Equal(t, songSources, getAllSongSources(t, storage.db))
 		assert.Equal(t, []domain.Playlist{playlist}, getAllPlaylists(t, storage.db))
-		assert.Equal(t, []domain.SpotifyTrack{spotifyTrack}, getAllSpotifyTracks(t, storage.db))
+		assert.Equal(t, []domain.Track{spotifyTrack}, getAllTracks(t, storage.db, domain.SpotifyPlaylistType))
 	})
 }
@@ -0,0 +1,164 @@
+package storage
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"log/slog"
+
+	"github.com/jbenzshawel/playlist-generator/internal/domain"
+)
+
+type songHashInfo struct {
+	id       string
+	artist   string
+	track    string
+	album    string
+	songHash string
+}
+
+// rehashSongs recalculates the song hash of songs hashed with an older domain.SongHashVersion.
+// Song sources and match overrides are updated to the new hash. When the new hash matches
+// an existing song the songs are merged, keeping a found spotify track over a not found one.
+// It runs as a migration, so a migration is added whenever domain.SongHashVersion changes.
+func rehashSongs(ctx context.Context, tx *sqlTx) error {
+	songs, err := getOutdatedSongHashes(ctx, tx)
+	if err != nil {
+		return fmt.Errorf("failed to get outdated song hashes: %w", err)
+	}
+
+	var updateCount, mergeCount int
+	for _, s := range songs {
+		songHash, err := domain.NewSongHash(s.artist, s.track, s.album)
+		if err != nil {
+			return fmt.Errorf("failed to hash song %s: %w", s.id, err)
+		}
+
+		if songHash != s.songHash {
+			var existingID string
+			err = tx.QueryRowContext(ctx, `SELECT id FROM songs WHERE song_hash = ?;`, songHash).Scan(&existingID)
+			switch {
+			case errors.Is(err, sql.ErrNoRows):
+				updateCount++
+			case err != nil:
+				return err
+			default:
+				if err = mergeSongs(ctx, tx, s.id, existingID); err != nil {
+					return fmt.Errorf("failed to merge song %s into %s: %w", s.id, existingID, err)
+				}
+				mergeCount++
+			}
+
+			if err = updateSongHashReferences(ctx, tx, s.songHash, songHash); err != nil {
+				return err
+			}
+		}
+
+		_, err = tx.ExecContext(ctx,
+			`UPDATE songs SET song_hash = ?, hash_version = ? WHERE id = ?;`,
+			songHash, domain.SongHashVersion, s.id,
+		)
+		if err != nil {
+			return fmt.Errorf("failed to update song hash: %w", err)
+		}
+	}
+
+	if len(songs) > 0 {
+		slog.Info("rehashed songs",
+			slog.Int("version", domain.SongHashVersion),
+			slog.Int("updated", updateCount),
+			slog.Int("merged", mergeCount),
+		)
+	}
+
+	return nil
+}
+
+func getOutdatedSongHashes(ctx context.Context, tx *sqlTx) ([]songHashInfo, error) {
+	rows, err := tx.QueryContext(ctx,
+		`SELECT id, artist, track, album, song_hash FROM songs WHERE hash_version < ?;`,
+		domain.SongHashVersion,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var songs []songHashInfo
+	for rows.Next() {
+		var s songHashInfo
+		if err = rows.Scan(&s.id, &s.artist, &s.track, &s.album, &s.songHash); err != nil {
+			return nil, err
+		}
+		songs = append(songs, s)
+	}
+
+	return songs, rows.Err()
+}
+
+// mergeSongs deletes the duplicate song, moving its track on each provider to the existing
+// song when the existing song doesn't have a found match on that provider.
+func mergeSongs(ctx context.Context, tx *sqlTx, duplicateID, existingID string) error {
+	_, err := tx.ExecContext(ctx,
+		`DELETE FROM tracks
+			WHERE song_id = ?
+			  AND match_found = 0
+			  AND playlist_type_id IN (SELECT playlist_type_id FROM tracks WHERE song_id = ?);`,
+		existingID, duplicateID,
+	)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.ExecContext(ctx,
+		`UPDATE tracks SET song_id = ?
+			WHERE song_id = ?
+			  AND playlist_type_id NOT IN (SELECT playlist_type_id FROM tracks WHERE song_id = ?);`,
+		existingID, duplicateID, existingID,
+	)
+	if err != nil {
+		return err
+	}
+
+	if _, err = tx.ExecContext(ctx, `DELETE FROM tracks WHERE song_id = ?;`, duplicateID); err != nil {
+		return err
+	}
+
+	if _, err = tx.ExecContext(ctx, `DELETE FROM match_candidates WHERE song_id = ?;`, duplicateID); err != nil {
+		return err
+	}
+
+	if _, err = tx.ExecContext(ctx, `DELETE FROM song_artists WHERE song_id = ?;`, duplicateID); err != nil {
+		return err
+	}
+
+	_, err = tx.ExecContext(ctx, `DELETE FROM songs WHERE id = ?;`, duplicateID)
+	return err
+}
+
+// updateSongHashReferences points song sources and match overrides at the new song hash.
+// An existing match override for the new hash takes precedence.
+func updateSongHashReferences(ctx context.Context, tx *sqlTx, previousHash, songHash string) error {
+	_, err := tx.ExecContext(ctx, `UPDATE song_sources SET song_hash = ? WHERE song_hash = ?;`, songHash, previousHash)
+	if err != nil {
+		return fmt.Errorf("failed to update song source hash: %w", err)
+	}
+
+	_, err = tx.ExecContext(ctx,
+		`UPDATE match_overrides SET song_hash = ?1
+			WHERE song_hash = ?2
+			AND NOT EXISTS (SELECT 1 FROM match_overrides WHERE song_hash = ?1);`,
+		songHash, previousHash,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to update match override hash: %w", err)
+	}
+
+	_, err = tx.ExecContext(ctx, `DELETE FROM match_overrides WHERE song_hash = ?;`, previousHash)
+	if err != nil {
+		return fmt.Errorf("failed to delete match override: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,160 @@
+package storage
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/jbenzshawel/playlist-generator/internal/domain"
+)
+
+func TestRehashSongs(t *testing.T) {
+	storage := InitTestStorage(t)
+
+	remasterHash := legacySongHash("Miles Davis", "So What - 1997 Remaster", "Kind of Blue")
+	originalHash := legacySongHash("Miles Davis", "So What", "Kind of Blue")
+	featuredHash := legacySongHash("Khruangbin feat. Leon Bridges", "Texas Sun", "Texas Sun")
+
+	statements := []struct {
+		query string
+		args  []any
+	}{
+		{
+			query: `INSERT INTO songs (id, artist, track, album, song_hash, created) VALUES (?, ?, ?, ?, ?, ?);`,
+			args:  []any{"remaster", "Miles Davis", "So What - 1997 Remaster", "Kind of Blue", remasterHash, "2025-01-01T00:00:00Z"},
+		},
+		{
+			query: `INSERT INTO songs (id, artist, track, album, song_hash, created) VALUES (?, ?, ?, ?, ?, ?);`,
+			args:  []any{"original", "Miles Davis", "So What", "Kind of Blue", originalHash, "2025-01-01T00:00:00Z"},
+		},
+		{
+			query: `INSERT INTO songs (id, artist, track, album, song_hash, created) VALUES (?, ?, ?, ?, ?, ?);`,
+			args:  []any{"featured", "Khruangbin feat. Leon Bridges", "Texas Sun", "Texas Sun", featuredHash, "2025-01-01T00:00:00Z"},
+		},
+		{
+			query: `INSERT INTO tracks (song_id, playlist_type_id, id, uri, match_found) VALUES (?, ?, ?, ?, ?);`,
+			args:  []any{"remaster", domain.SpotifyPlaylistType, "soWhat", "spotify:track:soWhat", 1},
+		},
+		{
+			query: `INSERT INTO tracks (song_id, playlist_type_id, id, uri, match_found) VALUES (?, ?, ?, ?, ?);`,
+			args:  []any{"original", domain.SpotifyPlaylistType, "", "", 0},
+		},
+		{
+			query: `INSERT INTO tracks (song_id, playlist_type_id, id, uri, match_found) VALUES (?, ?, ?, ?, ?);`,
+			args:  []any{"remaster", domain.AppleMusicPlaylistType, "", "", 0},
+		},
+		{
+			query: `INSERT INTO tracks (song_id, playlist_type_id, id, uri, match_found) VALUES (?, ?, ?, ?, ?);`,
+			args:  []any{"original", domain.AppleMusicPlaylistType, "1440880779", "https://music.apple.com/us/song/1440880779", 1},
+		},
+		{
+			query: `INSERT INTO song_sources (id, source_id, song_hash, source_type_id, date_played, end_time, created) VALUES (?, ?, ?, ?, ?, ?, ?);`,
+			args:  []any{"source1", "1", remasterHash, 1, "2025-01-01", "2025-01-01T00:00:00Z", "2025-01-01T00:00:00Z"},
+		},
+		{
+			query: `INSERT INTO song_sources (id, source_id, song_hash, source_type_id, date_played, end_time, created) VALUES (?, ?, ?, ?, ?, ?, ?);`,
+			args:  []any{"source2", "2", featuredHash, 1, "2025-01-01", "2025-01-01T00:00:00Z", "2025-01-01T00:00:00Z"},
+		},
+		{
+			query: `INSERT INTO match_overrides (song_hash, track_id, uri, previous_uri, created) VALUES (?, ?, ?, ?, ?);`,
+			args:  []any{featuredHash, "texasSun", "spotify:track:texasSun", "", "2025-01-01T00:00:00Z"},
+		},
+	}
+	for _, s := range statements {
+		_, err := storage.db.ExecContext(t.Context(), s.query, s.args...)
+		require.NoError(t, err)
+	}
+
+	migrateData(t, storage.db, rehashSongs)
+
+	soWhatHash, err := domain.NewSongHash("Miles Davis", "So What", "Kind of Blue")
+	require.NoError(t, err)
+	texasSunHash, err := domain.NewSongHash("Khruangbin", "Texas Sun", "Texas Sun")
+	require.NoError(t, err)
+
+	t.Run("songs rehashed and merged", func(t *testing.T) {
+		assert.Equal(t, map[string]string{
+			"original": soWhatHash,
+			"featured": texasSunHash,
+		}, queryStringMap(t, storage.db, `SELECT id, song_hash FROM songs WHERE hash_version = ?;`, domain.SongHashVersion))
+	})
+
+	t.Run("found tracks kept", func(t *testing.T) {
+		assert.Equal(t, map[string]string{
+			"original": "spotify:track:soWhat",
+		}, queryStringMap(t, storage.db, `SELECT song_id, uri FROM tracks WHERE playlist_type_id = ?;`, domain.SpotifyPlaylistType))
+		assert.Equal(t, map[string]string{
+			"original": "https://music.apple.com/us/song/1440880779",
+		}, queryStringMap(t, storage.db, `SELECT song_id, uri FROM tracks WHERE playlist_type_id = ?;`, domain.AppleMusicPlaylistType))
+	})
+
+	t.Run("song sources updated", func(t *testing.T) {
+		assert.Equal(t, map[string]string{
+			"source1": soWhatHash,
+			"source2": texasSunHash,
+		}, queryStringMap(t, storage.db, `SELECT id, song_hash FROM song_sources;`))
+	})
+
+	t.Run("match overrides updated", func(t *testing.T) {
+		assert.Equal(t, map[string]string{
+			texasSunHash: "spotify:track:texasSun",
+		}, queryStringMap(t, storage.db, `SELECT song_hash, uri FROM match_overrides;`))
+	})
+
+	t.Run("current songs not rehashed again", func(t *testing.T) {
+		_, err := storage.db.ExecContext(t.Context(), `UPDATE songs SET song_hash = 'unchanged' WHERE id = 'featured';`)
+		require.NoError(t, err)
+
+		migrateData(t, storage.db, rehashSongs)
+
+		var songHash string
+		require.NoError(t, storage.db.QueryRowContext(t.Context(), `SELECT song_hash FROM songs WHERE id = 'featured';`).Scan(&songHash))
+		assert.Equal(t, "unchanged", songHash)
+	})
+}
+
+// migrateData runs a data migration in a committed transaction.
+func migrateData(t *testing.T, db *sqlDB, up func(ctx context.Context, tx *sqlTx) error) {
+	t.Helper()
+
+	tx, err := db.BeginTx(t.Context(), nil)
+	require.NoError(t, err)
+	defer func() {
+		_ = tx.Rollback()
+	}()
+
+	require.NoError(t, up(t.Context(), tx))
+	require.NoError(t, tx.Commit())
+}
+
+// legacySongHash returns the song hash before domain.SongHashVersion 2
+func legacySongHash(artist, track, album string) string {
+	hashBytes := sha256.Sum256([]byte(strings.ToLower(artist + "-" + track + "-" + album)))
+	return hex.EncodeToString(hashBytes[:])
+}
+
+func queryStringMap(t *testing.T, db queryContexter, query string, args ...any) map[string]string {
+	t.Helper()
+
+	rows, err := db.QueryContext(t.Context(), query, args...)
+	require.NoError(t, err)
+
+	defer func() {
+		require.NoError(t, rows.Close())
+	}()
+
+	results := make(map[string]string)
+	for rows.Next() {
+		var key, value string
+		require.NoError(t, rows.Scan(&key, &value))
+		results[key] = value
+	}
+	require.NoError(t, rows.Err())
+
+	return results
+}
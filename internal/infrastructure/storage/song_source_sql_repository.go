@@ -14,24 +14,12 @@ import (
 
 var _ domain.SongSourceRepository = (*songSourceSqlRepository)(nil)
 
-var songSourceSchema string = `CREATE TABLE IF NOT EXISTS song_sources (
-    id TEXT PRIMARY KEY,
-    source_id TEXT NOT NULL,
-    song_hash TEXT NOT NULL,
-    source_type_id INT NOT NULL,
-    program_name TEXT,
-    date_played TEXT NOT NULL,
-    end_time TEXT NOT NULL,         -- store timestamps as ISO8601 strings (UTC)
-    created TEXT NOT NULL,           -- store timestamps as ISO8601 strings (UTC)
-   UNIQUE(source_id, source_type_id, end_time) ON CONFLICT IGNORE
-);`
-
 type songSourceSqlRepository struct {
-	tx    *sql.Tx
+	tx    *sqlTx
 	stmts statementGetter
 }
 
-func (r *songSourceSqlRepository) SetTransaction(tx *sql.Tx) {
+func (r *songSourceSqlRepository) SetTransaction(tx *sqlTx) {
 	r.tx = tx
 }
 
@@ -63,6 +51,106 @@ func (r *songSourceSqlRepository) BulkInsert(ctx context.Context, songs []domain
 	return nil
 }
 
+func (r *songSourceSqlRepository) GetSongSources(ctx context.Context, songHash string) ([]domain.SongSource, error) {
+	rows, err := r.tx.QueryContext(ctx,
+		`SELECT id, source_id, song_hash, source_type_id, program_name, date_played, end_time, created
+			FROM song_sources
+			WHERE song_hash = ?;`,
+		songHash,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	return scanSourceSourceRows(rows)
+}
+
+func (r *songSourceSqlRepository) GetSongsPlayedInRange(ctx context.Context, sourceType domain.SourceType, startDate, endDate string) ([]domain.SongPlay, error) {
+	rows, err := r.tx.QueryContext(ctx,
+		`SELECT songs.id, songs.artist, songs.track, songs.album, songs.upc, songs.song_hash, songs.created,
+				song_sources.id, song_sources.source_id, song_sources.song_hash, song_sources.source_type_id,
+				song_sources.program_name, song_sources.date_played, song_sources.end_time, song_sources.created
+			FROM song_sources
+			JOIN songs ON songs.song_hash = song_sources.song_hash
+			WHERE song_sources.source_type_id = ?
+			  AND song_sources.date_played >= ?
+			  AND song_sources.date_played < ?
+			ORDER BY song_sources.end_time, song_sources.id;`,
+		sourceType, startDate, endDate,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var results []domain.SongPlay
+	for rows.Next() {
+		var (
+			songIDStr      string
+			artist         string
+			track          string
+			album          string
+			upc            sql.NullString
+			songHash       string
+			songCreatedStr string
+			idStr          string
+			sourceID       string
+			sourceSongHash string
+			songSourceType domain.SourceType
+			programName    string
+			day            string
+			endTimeStr     string
+			createdStr     string
+		)
+
+		err = rows.Scan(
+			&songIDStr, &artist, &track, &album, &upc, &songHash, &songCreatedStr,
+			&idStr, &sourceID, &sourceSongHash, &songSourceType, &programName, &day, &endTimeStr, &createdStr,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		songID, err := uuid.Parse(songIDStr)
+		if err != nil {
+			return nil, err
+		}
+
+		songCreated, err := utcStringToTime(songCreatedStr)
+		if err != nil {
+			return nil, err
+		}
+
+		id, err := uuid.Parse(idStr)
+		if err != nil {
+			return nil, err
+		}
+
+		endTime, err := utcStringToTime(endTimeStr)
+		if err != nil {
+			return nil, err
+		}
+
+		created, err := utcStringToTime(createdStr)
+		if err != nil {
+			return nil, err
+		}
+
+		results = append(results, domain.NewSongPlay(
+			domain.NewSongFromDB(songID, artist, track, album, upc.String, songHash, songCreated),
+			domain.NewSongSourceFromDB(id, sourceID, sourceSongHash, songSourceType, programName, day, endTime, created),
+		))
+	}
+
+	err = rows.Err()
+	if err != nil {
+		return nil, err
+	}
+
+	return results, nil
+}
+
 func scanSourceSourceRows(rows *sql.Rows) ([]domain.SongSource, error) {
 	var results []domain.SongSource
 	for rows.Next() {
@@ -49,6 +49,13 @@ func TestSongSourceSqlRepository(t *testing.T) {
 		assert.Equal(t, expectedSongSources, actual)
 	})
 
+	t.Run("get song sources", func(t *testing.T) {
+		actual, err := r.GetSongSources(t.Context(), "songHash2")
+		require.NoError(t, err)
+
+		assert.Equal(t, expectedSongSources[1:2], actual)
+	})
+
 	t.Run("commit", func(t *testing.T) {
 		require.NoError(t, tx.Commit())
 
@@ -58,6 +65,58 @@ func TestSongSourceSqlRepository(t *testing.T) {
 	})
 }
 
+func TestSongSourceSqlRepository_GetSongsPlayedInRange(t *testing.T) {
+	var (
+		now       = formatDateTime(t, time.Now())
+		yesterday = formatDateTime(t, time.Now().AddDate(0, 0, -1))
+
+		nowDay       = now.Format(time.DateOnly)
+		yesterdayDay = yesterday.Format(time.DateOnly)
+		endDay       = now.AddDate(0, 0, 1).Format(time.DateOnly)
+
+		songs = []domain.Song{
+			domain.NewSongFromDB(uuid.New(), "artist1", "track1", "album1", "upc1", "songHash1", now),
+			domain.NewSongFromDB(uuid.New(), "artist2", "track2", "album2", "", "songHash2", now),
+		}
+
+		songSources = []domain.SongSource{
+			domain.NewSongSourceFromDB(uuid.New(), "sourceID1", "songHash1", domain.StudioOneSourceType, "Studio One Tracks", nowDay, now, now),
+			domain.NewSongSourceFromDB(uuid.New(), "sourceID2", "songHash2", domain.StudioOneSourceType, "World Cafe", yesterdayDay, yesterday, yesterday),
+			domain.NewSongSourceFromDB(uuid.New(), "sourceID3", "songHash1", domain.StudioOneSourceType, "Studio One Tracks", yesterdayDay, yesterday.Add(time.Minute), yesterday),
+			domain.NewSongSourceFromDB(uuid.New(), "sourceID4", "songHash2", domain.TheCurrentSourceType, "", nowDay, now, now),
+		}
+	)
+
+	storage := InitTestStorage(t)
+
+	tx, err := storage.db.BeginTx(t.Context(), nil)
+	require.NoError(t, err)
+
+	songRepo := &songSqlRepository{tx: tx, stmts: storage.stmts}
+	r := &songSourceSqlRepository{tx: tx, stmts: storage.stmts}
+
+	require.NoError(t, songRepo.BulkInsert(t.Context(), songs))
+	require.NoError(t, r.BulkInsert(t.Context(), songSources))
+
+	t.Run("plays for source returned in play order", func(t *testing.T) {
+		actual, err := r.GetSongsPlayedInRange(t.Context(), domain.StudioOneSourceType, yesterdayDay, endDay)
+		require.NoError(t, err)
+
+		assert.Equal(t, []domain.SongPlay{
+			domain.NewSongPlay(songs[1], songSources[1]),
+			domain.NewSongPlay(songs[0], songSources[2]),
+			domain.NewSongPlay(songs[0], songSources[0]),
+		}, actual)
+	})
+
+	t.Run("plays returned for date range", func(t *testing.T) {
+		actual, err := r.GetSongsPlayedInRange(t.Context(), domain.StudioOneSourceType, nowDay, endDay)
+		require.NoError(t, err)
+
+		assert.Equal(t, []domain.SongPlay{domain.NewSongPlay(songs[0], songSources[0])}, actual)
+	})
+}
+
 func getAllSongSources(t *testing.T, db queryContexter) []domain.SongSource {
 	rows, err := db.QueryContext(
 		t.Context(),
@@ -8,28 +8,19 @@ import (
 
 	"github.com/google/uuid"
 
+	"github.com/jbenzshawel/playlist-generator/internal/common/normalize"
 	"github.com/jbenzshawel/playlist-generator/internal/domain"
 	"github.com/jbenzshawel/playlist-generator/internal/infrastructure/storage/internal/statements"
 )
 
 var _ domain.SongRepository = (*songSqlRepository)(nil)
 
-var songSchema string = `CREATE TABLE IF NOT EXISTS songs (
-    id TEXT PRIMARY KEY,            -- UUID string (e.g., 550e8400-e29b-41d4-a716-446655440000)
-    artist TEXT NOT NULL,
-    track TEXT NOT NULL,
-    album TEXT NOT NULL,
-    upc TEXT,
-    song_hash TEXT NOT NULL UNIQUE, -- derived hash to de-duplicate song
-    created TEXT NOT NULL           -- store timestamps as ISO8601 strings (UTC)
-);`
-
 type songSqlRepository struct {
-	tx    *sql.Tx
+	tx    *sqlTx
 	stmts statementGetter
 }
 
-func (r *songSqlRepository) SetTransaction(tx *sql.Tx) {
+func (r *songSqlRepository) SetTransaction(tx *sqlTx) {
 	r.tx = tx
 }
 
@@ -39,12 +30,17 @@ func (r *songSqlRepository) BulkInsert(ctx context.Context, songs []domain.Song)
 		return err
 	}
 
+	artistStmt, err := r.stmts.Get(statements.InsertSongArtistType)
+	if err != nil {
+		return err
+	}
+
 	var insertCount int64
 	for _, s := range songs {
 		res, err := r.tx.StmtContext(ctx, stmt).
 			ExecContext(
 				ctx,
-				s.ID(), s.Artist(), s.Track(), s.Album(), s.UPC(), s.SongHash(), timeToUTCString(s.Created()),
+				s.ID(), s.Artist(), s.Track(), s.Album(), s.UPC(), s.SongHash(), domain.SongHashVersion, timeToUTCString(s.Created()),
 			)
 		if err != nil {
 			return fmt.Errorf("failed to insert song: %w", err)
@@ -54,6 +50,17 @@ func (r *songSqlRepository) BulkInsert(ctx context.Context, songs []domain.Song)
 			return fmt.Errorf("failed to get affected rows: %w", err)
 		}
 		insertCount += count
+
+		if count == 0 {
+			continue
+		}
+
+		for i, artist := range s.Artists() {
+			_, err = r.tx.StmtContext(ctx, artistStmt).ExecContext(ctx, s.ID(), i, artist)
+			if err != nil {
+				return fmt.Errorf("failed to insert song artist: %w", err)
+			}
+		}
 	}
 
 	slog.Debug("insert songs complete", slog.Int64("count", insertCount))
@@ -61,6 +68,109 @@ func (r *songSqlRepository) BulkInsert(ctx context.Context, songs []domain.Song)
 	return nil
 }
 
+func (r *songSqlRepository) GetSongByHash(ctx context.Context, songHash string) (domain.Song, error) {
+	rows, err := r.tx.QueryContext(ctx,
+		`SELECT id, artist, track, album, upc, song_hash, created FROM songs WHERE song_hash = ?;`,
+		songHash,
+	)
+	if err != nil {
+		return domain.Song{}, err
+	}
+	defer rows.Close()
+
+	songs, err := scanSongRows(rows)
+	if err != nil {
+		return domain.Song{}, err
+	}
+
+	if len(songs) == 0 {
+		return domain.Song{}, nil
+	}
+
+	return songs[0], nil
+}
+
+func (r *songSqlRepository) GetSongsByArtistTrack(ctx context.Context, artist, track string) ([]domain.Song, error) {
+	rows, err := r.tx.QueryContext(ctx,
+		`SELECT id, artist, track, album, upc, song_hash, created
+			FROM songs
+			WHERE LOWER(artist) = LOWER(?)
+			  AND LOWER(track) = LOWER(?)
+			ORDER BY created, song_hash;`,
+		artist, track,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	return scanSongRows(rows)
+}
+
+func (r *songSqlRepository) GetSongsByArtist(ctx context.Context, artist string) ([]domain.Song, error) {
+	rows, err := r.tx.QueryContext(ctx,
+		`SELECT id, artist, track, album, upc, song_hash, created
+			FROM songs
+			WHERE id IN (
+				SELECT song_id
+				FROM song_artists
+				WHERE artist = ?1
+				   OR artist LIKE ?1 || ' and %'
+				   OR artist LIKE '% and ' || ?1
+				   OR artist LIKE '% and ' || ?1 || ' and %'
+			)
+			ORDER BY created, id;`,
+		normalize.Artist(artist),
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	return scanSongRows(rows)
+}
+
+// initSongArtists stores the parsed artists of songs saved before song artists were stored.
+func initSongArtists(ctx context.Context, tx *sqlTx) error {
+	rows, err := tx.QueryContext(ctx,
+		`SELECT id, artist FROM songs WHERE id NOT IN (SELECT song_id FROM song_artists);`,
+	)
+	if err != nil {
+		return err
+	}
+
+	songArtists := make(map[string]string)
+	for rows.Next() {
+		var id, artist string
+		if err = rows.Scan(&id, &artist); err != nil {
+			_ = rows.Close()
+			return err
+		}
+		songArtists[id] = artist
+	}
+	if err = rows.Close(); err != nil {
+		return err
+	}
+
+	for id, credit := range songArtists {
+		for i, artist := range normalize.Artists(credit) {
+			_, err = tx.ExecContext(ctx,
+				`INSERT INTO song_artists (song_id, position, artist) VALUES (?, ?, ?);`,
+				id, i, artist,
+			)
+			if err != nil {
+				return fmt.Errorf("failed to insert song artist: %w", err)
+			}
+		}
+	}
+
+	if len(songArtists) > 0 {
+		slog.Info("stored song artists", slog.Int("count", len(songArtists)))
+	}
+
+	return nil
+}
+
 func scanSongRows(rows *sql.Rows) ([]domain.Song, error) {
 	var results []domain.Song
 	for rows.Next() {
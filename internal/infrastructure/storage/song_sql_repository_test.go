@@ -44,6 +44,26 @@ func TestSongSqlRepository(t *testing.T) {
 		assert.Equal(t, expectedSongs, actual)
 	})
 
+	t.Run("get song by hash", func(t *testing.T) {
+		actual, err := r.GetSongByHash(t.Context(), "songHash2")
+		require.NoError(t, err)
+		assert.Equal(t, expectedSongs[1], actual)
+
+		actual, err = r.GetSongByHash(t.Context(), "unknown")
+		require.NoError(t, err)
+		assert.Equal(t, domain.Song{}, actual)
+	})
+
+	t.Run("get songs by artist track", func(t *testing.T) {
+		actual, err := r.GetSongsByArtistTrack(t.Context(), "ARTIST3", "Track3")
+		require.NoError(t, err)
+		assert.Equal(t, expectedSongs[2:], actual)
+
+		actual, err = r.GetSongsByArtistTrack(t.Context(), "artist3", "track1")
+		require.NoError(t, err)
+		assert.Empty(t, actual)
+	})
+
 	t.Run("commit", func(t *testing.T) {
 		require.NoError(t, tx.Commit())
 
@@ -53,10 +73,75 @@ func TestSongSqlRepository(t *testing.T) {
 	})
 }
 
+func TestSongSqlRepository_SongArtists(t *testing.T) {
+	now := formatDateTime(t, time.Now())
+
+	songs := []domain.Song{
+		domain.NewSongFromDB(uuid.New(), "Khruangbin feat. Leon Bridges", "Texas Sun", "Texas Sun", "", "songHash1", now),
+		domain.NewSongFromDB(uuid.New(), "Leon Bridges", "Coming Home", "Coming Home", "", "songHash2", now.Add(time.Second)),
+		domain.NewSongFromDB(uuid.New(), "Norah Jones & Mavis Staples", "I Just Wanna Sleep", "Visions", "", "songHash3", now),
+	}
+
+	storage := InitTestStorage(t)
+
+	tx, err := storage.db.BeginTx(t.Context(), nil)
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		_ = tx.Rollback()
+	})
+
+	r := &songSqlRepository{tx: tx, stmts: storage.stmts}
+	require.NoError(t, r.BulkInsert(t.Context(), songs))
+
+	t.Run("artists stored in credit order", func(t *testing.T) {
+		assert.Equal(t, map[string]string{
+			songs[0].ID().String(): "khruangbin",
+			songs[1].ID().String(): "leon bridges",
+			songs[2].ID().String(): "norah jones and mavis staples",
+		}, queryStringMap(t, tx, `SELECT song_id, artist FROM song_artists WHERE position = 0;`))
+
+		assert.Equal(t, map[string]string{
+			songs[0].ID().String(): "leon bridges",
+		}, queryStringMap(t, tx, `SELECT song_id, artist FROM song_artists WHERE position = 1;`))
+	})
+
+	t.Run("get songs by artist", func(t *testing.T) {
+		actual, err := r.GetSongsByArtist(t.Context(), "Leon Bridges")
+		require.NoError(t, err)
+		assert.Equal(t, songs[:2], actual)
+
+		actual, err = r.GetSongsByArtist(t.Context(), "Mavis Staples")
+		require.NoError(t, err)
+		assert.Equal(t, songs[2:], actual)
+
+		actual, err = r.GetSongsByArtist(t.Context(), "Mavis")
+		require.NoError(t, err)
+		assert.Empty(t, actual)
+	})
+}
+
+func TestInitSongArtists(t *testing.T) {
+	storage := InitTestStorage(t)
+
+	_, err := storage.db.ExecContext(t.Context(),
+		`INSERT INTO songs (id, artist, track, album, song_hash, created) VALUES (?, ?, ?, ?, ?, ?);`,
+		"song1", "Bon Iver, St. Vincent", "Sleeping", "Sleeping", "songHash1", "2025-01-01T00:00:00Z",
+	)
+	require.NoError(t, err)
+
+	migrateData(t, storage.db, initSongArtists)
+	migrateData(t, storage.db, initSongArtists)
+
+	assert.Equal(t, map[string]string{
+		"0": "bon iver",
+		"1": "st vincent",
+	}, queryStringMap(t, storage.db, `SELECT position, artist FROM song_artists WHERE song_id = 'song1';`))
+}
+
 func getAllSongs(t *testing.T, db queryContexter) []domain.Song {
 	rows, err := db.QueryContext(
 		t.Context(),
-		`SELECT * FROM songs;`,
+		`SELECT id, artist, track, album, upc, song_hash, created FROM songs;`,
 	)
 	require.NoError(t, err)
 
@@ -1,23 +1,18 @@
 package storage
 
 import (
-	"database/sql"
+	"context"
 
 	"github.com/jbenzshawel/playlist-generator/internal/domain"
 )
 
-var sourceTypeSchema string = `CREATE TABLE IF NOT EXISTS source_types (
-    id INTEGER PRIMARY KEY,
-    name TEXT NOT NULL
-);`
-
-func initSourceTypes(db *sql.DB) error {
+func initSourceTypes(ctx context.Context, db *sqlDB) error {
 	for _, st := range domain.AllSourceTypes() {
-		query := `INSERT INTO source_types (ID, Name)
-		SELECT ?, ?
-		WHERE NOT EXISTS (SELECT 1 FROM source_types WHERE id = ?);`
+		query := `INSERT INTO source_types (id, name)
+		VALUES (?, ?)
+		ON CONFLICT (id) DO NOTHING;`
 
-		_, err := db.Exec(query, int(st), st.String(), int(st))
+		_, err := db.ExecContext(ctx, query, int(st), st.String())
 		if err != nil {
 			return err
 		}
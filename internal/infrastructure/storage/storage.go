@@ -6,23 +6,17 @@ import (
 	"fmt"
 	"log/slog"
 
+	_ "github.com/jackc/pgx/v5/stdlib"
 	_ "modernc.org/sqlite"
 
 	"github.com/jbenzshawel/playlist-generator/internal/infrastructure/storage/internal/statements"
 )
 
-var tableSchemas = []string{
-	sourceTypeSchema,
-	playlistTypeSchema,
-	songSchema,
-	songSourceSchema,
-	spotifyTrackSchema,
-	playlistsSchema,
-}
-
-var lookupInitializers = []func(*sql.DB) error{
+var lookupInitializers = []func(context.Context, *sqlDB) error{
 	initSourceTypes,
 	initPlaylistTypes,
+	initPlaylistDateScopes,
+	initMatchMethods,
 }
 
 type statementGetter interface {
@@ -31,34 +25,36 @@ type statementGetter interface {
 }
 
 type Storage struct {
-	db    *sql.DB
+	db    *sqlDB
 	stmts statementGetter
 }
 
-// Initialize opens a database connection and initializes the database. A Storage struct
-// is returned with a Close function that closes the database connection.
+// Initialize opens a database connection and initializes the database. A postgres:// or
+// postgresql:// DSN opens a Postgres database and any other DSN opens a SQLite database.
+// A Storage struct is returned with a Close function that closes the database connection.
 func Initialize(ctx context.Context, dsn string) (*Storage, error) {
-	db, err := sql.Open("sqlite", dsn)
+	db, err := openDB(dsn)
 	if err != nil {
 		return nil, fmt.Errorf("failed to open database: %w", err)
 	}
 
-	for _, table := range tableSchemas {
-		_, err = db.ExecContext(ctx, table)
-		if err != nil {
-			return nil, fmt.Errorf("error initilizing table schema: %w", err)
-		}
+	err = migrate(ctx, db)
+	if err != nil {
+		_ = db.Close()
+		return nil, fmt.Errorf("failed to migrate database: %w", err)
 	}
 
 	for _, lookupInit := range lookupInitializers {
-		err = lookupInit(db)
+		err = lookupInit(ctx, db)
 		if err != nil {
+			_ = db.Close()
 			return nil, fmt.Errorf("failed to seed lookup table: %w", err)
 		}
 	}
 
-	stmts, err := statements.New(ctx, db)
+	stmts, err := statements.New(ctx, db.db, db.dialect)
 	if err != nil {
+		_ = db.Close()
 		return nil, fmt.Errorf("failed to prepare statements: %w", err)
 	}
 
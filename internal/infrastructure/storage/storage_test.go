@@ -1,7 +1,6 @@
 package storage
 
 import (
-	"database/sql"
 	"fmt"
 	"testing"
 
@@ -9,6 +8,7 @@ import (
 	"github.com/stretchr/testify/require"
 
 	"github.com/jbenzshawel/playlist-generator/internal/domain"
+	"github.com/jbenzshawel/playlist-generator/internal/infrastructure/storage/internal/dialect"
 	"github.com/jbenzshawel/playlist-generator/internal/infrastructure/storage/internal/statements"
 )
 
@@ -19,12 +19,20 @@ func TestStorage(t *testing.T) {
 
 	t.Run("expected tables exists", func(t *testing.T) {
 		expectedTables := map[string]struct{}{
-			"songs":          {},
-			"song_sources":   {},
-			"spotify_tracks": {},
-			"source_types":   {},
-			"playlist_types": {},
-			"playlists":      {},
+			"songs":                {},
+			"song_artists":         {},
+			"song_sources":         {},
+			"match_overrides":      {},
+			"match_candidates":     {},
+			"oauth_tokens":         {},
+			"tracks":               {},
+			"replaced_tracks":      {},
+			"source_types":         {},
+			"playlist_types":       {},
+			"playlist_date_scopes": {},
+			"match_methods":        {},
+			"playlists":            {},
+			"schema_migrations":    {},
 		}
 
 		actualTables := listTables(t, storage.db)
@@ -54,6 +62,15 @@ func TestStorage(t *testing.T) {
 		}
 	})
 
+	t.Run("playlist date scopes lookup", func(t *testing.T) {
+		actual := getLookupValues(t, storage.db, "playlist_date_scopes")
+
+		for idx, expected := range domain.AllPlaylistDateScopes() {
+			assert.Equal(t, expected, domain.PlaylistDateScope(actual[idx].id))
+			assert.Equal(t, expected.String(), actual[idx].name)
+		}
+	})
+
 	t.Run("statements prepared", func(t *testing.T) {
 		for _, st := range statements.AllTypes() {
 			stmt, err := storage.stmts.Get(st)
@@ -63,11 +80,13 @@ func TestStorage(t *testing.T) {
 	})
 }
 
-func listTables(t *testing.T, db *sql.DB) []string {
-	rows, err := db.QueryContext(
-		t.Context(),
-		`SELECT name FROM sqlite_master WHERE type='table';`,
-	)
+func listTables(t *testing.T, db *sqlDB) []string {
+	query := `SELECT name FROM sqlite_master WHERE type='table';`
+	if db.dialect == dialect.Postgres {
+		query = `SELECT table_name FROM information_schema.tables WHERE table_schema = current_schema();`
+	}
+
+	rows, err := db.QueryContext(t.Context(), query)
 	require.NoError(t, err)
 
 	defer func() {
@@ -90,10 +109,10 @@ type lookup struct {
 	name string
 }
 
-func getLookupValues(t *testing.T, db *sql.DB, tableName string) []lookup {
+func getLookupValues(t *testing.T, db *sqlDB, tableName string) []lookup {
 	rows, err := db.QueryContext(
 		t.Context(),
-		fmt.Sprintf(`SELECT id, name FROM %s;`, tableName),
+		fmt.Sprintf(`SELECT id, name FROM %s ORDER BY id;`, tableName),
 	)
 	require.NoError(t, err)
 
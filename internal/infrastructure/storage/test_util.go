@@ -5,17 +5,30 @@ package storage
 import (
 	"context"
 	"database/sql"
+	"net/url"
+	"os"
+	"strings"
 	"testing"
 	"time"
 
+	"github.com/google/uuid"
 	"github.com/stretchr/testify/require"
 	_ "modernc.org/sqlite"
 )
 
+// TestPostgresDSNEnv names the environment variable with the DSN of a Postgres database
+// that InitTestStorage uses instead of an in-memory SQLite database.
+const TestPostgresDSNEnv = "PLAYLIST_GENERATOR_TEST_POSTGRES_DSN"
+
 func InitTestStorage(t *testing.T) *Storage {
 	t.Helper()
 
-	storage, err := Initialize(t.Context(), "file::memory:?mode=memory&cache=shared")
+	dsn := "file::memory:?mode=memory&cache=shared"
+	if postgresDSN := os.Getenv(TestPostgresDSNEnv); postgresDSN != "" {
+		dsn = initTestPostgresSchema(t, postgresDSN)
+	}
+
+	storage, err := Initialize(t.Context(), dsn)
 	t.Cleanup(func() {
 		storage.Close()
 	})
@@ -24,6 +37,34 @@ func InitTestStorage(t *testing.T) *Storage {
 	return storage
 }
 
+// initTestPostgresSchema creates a schema that is dropped when the test ends and returns
+// a DSN that uses it, so tests don't share tables.
+func initTestPostgresSchema(t *testing.T, dsn string) string {
+	t.Helper()
+
+	db, err := sql.Open("pgx", dsn)
+	require.NoError(t, err)
+
+	schema := "test_" + strings.ReplaceAll(uuid.NewString(), "-", "")
+	_, err = db.ExecContext(t.Context(), `CREATE SCHEMA `+schema+`;`)
+	require.NoError(t, err)
+
+	t.Cleanup(func() {
+		_, err := db.ExecContext(context.Background(), `DROP SCHEMA `+schema+` CASCADE;`)
+		require.NoError(t, err)
+		require.NoError(t, db.Close())
+	})
+
+	dsnURL, err := url.Parse(dsn)
+	require.NoError(t, err)
+
+	query := dsnURL.Query()
+	query.Set("search_path", schema)
+	dsnURL.RawQuery = query.Encode()
+
+	return dsnURL.String()
+}
+
 // formatDateTime is a test helper for formating time on an expected struct
 // at the precision the time will be when inserted/returned from the database
 func formatDateTime(t *testing.T, dt time.Time) time.Time {
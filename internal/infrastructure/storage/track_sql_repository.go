@@ -0,0 +1,358 @@
+package storage
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/jbenzshawel/playlist-generator/internal/domain"
+	"github.com/jbenzshawel/playlist-generator/internal/infrastructure/storage/internal/statements"
+)
+
+var _ domain.TrackRepository = (*trackSqlRepository)(nil)
+
+// trackSqlRepository stores the tracks songs were matched to on one provider
+type trackSqlRepository struct {
+	tx           *sqlTx
+	stmts        statementGetter
+	playlistType domain.PlaylistType
+}
+
+func (r *trackSqlRepository) SetTransaction(tx *sqlTx) {
+	r.tx = tx
+}
+
+func (r *trackSqlRepository) GetUnknownSongs(ctx context.Context) ([]domain.Song, error) {
+	rows, err := r.tx.QueryContext(
+		ctx,
+		`SELECT songs.id, songs.artist, songs.track, songs.album, songs.upc, songs.song_hash, songs.created
+			FROM songs
+			LEFT JOIN tracks ON songs.id = tracks.song_id AND tracks.playlist_type_id = ?
+			WHERE tracks.song_id IS NULL
+			ORDER BY songs.created, songs.song_hash;`,
+		r.playlistType,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	results, err := scanSongRows(rows)
+	if err != nil {
+		return nil, err
+	}
+
+	return results, nil
+}
+
+func (r *trackSqlRepository) GetRetryableSongs(ctx context.Context, now time.Time) ([]domain.Song, error) {
+	rows, err := r.tx.QueryContext(
+		ctx,
+		`SELECT songs.id, songs.artist, songs.track, songs.album, songs.upc, songs.song_hash, songs.created
+			FROM songs
+			JOIN tracks ON songs.id = tracks.song_id
+			WHERE tracks.playlist_type_id = ?
+			  AND tracks.match_found = 0
+			  AND tracks.next_attempt != ''
+			  AND tracks.next_attempt <= ?
+			ORDER BY songs.created, songs.song_hash;`,
+		r.playlistType, timeToUTCString(now),
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	results, err := scanSongRows(rows)
+	if err != nil {
+		return nil, err
+	}
+
+	return results, nil
+}
+
+func (r *trackSqlRepository) GetLowConfidenceSongs(ctx context.Context, minConfidence float64) ([]domain.Song, error) {
+	rows, err := r.tx.QueryContext(
+		ctx,
+		`SELECT songs.id, songs.artist, songs.track, songs.album, songs.upc, songs.song_hash, songs.created
+			FROM songs
+			JOIN tracks ON songs.id = tracks.song_id
+			WHERE tracks.playlist_type_id = ?
+			  AND tracks.match_found = 1
+			  AND tracks.confidence < ?
+			ORDER BY songs.created, songs.song_hash;`,
+		r.playlistType, minConfidence,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	results, err := scanSongRows(rows)
+	if err != nil {
+		return nil, err
+	}
+
+	return results, nil
+}
+
+func (r *trackSqlRepository) GetTracksPlayedInRange(ctx context.Context, songSourceType domain.SourceType, programName, startDate, endDate string) ([]domain.Track, error) {
+	rows, err := r.tx.QueryContext(
+		ctx,
+		`SELECT tracks.id, tracks.uri, tracks.song_id, tracks.match_found, tracks.match_method_id,
+				tracks.confidence, tracks.matched_artist, tracks.matched_track, tracks.matched_album, tracks.isrc, tracks.matched_at,
+				tracks.attempt_count, tracks.last_attempt, tracks.next_attempt
+			FROM songs
+			JOIN tracks ON songs.id = tracks.song_id
+			JOIN song_sources ON song_sources.song_hash = songs.song_hash
+			WHERE tracks.playlist_type_id = ?
+			  AND tracks.match_found = 1
+			  AND song_sources.source_type_id = ?
+			  AND (? = '' OR song_sources.program_name = ?)
+			  AND song_sources.date_played >= ?
+			  AND song_sources.date_played < ?
+			GROUP BY tracks.id, tracks.uri, tracks.song_id, tracks.match_found, tracks.match_method_id,
+				tracks.confidence, tracks.matched_artist, tracks.matched_track, tracks.matched_album, tracks.isrc, tracks.matched_at,
+				tracks.attempt_count, tracks.last_attempt, tracks.next_attempt
+			ORDER BY MIN(song_sources.end_time), tracks.id;`,
+		r.playlistType, songSourceType, programName, programName, startDate, endDate,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	results, err := r.scanTracks(rows)
+	if err != nil {
+		return nil, err
+	}
+
+	return results, nil
+}
+
+func (r *trackSqlRepository) GetRandomTracks(ctx context.Context, numTracks int) ([]domain.Track, error) {
+	rows, err := r.tx.QueryContext(ctx,
+		`SELECT id, uri, song_id, match_found, match_method_id, confidence, matched_artist, matched_track, matched_album, isrc, matched_at,
+				attempt_count, last_attempt, next_attempt
+				FROM tracks
+				WHERE playlist_type_id = ?
+				  AND match_found = 1
+				ORDER BY RANDOM()
+				LIMIT ?`,
+		r.playlistType, numTracks)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	results, err := r.scanTracks(rows)
+	if err != nil {
+		return nil, err
+	}
+
+	return results, nil
+}
+
+func (r *trackSqlRepository) scanTracks(rows *sql.Rows) ([]domain.Track, error) {
+	var results []domain.Track
+	for rows.Next() {
+		var (
+			id             string
+			uri            string
+			songIDStr      string
+			matchFoundInt  int
+			matchMethod    domain.MatchMethod
+			confidence     float64
+			matchedArtist  string
+			matchedTrack   string
+			matchedAlbum   string
+			isrc           string
+			matchedAtStr   string
+			attemptCount   int
+			lastAttemptStr string
+			nextAttemptStr string
+		)
+
+		if err := rows.Scan(
+			&id, &uri, &songIDStr, &matchFoundInt, &matchMethod, &confidence, &matchedArtist, &matchedTrack, &matchedAlbum, &isrc, &matchedAtStr,
+			&attemptCount, &lastAttemptStr, &nextAttemptStr,
+		); err != nil {
+			return nil, err
+		}
+
+		songID, err := uuid.Parse(songIDStr)
+		if err != nil {
+			return nil, err
+		}
+
+		// tracks matched before matched_at was recorded don't have a timestamp
+		matchedAt, err := optionalUTCStringToTime(matchedAtStr)
+		if err != nil {
+			return nil, err
+		}
+
+		lastAttempt, err := optionalUTCStringToTime(lastAttemptStr)
+		if err != nil {
+			return nil, err
+		}
+
+		nextAttempt, err := optionalUTCStringToTime(nextAttemptStr)
+		if err != nil {
+			return nil, err
+		}
+
+		t := domain.NewTrackFromDB(
+			r.playlistType, id, uri, songID, matchFoundInt == 1, matchMethod, confidence, matchedArtist, matchedTrack, matchedAlbum, isrc, matchedAt,
+			attemptCount, lastAttempt, nextAttempt,
+		)
+		results = append(results, t)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return results, nil
+}
+
+func (r *trackSqlRepository) Insert(ctx context.Context, track domain.Track) error {
+	if track.PlaylistType() != r.playlistType {
+		return fmt.Errorf("%s track can't be stored with %s tracks", track.PlaylistType(), r.playlistType)
+	}
+
+	stmt, err := r.stmts.Get(statements.InsertTrackType)
+	if err != nil {
+		return err
+	}
+
+	_, err = r.tx.StmtContext(ctx, stmt).
+		ExecContext(
+			ctx,
+			track.SongID(), r.playlistType, track.TrackID(), track.URI(), boolToInt(track.MatchFound()), track.MatchMethod(),
+			track.Confidence(), track.MatchedArtist(), track.MatchedTrack(), track.MatchedAlbum(), track.ISRC(),
+			optionalTimeToUTCString(track.MatchedAt()),
+			track.AttemptCount(), optionalTimeToUTCString(track.LastAttempt()), optionalTimeToUTCString(track.NextAttempt()),
+		)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (r *trackSqlRepository) GetTrackBySongID(ctx context.Context, songID uuid.UUID) (domain.Track, error) {
+	rows, err := r.tx.QueryContext(ctx,
+		`SELECT id, uri, song_id, match_found, match_method_id, confidence, matched_artist, matched_track, matched_album, isrc, matched_at,
+				attempt_count, last_attempt, next_attempt
+			FROM tracks
+			WHERE song_id = ?
+			  AND playlist_type_id = ?;`,
+		songID, r.playlistType,
+	)
+	if err != nil {
+		return domain.Track{}, err
+	}
+	defer rows.Close()
+
+	tracks, err := r.scanTracks(rows)
+	if err != nil {
+		return domain.Track{}, err
+	}
+
+	if len(tracks) == 0 {
+		return domain.Track{}, nil
+	}
+
+	return tracks[0], nil
+}
+
+func (r *trackSqlRepository) Replace(ctx context.Context, track domain.Track) error {
+	previous, err := r.GetTrackBySongID(ctx, track.SongID())
+	if err != nil {
+		return err
+	}
+
+	_, err = r.tx.ExecContext(ctx,
+		`DELETE FROM tracks WHERE song_id = ? AND playlist_type_id = ?;`,
+		track.SongID(), r.playlistType,
+	)
+	if err != nil {
+		return err
+	}
+
+	if err := r.Insert(ctx, track); err != nil {
+		return err
+	}
+
+	// tracks replaced by the song's earlier matches are replaced by the new match
+	_, err = r.tx.ExecContext(ctx,
+		`UPDATE replaced_tracks SET uri = ? WHERE playlist_type_id = ? AND song_id = ?;`,
+		track.URI(), r.playlistType, track.SongID(),
+	)
+	if err != nil {
+		return err
+	}
+
+	if !previous.MatchFound() || previous.URI() == "" || previous.URI() == track.URI() {
+		return nil
+	}
+
+	_, err = r.tx.ExecContext(ctx,
+		`INSERT INTO replaced_tracks (playlist_type_id, previous_uri, song_id, uri, created)
+			VALUES (?, ?, ?, ?, ?)
+			ON CONFLICT (playlist_type_id, previous_uri) DO UPDATE SET
+				song_id = excluded.song_id,
+				uri = excluded.uri,
+				created = excluded.created;`,
+		r.playlistType, previous.URI(), track.SongID(), track.URI(), timeToUTCString(time.Now()),
+	)
+	return err
+}
+
+func (r *trackSqlRepository) GetReplacedURIs(ctx context.Context) (map[string]string, error) {
+	rows, err := r.tx.QueryContext(ctx,
+		`SELECT previous_uri, uri
+			FROM replaced_tracks
+			WHERE playlist_type_id = ?1
+			  AND previous_uri != uri
+			  AND previous_uri NOT IN (SELECT uri FROM tracks WHERE playlist_type_id = ?1 AND match_found = 1);`,
+		r.playlistType,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	replaced := map[string]string{}
+	for rows.Next() {
+		var previousURI, uri string
+		if err := rows.Scan(&previousURI, &uri); err != nil {
+			return nil, err
+		}
+		replaced[previousURI] = uri
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return replaced, nil
+}
+
+func (r *trackSqlRepository) GetISRC(ctx context.Context, songID uuid.UUID) (string, error) {
+	var isrc string
+	err := r.tx.QueryRowContext(ctx,
+		`SELECT isrc FROM tracks WHERE song_id = ? AND playlist_type_id != ? AND isrc != '' ORDER BY playlist_type_id LIMIT 1;`,
+		songID, r.playlistType,
+	).Scan(&isrc)
+	if errors.Is(err, sql.ErrNoRows) {
+		return "", nil
+	}
+	if err != nil {
+		return "", err
+	}
+
+	return isrc, nil
+}
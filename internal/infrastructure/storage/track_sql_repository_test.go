@@ -0,0 +1,563 @@
+package storage
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/jbenzshawel/playlist-generator/internal/domain"
+)
+
+func TestTrackSqlRepository_GetUnknownSongs(t *testing.T) {
+	var (
+		now = formatDateTime(t, time.Now())
+
+		songID1 = uuid.New()
+		songID2 = uuid.New()
+		songID3 = uuid.New()
+	)
+
+	storage := InitTestStorage(t)
+
+	tx, err := storage.db.BeginTx(t.Context(), nil)
+	require.NoError(t, err)
+
+	songRepo := &songSqlRepository{tx: tx, stmts: storage.stmts}
+	spotifyTrackRepo := &trackSqlRepository{tx: tx, stmts: storage.stmts, playlistType: domain.SpotifyPlaylistType}
+	trackRepo := &trackSqlRepository{tx: tx, stmts: storage.stmts, playlistType: domain.AppleMusicPlaylistType}
+
+	songs := []domain.Song{
+		domain.NewSongFromDB(songID1, "artist1", "track1", "album1", "upc1", "songHash1", now),
+		domain.NewSongFromDB(songID2, "artist2", "track2", "album2", "upc2", "songHash2", now),
+		domain.NewSongFromDB(songID3, "artist3", "track3", "album3", "upc3", "songHash3", now),
+	}
+
+	require.NoError(t, songRepo.BulkInsert(t.Context(), songs))
+
+	t.Run("songs searched on another provider are unknown", func(t *testing.T) {
+		require.NoError(t, spotifyTrackRepo.Insert(t.Context(), domain.NewNotFoundTrack(domain.SpotifyPlaylistType, songID1, 1)))
+
+		actualSongs, err := trackRepo.GetUnknownSongs(t.Context())
+		require.NoError(t, err)
+
+		assert.Equal(t, songs, actualSongs)
+	})
+
+	t.Run("found and not found tracks set", func(t *testing.T) {
+		require.NoError(t, trackRepo.Insert(t.Context(), domain.NewTrackFromDB(domain.AppleMusicPlaylistType, "trackID1", "uri1", songID1, true, domain.FuzzyMatchMethod, 91, "artist1", "track1", "album1", "USUM71900001", now, 1, now, time.Time{})))
+		require.NoError(t, trackRepo.Insert(t.Context(), domain.NewNotFoundTrack(domain.AppleMusicPlaylistType, songID2, 1)))
+
+		actualSongs, err := trackRepo.GetUnknownSongs(t.Context())
+		require.NoError(t, err)
+
+		assert.Equal(t, songs[2:], actualSongs)
+	})
+
+	t.Run("track for another provider rejected", func(t *testing.T) {
+		err := trackRepo.Insert(t.Context(), domain.NewNotFoundTrack(domain.SpotifyPlaylistType, songID3, 1))
+		assert.Error(t, err)
+	})
+}
+
+func TestTrackSqlRepository_GetTracksPlayedInRange(t *testing.T) {
+	var (
+		now = formatDateTime(t, time.Now())
+
+		datePlayedYesterday    = formatDateTime(t, time.Now().AddDate(0, 0, -1))
+		datePlayedYesterdayDay = datePlayedYesterday.Format(time.DateOnly)
+		datePlayedNowDay       = now.Format(time.DateOnly)
+		exclusiveEndDate       = now.AddDate(0, 0, 1).Format(time.DateOnly)
+
+		songID1 = uuid.New()
+		songID2 = uuid.New()
+		songID3 = uuid.New()
+
+		songs = []domain.Song{
+			domain.NewSongFromDB(songID1, "artist1", "track1", "album1", "upc1", "songHash1", now),
+			domain.NewSongFromDB(songID2, "artist2", "track2", "album2", "upc2", "songHash2", now),
+			domain.NewSongFromDB(songID3, "artist3", "track3", "album3", "upc3", "songHash3", now),
+		}
+
+		songSources = []domain.SongSource{
+			domain.NewSongSourceFromDB(uuid.New(), "sourceID1", "songHash1", domain.StudioOneSourceType, "Studio One Tracks", datePlayedYesterdayDay, datePlayedYesterday, datePlayedYesterday),
+			domain.NewSongSourceFromDB(uuid.New(), "sourceID2", "songHash2", domain.StudioOneSourceType, "World Cafe", datePlayedNowDay, now, now),
+			domain.NewSongSourceFromDB(uuid.New(), "sourceID3", "songHash3", domain.StudioOneSourceType, "World Cafe", datePlayedNowDay, now, now),
+		}
+
+		tracks = []domain.Track{
+			domain.NewTrackFromDB(domain.AppleMusicPlaylistType, "trackID1", "uri1", songID1, true, domain.UPCMatchMethod, 100, "artist1", "track1", "album1", "USUM71900001", now, 1, now, time.Time{}),
+			domain.NewTrackFromDB(domain.AppleMusicPlaylistType, "trackID2", "uri2", songID2, true, domain.FuzzyMatchMethod, 82, "artist2", "track2", "album2", "", now, 1, now, time.Time{}),
+		}
+	)
+
+	storage := InitTestStorage(t)
+
+	tx, err := storage.db.BeginTx(t.Context(), nil)
+	require.NoError(t, err)
+
+	songRepo := &songSqlRepository{tx: tx, stmts: storage.stmts}
+	songSourceRepo := &songSourceSqlRepository{tx: tx, stmts: storage.stmts}
+	spotifyTrackRepo := &trackSqlRepository{tx: tx, stmts: storage.stmts, playlistType: domain.SpotifyPlaylistType}
+	trackRepo := &trackSqlRepository{tx: tx, stmts: storage.stmts, playlistType: domain.AppleMusicPlaylistType}
+
+	require.NoError(t, songRepo.BulkInsert(t.Context(), songs))
+	require.NoError(t, songSourceRepo.BulkInsert(t.Context(), songSources))
+	for _, track := range tracks {
+		require.NoError(t, trackRepo.Insert(t.Context(), track))
+	}
+	require.NoError(t, trackRepo.Insert(t.Context(), domain.NewNotFoundTrack(domain.AppleMusicPlaylistType, songID3, 1)))
+	require.NoError(t, spotifyTrackRepo.Insert(t.Context(), domain.NewTrackFromDB(domain.SpotifyPlaylistType, "spotifyID3", "spotify:track:spotifyID3", songID3, true, domain.ExactMatchMethod, 100, "artist3", "track3", "album3", "", now, 1, now, time.Time{})))
+
+	t.Run("matched tracks for the provider returned", func(t *testing.T) {
+		actual, err := trackRepo.GetTracksPlayedInRange(t.Context(), domain.StudioOneSourceType, "", datePlayedYesterdayDay, exclusiveEndDate)
+		require.NoError(t, err)
+		assert.ElementsMatch(t, tracks, actual)
+	})
+
+	t.Run("tracks returned for date range", func(t *testing.T) {
+		actual, err := trackRepo.GetTracksPlayedInRange(t.Context(), domain.StudioOneSourceType, "", datePlayedNowDay, exclusiveEndDate)
+		require.NoError(t, err)
+		assert.Equal(t, tracks[1:], actual)
+	})
+
+	t.Run("tracks returned for program", func(t *testing.T) {
+		actual, err := trackRepo.GetTracksPlayedInRange(t.Context(), domain.StudioOneSourceType, "Studio One Tracks", datePlayedYesterdayDay, exclusiveEndDate)
+		require.NoError(t, err)
+		assert.Equal(t, tracks[:1], actual)
+	})
+}
+
+func TestTrackSqlRepository_GetTracksPlayedInRange_DateRanges(t *testing.T) {
+	var (
+		now = formatDateTime(t, time.Now())
+
+		datePlayedOldest = formatDateTime(t, time.Now().AddDate(0, 0, -2))
+		datePlayedMiddle = formatDateTime(t, time.Now().AddDate(0, 0, -1))
+		datePlayedNow    = now
+
+		datePlayedOldestDay = datePlayedOldest.Format(time.DateOnly)
+		datePlayedMiddleDay = datePlayedMiddle.Format(time.DateOnly)
+		datePlayedNowDay    = datePlayedNow.Format(time.DateOnly)
+
+		songID1 = uuid.New()
+		songID2 = uuid.New()
+		songID3 = uuid.New()
+		songID4 = uuid.New()
+
+		songHash1 = "songHash1"
+		songHash2 = "songHash2"
+		songHash3 = "songHash3"
+		songHash4 = "songHash4"
+
+		songs = []domain.Song{
+			domain.NewSongFromDB(songID1, "artist1", "track1", "album1", "upc1", songHash1, now),
+			domain.NewSongFromDB(songID2, "artist2", "track2", "album2", "upc2", songHash2, now),
+			domain.NewSongFromDB(songID3, "artist3", "track3", "album3", "upc3", songHash3, now),
+			domain.NewSongFromDB(songID4, "artist4", "track4", "album4", "upc4", songHash4, now),
+		}
+
+		songSources = []domain.SongSource{
+			domain.NewSongSourceFromDB(uuid.New(), "sourceID1", songHash1, domain.StudioOneSourceType, "Studio One Tracks", datePlayedOldestDay, datePlayedOldest, datePlayedOldest),
+			domain.NewSongSourceFromDB(uuid.New(), "sourceID2", songHash2, domain.StudioOneSourceType, "Studio One Tracks", datePlayedMiddleDay, datePlayedMiddle, datePlayedMiddle),
+			domain.NewSongSourceFromDB(uuid.New(), "sourceID3", songHash3, domain.StudioOneSourceType, "Studio One Tracks", datePlayedNowDay, datePlayedNow, datePlayedNow),
+			domain.NewSongSourceFromDB(uuid.New(), "sourceID4", songHash4, domain.StudioOneSourceType, "World Cafe", datePlayedNowDay, datePlayedNow, datePlayedNow),
+		}
+
+		spotifyTracks = []domain.Track{
+			domain.NewTrackFromDB(domain.SpotifyPlaylistType, "trackID1", "uri1", songID1, true, domain.FuzzyMatchMethod, 91, "artist1", "track1", "album1", "", now, 1, now, time.Time{}),
+			domain.NewTrackFromDB(domain.SpotifyPlaylistType, "trackID2", "uri2", songID2, true, domain.FuzzyMatchMethod, 92, "artist2", "track2", "album2", "", now, 1, now, time.Time{}),
+			domain.NewTrackFromDB(domain.SpotifyPlaylistType, "trackID3", "uri3", songID3, true, domain.FuzzyMatchMethod, 93, "artist3", "track3", "album3", "", now, 1, now, time.Time{}),
+		}
+	)
+
+	storage := InitTestStorage(t)
+
+	tx, err := storage.db.BeginTx(t.Context(), nil)
+	require.NoError(t, err)
+
+	songRepo := &songSqlRepository{tx: tx, stmts: storage.stmts}
+	songSourceRepo := &songSourceSqlRepository{tx: tx, stmts: storage.stmts}
+	trackRepo := &trackSqlRepository{tx: tx, stmts: storage.stmts, playlistType: domain.SpotifyPlaylistType}
+
+	require.NoError(t, songRepo.BulkInsert(t.Context(), songs))
+	require.NoError(t, songSourceRepo.BulkInsert(t.Context(), songSources))
+
+	t.Run("no identified tracks no songs", func(t *testing.T) {
+		actual, err := trackRepo.GetTracksPlayedInRange(t.Context(), domain.StudioOneSourceType, "", datePlayedOldestDay, datePlayedNowDay)
+		require.NoError(t, err)
+		assert.Empty(t, actual)
+
+		require.NoError(t, trackRepo.Insert(t.Context(), domain.NewNotFoundTrack(domain.SpotifyPlaylistType, songID4, 1)))
+
+		actual, err = trackRepo.GetTracksPlayedInRange(t.Context(), domain.StudioOneSourceType, "", datePlayedOldestDay, datePlayedNowDay)
+		require.NoError(t, err)
+		assert.Empty(t, actual)
+	})
+
+	t.Run("all identified tracks returned", func(t *testing.T) {
+		for _, st := range spotifyTracks {
+			require.NoError(t, trackRepo.Insert(t.Context(), st))
+		}
+
+		exclusiveEndDate := time.Now().AddDate(0, 0, 1).Format(time.DateOnly)
+
+		actual, err := trackRepo.GetTracksPlayedInRange(t.Context(), domain.StudioOneSourceType, "", datePlayedOldestDay, exclusiveEndDate)
+		require.NoError(t, err)
+		assert.Equal(t, spotifyTracks[:3], actual)
+	})
+
+	t.Run("identified tracks returned for expected date range", func(t *testing.T) {
+		actual, err := trackRepo.GetTracksPlayedInRange(t.Context(), domain.StudioOneSourceType, "", datePlayedOldestDay, datePlayedMiddleDay)
+		require.NoError(t, err)
+		assert.Equal(t, spotifyTracks[:1], actual)
+
+		actual, err = trackRepo.GetTracksPlayedInRange(t.Context(), domain.StudioOneSourceType, "", datePlayedMiddleDay, datePlayedNowDay)
+		require.NoError(t, err)
+		assert.Equal(t, spotifyTracks[1:2], actual)
+	})
+
+	t.Run("identified tracks returned for program", func(t *testing.T) {
+		track4 := domain.NewTrackFromDB(domain.SpotifyPlaylistType, "trackID4", "uri4", songID4, true, domain.UPCMatchMethod, 94, "artist4", "track4", "album4", "", now, 1, now, time.Time{})
+		require.NoError(t, trackRepo.Replace(t.Context(), track4))
+
+		exclusiveEndDate := time.Now().AddDate(0, 0, 1).Format(time.DateOnly)
+
+		actual, err := trackRepo.GetTracksPlayedInRange(t.Context(), domain.StudioOneSourceType, "World Cafe", datePlayedOldestDay, exclusiveEndDate)
+		require.NoError(t, err)
+		assert.Equal(t, []domain.Track{track4}, actual)
+
+		actual, err = trackRepo.GetTracksPlayedInRange(t.Context(), domain.StudioOneSourceType, "Studio One Tracks", datePlayedOldestDay, exclusiveEndDate)
+		require.NoError(t, err)
+		assert.Equal(t, spotifyTracks[:3], actual)
+	})
+}
+
+func TestTrackSqlRepository_GetLowConfidenceSongs(t *testing.T) {
+	var (
+		now = formatDateTime(t, time.Now())
+
+		songID1 = uuid.New()
+		songID2 = uuid.New()
+		songID3 = uuid.New()
+	)
+
+	storage := InitTestStorage(t)
+
+	tx, err := storage.db.BeginTx(t.Context(), nil)
+	require.NoError(t, err)
+
+	songRepo := &songSqlRepository{tx: tx, stmts: storage.stmts}
+	trackRepo := &trackSqlRepository{tx: tx, stmts: storage.stmts, playlistType: domain.SpotifyPlaylistType}
+	appleMusicRepo := &trackSqlRepository{tx: tx, stmts: storage.stmts, playlistType: domain.AppleMusicPlaylistType}
+
+	songs := []domain.Song{
+		domain.NewSongFromDB(songID1, "artist1", "track1", "album1", "upc1", "songHash1", now),
+		domain.NewSongFromDB(songID2, "artist2", "track2", "album2", "upc2", "songHash2", now),
+		domain.NewSongFromDB(songID3, "artist3", "track3", "album3", "upc3", "songHash3", now),
+	}
+
+	require.NoError(t, songRepo.BulkInsert(t.Context(), songs))
+	require.NoError(t, trackRepo.Insert(t.Context(), domain.NewTrackFromDB(domain.SpotifyPlaylistType, "trackID1", "uri1", songID1, true, domain.ExactMatchMethod, 100, "artist1", "track1", "album1", "", now, 1, now, time.Time{})))
+	require.NoError(t, trackRepo.Insert(t.Context(), domain.NewTrackFromDB(domain.SpotifyPlaylistType, "trackID2", "uri2", songID2, true, domain.FuzzyMatchMethod, 72.5, "artist2", "track2", "other", "", now, 1, now, time.Time{})))
+	require.NoError(t, trackRepo.Insert(t.Context(), domain.NewNotFoundTrack(domain.SpotifyPlaylistType, songID3, 1)))
+	require.NoError(t, appleMusicRepo.Insert(t.Context(), domain.NewTrackFromDB(domain.AppleMusicPlaylistType, "trackID1", "uri1", songID1, true, domain.FuzzyMatchMethod, 71, "artist1", "track1", "other", "", now, 1, now, time.Time{})))
+
+	t.Run("matched songs below confidence returned", func(t *testing.T) {
+		actualSongs, err := trackRepo.GetLowConfidenceSongs(t.Context(), 80)
+		require.NoError(t, err)
+
+		assert.Equal(t, songs[1:2], actualSongs)
+	})
+
+	t.Run("replace low confidence match", func(t *testing.T) {
+		track := domain.NewTrackFromDB(domain.SpotifyPlaylistType, "trackID2b", "uri2b", songID2, true, domain.UPCMatchMethod, 100, "artist2", "track2", "album2", "", now, 1, now, time.Time{})
+		require.NoError(t, trackRepo.Replace(t.Context(), track))
+
+		actualSongs, err := trackRepo.GetLowConfidenceSongs(t.Context(), 80)
+		require.NoError(t, err)
+		assert.Empty(t, actualSongs)
+
+		assert.Contains(t, getAllTracks(t, tx, domain.SpotifyPlaylistType), track)
+		assert.Len(t, getAllTracks(t, tx, domain.SpotifyPlaylistType), 3)
+		assert.Len(t, getAllTracks(t, tx, domain.AppleMusicPlaylistType), 1)
+	})
+}
+
+func TestTrackSqlRepository_GetRetryableSongs(t *testing.T) {
+	var (
+		now       = formatDateTime(t, time.Now())
+		yesterday = now.AddDate(0, 0, -1)
+		tomorrow  = now.AddDate(0, 0, 1)
+
+		songID1 = uuid.New()
+		songID2 = uuid.New()
+		songID3 = uuid.New()
+		songID4 = uuid.New()
+	)
+
+	storage := InitTestStorage(t)
+
+	tx, err := storage.db.BeginTx(t.Context(), nil)
+	require.NoError(t, err)
+
+	songRepo := &songSqlRepository{tx: tx, stmts: storage.stmts}
+	trackRepo := &trackSqlRepository{tx: tx, stmts: storage.stmts, playlistType: domain.SpotifyPlaylistType}
+
+	songs := []domain.Song{
+		domain.NewSongFromDB(songID1, "artist1", "track1", "album1", "upc1", "songHash1", now),
+		domain.NewSongFromDB(songID2, "artist2", "track2", "album2", "upc2", "songHash2", now),
+		domain.NewSongFromDB(songID3, "artist3", "track3", "album3", "upc3", "songHash3", now),
+		domain.NewSongFromDB(songID4, "artist4", "track4", "album4", "upc4", "songHash4", now),
+	}
+
+	require.NoError(t, songRepo.BulkInsert(t.Context(), songs))
+	// matched
+	require.NoError(t, trackRepo.Insert(t.Context(), domain.NewTrackFromDB(domain.SpotifyPlaylistType, "trackID1", "uri1", songID1, true, domain.ExactMatchMethod, 100, "artist1", "track1", "album1", "", now, 1, now, time.Time{})))
+	// not found, next attempt passed
+	require.NoError(t, trackRepo.Insert(t.Context(), domain.NewTrackFromDB(domain.SpotifyPlaylistType, "", "", songID2, false, domain.UnknownMatchMethod, 0, "", "", "", "", time.Time{}, 2, yesterday, now)))
+	// not found, next attempt in the future
+	require.NoError(t, trackRepo.Insert(t.Context(), domain.NewTrackFromDB(domain.SpotifyPlaylistType, "", "", songID3, false, domain.UnknownMatchMethod, 0, "", "", "", "", time.Time{}, 1, now, tomorrow)))
+	// never searched for again
+	require.NoError(t, trackRepo.Insert(t.Context(), domain.NewNeverMatchTrack(domain.SpotifyPlaylistType, songID4)))
+
+	actualSongs, err := trackRepo.GetRetryableSongs(t.Context(), now)
+	require.NoError(t, err)
+	assert.Equal(t, songs[1:2], actualSongs)
+
+	actualSongs, err = trackRepo.GetRetryableSongs(t.Context(), tomorrow)
+	require.NoError(t, err)
+	assert.Equal(t, songs[1:3], actualSongs)
+}
+
+func TestTrackSqlRepository_GetRandomTracks(t *testing.T) {
+	storage := InitTestStorage(t)
+
+	tx, err := storage.db.BeginTx(t.Context(), nil)
+	require.NoError(t, err)
+
+	trackRepo := &trackSqlRepository{tx: tx, stmts: storage.stmts, playlistType: domain.SpotifyPlaylistType}
+
+	for idx := range 30 {
+		track := domain.NewTrack(domain.SpotifyPlaylistType, uuid.New(), fmt.Sprintf("track%d", idx), fmt.Sprintf("uri%d", idx), domain.FuzzyMatchMethod, 80, "artist", "track", "album", "")
+		require.NoError(t, trackRepo.Insert(t.Context(), track))
+	}
+
+	actualTracks1, err := trackRepo.GetRandomTracks(t.Context(), 10)
+	require.NoError(t, err)
+
+	assertUniqueTracks(t, actualTracks1)
+	assert.Len(t, actualTracks1, 10)
+
+	actualTracks2, err := trackRepo.GetRandomTracks(t.Context(), 10)
+	require.NoError(t, err)
+
+	assertUniqueTracks(t, actualTracks2)
+	assert.Len(t, actualTracks2, 10)
+
+	assert.NotEqual(t, actualTracks1, actualTracks2)
+}
+
+func TestTrackSqlRepository_Replace(t *testing.T) {
+	var (
+		now    = formatDateTime(t, time.Now())
+		songID = uuid.New()
+	)
+
+	storage := InitTestStorage(t)
+
+	tx, err := storage.db.BeginTx(t.Context(), nil)
+	require.NoError(t, err)
+
+	songRepo := &songSqlRepository{tx: tx, stmts: storage.stmts}
+	trackRepo := &trackSqlRepository{tx: tx, stmts: storage.stmts, playlistType: domain.AppleMusicPlaylistType}
+
+	require.NoError(t, songRepo.BulkInsert(t.Context(), []domain.Song{
+		domain.NewSongFromDB(songID, "artist1", "track1", "album1", "upc1", "songHash1", now),
+	}))
+
+	t.Run("song not searched for", func(t *testing.T) {
+		actual, err := trackRepo.GetTrackBySongID(t.Context(), songID)
+		require.NoError(t, err)
+		assert.Equal(t, domain.Track{}, actual)
+	})
+
+	t.Run("not found track replaced by match", func(t *testing.T) {
+		require.NoError(t, trackRepo.Insert(t.Context(), domain.NewTrackFromDB(domain.AppleMusicPlaylistType, "", "", songID, false, domain.UnknownMatchMethod, 0, "", "", "", "", time.Time{}, 1, now, now.AddDate(0, 0, 1))))
+
+		track := domain.NewTrackFromDB(domain.AppleMusicPlaylistType, "trackID1", "uri1", songID, true, domain.ExactMatchMethod, 100, "artist1", "track1", "album1", "USUM71900001", now, 2, now, time.Time{})
+		require.NoError(t, trackRepo.Replace(t.Context(), track))
+
+		actual, err := trackRepo.GetTrackBySongID(t.Context(), songID)
+		require.NoError(t, err)
+		assert.Equal(t, track, actual)
+
+		replaced, err := trackRepo.GetReplacedURIs(t.Context())
+		require.NoError(t, err)
+		assert.Empty(t, replaced)
+	})
+}
+
+func TestTrackSqlRepository_GetReplacedURIs(t *testing.T) {
+	var (
+		now = formatDateTime(t, time.Now())
+
+		songID1 = uuid.New()
+		songID2 = uuid.New()
+		songID3 = uuid.New()
+	)
+
+	storage := InitTestStorage(t)
+
+	tx, err := storage.db.BeginTx(t.Context(), nil)
+	require.NoError(t, err)
+
+	songRepo := &songSqlRepository{tx: tx, stmts: storage.stmts}
+	trackRepo := &trackSqlRepository{tx: tx, stmts: storage.stmts, playlistType: domain.SpotifyPlaylistType}
+	appleMusicTrackRepo := &trackSqlRepository{tx: tx, stmts: storage.stmts, playlistType: domain.AppleMusicPlaylistType}
+
+	require.NoError(t, songRepo.BulkInsert(t.Context(), []domain.Song{
+		domain.NewSongFromDB(songID1, "artist1", "track1", "album1", "", "songHash1", now),
+		domain.NewSongFromDB(songID2, "artist2", "track2", "album2", "", "songHash2", now),
+		domain.NewSongFromDB(songID3, "artist3", "track3", "album3", "", "songHash3", now),
+	}))
+
+	newTrack := func(songID uuid.UUID, id string) domain.Track {
+		return domain.NewTrackFromDB(domain.SpotifyPlaylistType, id, "spotify:track:"+id, songID, true, domain.FuzzyMatchMethod, 72, "artist", "track", "album", "", now, 1, now, time.Time{})
+	}
+
+	require.NoError(t, trackRepo.Insert(t.Context(), newTrack(songID1, "trackID1")))
+	require.NoError(t, trackRepo.Insert(t.Context(), newTrack(songID2, "shared")))
+	require.NoError(t, trackRepo.Insert(t.Context(), newTrack(songID3, "shared")))
+
+	t.Run("rematch recorded", func(t *testing.T) {
+		require.NoError(t, trackRepo.Replace(t.Context(), newTrack(songID1, "trackID1b")))
+
+		actual, err := trackRepo.GetReplacedURIs(t.Context())
+		require.NoError(t, err)
+		assert.Equal(t, map[string]string{"spotify:track:trackID1": "spotify:track:trackID1b"}, actual)
+	})
+
+	t.Run("earlier replacements point at the newest match", func(t *testing.T) {
+		require.NoError(t, trackRepo.Replace(t.Context(), newTrack(songID1, "trackID1c")))
+
+		actual, err := trackRepo.GetReplacedURIs(t.Context())
+		require.NoError(t, err)
+		assert.Equal(t, map[string]string{
+			"spotify:track:trackID1":  "spotify:track:trackID1c",
+			"spotify:track:trackID1b": "spotify:track:trackID1c",
+		}, actual)
+	})
+
+	t.Run("never match replaces with an empty uri", func(t *testing.T) {
+		require.NoError(t, trackRepo.Replace(t.Context(), domain.NewNotFoundTrack(domain.SpotifyPlaylistType, songID1, 2)))
+
+		actual, err := trackRepo.GetReplacedURIs(t.Context())
+		require.NoError(t, err)
+		assert.Equal(t, map[string]string{
+			"spotify:track:trackID1":  "",
+			"spotify:track:trackID1b": "",
+			"spotify:track:trackID1c": "",
+		}, actual)
+	})
+
+	t.Run("match after never match replaces earlier matches", func(t *testing.T) {
+		require.NoError(t, trackRepo.Replace(t.Context(), newTrack(songID1, "trackID1")))
+
+		actual, err := trackRepo.GetReplacedURIs(t.Context())
+		require.NoError(t, err)
+		assert.Equal(t, map[string]string{
+			"spotify:track:trackID1b": "spotify:track:trackID1",
+			"spotify:track:trackID1c": "spotify:track:trackID1",
+		}, actual)
+	})
+
+	t.Run("uri still matched to another song not replaced", func(t *testing.T) {
+		require.NoError(t, trackRepo.Replace(t.Context(), newTrack(songID2, "trackID2")))
+
+		actual, err := trackRepo.GetReplacedURIs(t.Context())
+		require.NoError(t, err)
+		assert.NotContains(t, actual, "spotify:track:shared")
+	})
+
+	t.Run("replacements on another provider not returned", func(t *testing.T) {
+		actual, err := appleMusicTrackRepo.GetReplacedURIs(t.Context())
+		require.NoError(t, err)
+		assert.Empty(t, actual)
+	})
+}
+
+func TestTrackSqlRepository_GetISRC(t *testing.T) {
+	var (
+		now = formatDateTime(t, time.Now())
+
+		songID1 = uuid.New()
+		songID2 = uuid.New()
+	)
+
+	storage := InitTestStorage(t)
+
+	tx, err := storage.db.BeginTx(t.Context(), nil)
+	require.NoError(t, err)
+
+	songRepo := &songSqlRepository{tx: tx, stmts: storage.stmts}
+	appleMusicRepo := &trackSqlRepository{tx: tx, stmts: storage.stmts, playlistType: domain.AppleMusicPlaylistType}
+	tidalRepo := &trackSqlRepository{tx: tx, stmts: storage.stmts, playlistType: domain.TidalPlaylistType}
+
+	require.NoError(t, songRepo.BulkInsert(t.Context(), []domain.Song{
+		domain.NewSongFromDB(songID1, "artist1", "track1", "album1", "upc1", "songHash1", now),
+		domain.NewSongFromDB(songID2, "artist2", "track2", "album2", "upc2", "songHash2", now),
+	}))
+	require.NoError(t, appleMusicRepo.Insert(t.Context(), domain.NewTrackFromDB(domain.AppleMusicPlaylistType, "trackID1", "uri1", songID1, true, domain.ExactMatchMethod, 100, "artist1", "track1", "album1", "USUM71900001", now, 1, now, time.Time{})))
+	require.NoError(t, appleMusicRepo.Insert(t.Context(), domain.NewNotFoundTrack(domain.AppleMusicPlaylistType, songID2, 1)))
+
+	t.Run("isrc matched on another provider", func(t *testing.T) {
+		actual, err := tidalRepo.GetISRC(t.Context(), songID1)
+		require.NoError(t, err)
+		assert.Equal(t, "USUM71900001", actual)
+	})
+
+	t.Run("no match with an isrc", func(t *testing.T) {
+		actual, err := tidalRepo.GetISRC(t.Context(), songID2)
+		require.NoError(t, err)
+		assert.Empty(t, actual)
+	})
+
+	t.Run("isrc of the provider's own match not returned", func(t *testing.T) {
+		actual, err := appleMusicRepo.GetISRC(t.Context(), songID1)
+		require.NoError(t, err)
+		assert.Empty(t, actual)
+	})
+}
+
+func assertUniqueTracks(t *testing.T, tracks []domain.Track) {
+	trackURIs := map[string]struct{}{}
+	for _, track := range tracks {
+		trackURIs[track.URI()] = struct{}{}
+	}
+	assert.Len(t, tracks, len(trackURIs))
+}
+
+func getAllTracks(t *testing.T, db queryContexter, playlistType domain.PlaylistType) []domain.Track {
+	rows, err := db.QueryContext(
+		t.Context(),
+		`SELECT id, uri, song_id, match_found, match_method_id, confidence, matched_artist, matched_track, matched_album, isrc, matched_at,
+				attempt_count, last_attempt, next_attempt
+			FROM tracks
+			WHERE playlist_type_id = ?;`,
+		playlistType,
+	)
+	require.NoError(t, err)
+
+	defer func() {
+		require.NoError(t, rows.Close())
+	}()
+
+	results, err := (&trackSqlRepository{playlistType: playlistType}).scanTracks(rows)
+	require.NoError(t, err)
+
+	return results
+}
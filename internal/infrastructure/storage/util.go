@@ -17,6 +17,22 @@ func utcStringToTime(s string) (time.Time, error) {
 	return t.Local(), nil
 }
 
+// optionalTimeToUTCString stores the zero time as an empty string
+func optionalTimeToUTCString(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+	return timeToUTCString(t)
+}
+
+// optionalUTCStringToTime returns the zero time for an empty string
+func optionalUTCStringToTime(s string) (time.Time, error) {
+	if s == "" {
+		return time.Time{}, nil
+	}
+	return utcStringToTime(s)
+}
+
 func boolToInt(b bool) int {
 	if b {
 		return 1
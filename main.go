@@ -9,15 +9,31 @@ import (
 	"time"
 
 	"github.com/jbenzshawel/playlist-generator/internal/app"
+	"github.com/jbenzshawel/playlist-generator/internal/app/commands/playlists/spotify"
+	"github.com/jbenzshawel/playlist-generator/internal/app/config"
 )
 
 func main() {
 	defaultDate := time.Now().Format(time.DateOnly)
-	actionFlag := flag.String("action", string(app.SyncDayAction), "the action the generator runs (syncDay, syncMonth, recurring, or random)")
-	dateFlag := flag.String("date", defaultDate, "the date to download songs for in YYYY-MM-DD (syncDay action)")
+	actionFlag := flag.String("action", string(app.SyncDayAction), "the action the generator runs (syncDay, syncMonth, recurring, random, rolling, programs, rematch, match, researchMissing, review, export, auth, or migrate)")
+	dateFlag := flag.String("date", defaultDate, "the date to download songs for in YYYY-MM-DD (syncDay action, the start date for the programs action, or any date in the period to export)")
+	toFlag := flag.String("to", "", "the inclusive end date in YYYY-MM-DD, defaults to date (programs action)")
 	monthFlag := flag.String("month", "", "the month to download songs for in YYYY-MM (syncMonth action)")
+	scopeFlag := flag.String("scope", "month", "the playlist date scope (day, month, or year)")
+	sourceFlag := flag.String("source", "", "the name of the source to export, optional when one source is configured (export action)")
+	formatFlag := flag.String("format", "m3u8", "the export file format (m3u8, xspf, csv, or json) (export action)")
+	outFlag := flag.String("out", "", "the file to export to, defaults to standard output (export action)")
 	intervalFlag := flag.Int("interval", 60, "the interval between downloading songs for in minutes (recurring action)")
 	numTracks := flag.Int("numTracks", 50, "the number of random tracks to include in the random tracks playlist (random action)")
+	daysFlag := flag.Int("days", 0, "the number of days in the rolling playlist, defaults to 30 (rolling action, or recurring action when set)")
+	confidenceFlag := flag.Float64("confidence", 0, "the match confidence from 0 to 100 below which songs are searched again, defaults to 80 (rematch action)")
+	hashFlag := flag.String("hash", "", "the song hash of the song to override (match action)")
+	artistFlag := flag.String("artist", "", "the artist of the songs to override when hash isn't set (match action)")
+	trackFlag := flag.String("track", "", "the track name of the songs to override when hash isn't set (match action)")
+	uriFlag := flag.String("uri", "", "the Spotify track URI or URL to match the songs to (match action)")
+	neverMatchFlag := flag.Bool("neverMatch", false, "never match the songs to a Spotify track (match action)")
+	dbPathFlag := flag.String("dbPath", "", "the SQLite database file, or :memory: for a database discarded when the run ends, overrides storage.path in config.json (defaults to db/app.db)")
+	dsnFlag := flag.String("dsn", "", "the database DSN, a postgres:// URL or a SQLite DSN, overrides storage in config.json")
 	verboseFlag := flag.Bool("verbose", false, "include detailed logs")
 
 	flag.Parse()
@@ -31,18 +47,65 @@ func main() {
 	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, os.Kill)
 	defer stop()
 
-	application, closer := app.NewApplication(ctx)
+	storageFlags := config.Storage{Path: *dbPathFlag, DSN: *dsnFlag}
+
+	if app.Action(*actionFlag) == app.AuthAction {
+		err := app.Login(ctx, storageFlags, flag.Arg(0), os.Stdin, os.Stdout)
+		if err != nil {
+			slog.Error("login error", slog.Any("error", err))
+		}
+		return
+	}
+
+	if app.Action(*actionFlag) == app.MigrateAction {
+		err := app.Migrate(ctx, storageFlags, flag.Arg(0), os.Stdout)
+		if err != nil {
+			slog.Error("migrate error", slog.Any("error", err))
+		}
+		return
+	}
+
+	if app.Action(*actionFlag) == app.ExportAction {
+		err := app.Export(ctx, storageFlags, app.ExportConfig{
+			Source: *sourceFlag,
+			Date:   *dateFlag,
+			Scope:  *scopeFlag,
+			Format: *formatFlag,
+			Path:   *outFlag,
+		}, os.Stdout)
+		if err != nil {
+			slog.Error("export error", slog.Any("error", err))
+		}
+		return
+	}
+
+	application, closer, err := app.NewApplication(ctx, storageFlags)
+	if err != nil {
+		slog.Error("startup error", slog.Any("error", err))
+		return
+	}
 	defer closer()
 
 	select {
 	case <-ctx.Done():
 	default:
 		application.Run(ctx, app.RunConfig{
-			Action:    app.Action(*actionFlag),
-			Date:      *dateFlag,
-			Month:     *monthFlag,
-			Interval:  time.Duration(*intervalFlag) * time.Minute,
-			NumTracks: *numTracks,
+			Action:        app.Action(*actionFlag),
+			Date:          *dateFlag,
+			Month:         *monthFlag,
+			Scope:         *scopeFlag,
+			To:            *toFlag,
+			Interval:      time.Duration(*intervalFlag) * time.Minute,
+			NumTracks:     *numTracks,
+			RollingDays:   *daysFlag,
+			MinConfidence: *confidenceFlag,
+			Match: spotify.MatchSongCommand{
+				SongHash:   *hashFlag,
+				Artist:     *artistFlag,
+				Track:      *trackFlag,
+				TrackURI:   *uriFlag,
+				NeverMatch: *neverMatchFlag,
+			},
 		})
 	}
 }